@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/base32"
 	"errors"
 	"flag"
@@ -307,7 +308,7 @@ func main() {
 	}
 
 	// just do it.
-	if err := keydesk.CreateBrigade(db, vpnCfgs, config, &routerPublicKey, &shufflerPublicKey, mode, maxUsers, vip); err != nil {
+	if err := keydesk.CreateBrigade(context.Background(), db, vpnCfgs, config, &routerPublicKey, &shufflerPublicKey, mode, maxUsers, vip); err != nil {
 		log.Fatalf("Can't create brigade: %s\n", err)
 	}
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/base32"
 	"flag"
 	"fmt"
@@ -109,7 +110,7 @@ func main() {
 	}
 
 	// just do it.
-	if err := keydesk.DestroyBrigade(db, force); err != nil {
+	if err := keydesk.DestroyBrigade(context.Background(), db, force); err != nil {
 		log.Fatalf("Can't destroy brigade: %s\n", err)
 	}
 }
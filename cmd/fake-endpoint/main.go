@@ -0,0 +1,46 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/vpngen/keydesk/internal/fakeendpoint"
+)
+
+func main() {
+	listen := flag.String("l", "127.0.0.1:8080", "Listen address:port")
+	step := flag.Uint64("traffic-step", fakeendpoint.DefaultTrafficStep, "Peer traffic growth per stat call, bytes")
+	faultCall := flag.String("fault-call", "", "Faulty call (wg_add, wg_del, peer_add, peer_del, throttle_on, throttle_off, peer_list, stat), empty - any")
+	latency := flag.Duration("fault-latency", 0, "Fault: response delay")
+	hang := flag.Bool("fault-hang", false, "Fault: no response till the client gives up")
+	status := flag.Int("fault-status", 0, "Fault: HTTP status instead of 200")
+	code := flag.String("fault-code", "", "Fault: API code instead of the call result, i.e. 151")
+	times := flag.Int("fault-times", 0, "Fault: faulty calls number, 0 - all")
+
+	flag.Parse()
+
+	s := fakeendpoint.New()
+	s.TrafficStep = *step
+
+	if *latency > 0 || *hang || *status != 0 || *code != "" {
+		s.Inject(*faultCall, fakeendpoint.Fault{
+			Latency: *latency,
+			Hang:    *hang,
+			Status:  *status,
+			Code:    *code,
+			Times:   *times,
+		})
+	}
+
+	srv := &http.Server{
+		Addr:              *listen,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	log.Printf("Fake endpoint API: %s\n", *listen)
+
+	log.Fatal(srv.ListenAndServe())
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// Output formats.
+const (
+	formatJSON   = "json"   // AggrStats
+	formatNDJSON = "ndjson" // one brigade stats per line
+	formatCSV    = "csv"    // one brigade per row, -columns
+)
+
+var formats = []string{formatJSON, formatNDJSON, formatCSV}
+
+// ErrUnknownColumn is an error for unknown CSV column.
+var ErrUnknownColumn = errors.New("unknown column")
+
+var defaultColumns = []string{
+	"brigade_id", "update_time", "vip", "last_activity",
+	"total_users", "active_users", "total_rx", "total_tx",
+}
+
+var csvColumns = map[string]func(s *storage.Stats) string{
+	"brigade_id":           func(s *storage.Stats) string { return s.BrigadeID },
+	"version":              func(s *storage.Stats) string { return strconv.Itoa(s.Ver) },
+	"update_time":          func(s *storage.Stats) string { return formatTime(s.UpdateTime) },
+	"brigade_created_at":   func(s *storage.Stats) string { return formatTime(s.BrigadeCreatedAt) },
+	"keydesk_first_visit":  func(s *storage.Stats) string { return formatTime(s.KeydeskFirstVisit) },
+	"last_activity":        func(s *storage.Stats) string { return formatTime(s.LastActivity) },
+	"vip":                  func(s *storage.Stats) string { return strconv.FormatBool(s.VIP) },
+	"total_users":          func(s *storage.Stats) string { return strconv.Itoa(s.TotalUsersCount) },
+	"active_users":         func(s *storage.Stats) string { return strconv.Itoa(s.ActiveUsersCount) },
+	"active_wg_users":      func(s *storage.Stats) string { return strconv.Itoa(s.ActiveWgUsersCount) },
+	"active_ipsec_users":   func(s *storage.Stats) string { return strconv.Itoa(s.ActiveIPSecUsersCount) },
+	"active_ovc_users":     func(s *storage.Stats) string { return strconv.Itoa(s.ActiveOvcUsersCount) },
+	"active_olc_users":     func(s *storage.Stats) string { return strconv.Itoa(s.ActiveOlcUsersCount) },
+	"active_outline_users": func(s *storage.Stats) string { return strconv.Itoa(s.ActiveOutlineUsersCount) },
+	"active_proto0_users":  func(s *storage.Stats) string { return strconv.Itoa(s.ActiveProto0UsersCount) },
+	"throttled_users":      func(s *storage.Stats) string { return strconv.Itoa(s.ThrottledUsersCount) },
+	"blocked_users":        func(s *storage.Stats) string { return strconv.Itoa(s.BlockedUsersCount) },
+	"users_50gb":           func(s *storage.Stats) string { return strconv.Itoa(s.Users50gb.TotalUsersCount) },
+	"users_100gb":          func(s *storage.Stats) string { return strconv.Itoa(s.Users100gb.TotalUsersCount) },
+	"users_500gb":          func(s *storage.Stats) string { return strconv.Itoa(s.Users500gb.TotalUsersCount) },
+	"users_1000gb":         func(s *storage.Stats) string { return strconv.Itoa(s.Users1000gb.TotalUsersCount) },
+	"total_rx":             func(s *storage.Stats) string { return strconv.FormatUint(s.TotalTraffic.Rx, 10) },
+	"total_tx":             func(s *storage.Stats) string { return strconv.FormatUint(s.TotalTraffic.Tx, 10) },
+	"yesterday_rx":         func(s *storage.Stats) string { return strconv.FormatUint(s.YesterdayTraffic.Rx, 10) },
+	"yesterday_tx":         func(s *storage.Stats) string { return strconv.FormatUint(s.YesterdayTraffic.Tx, 10) },
+}
+
+func isFormat(format string) bool {
+	return slices.Contains(formats, format)
+}
+
+func csvColumnNames() []string {
+	names := make([]string, 0, len(csvColumns))
+	for name := range csvColumns {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+func checkColumns(columns []string) error {
+	for _, name := range columns {
+		if _, ok := csvColumns[name]; !ok {
+			return fmt.Errorf("%q: %w", name, ErrUnknownColumn)
+		}
+	}
+
+	return nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+// writeStats prints the stats in the format, the errors are the part of the json format only.
+func writeStats(w io.Writer, format string, columns []string, stats []*storage.Stats, errs []StatsError) error {
+	switch format {
+	case formatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, s := range stats {
+			if err := enc.Encode(s); err != nil {
+				return fmt.Errorf("encode: %w", err)
+			}
+		}
+
+		return nil
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return fmt.Errorf("csv header: %w", err)
+		}
+
+		row := make([]string, len(columns))
+		for _, s := range stats {
+			for i, name := range columns {
+				row[i] = csvColumns[name](s)
+			}
+
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("csv: %w", err)
+			}
+		}
+
+		cw.Flush()
+
+		if err := cw.Error(); err != nil {
+			return fmt.Errorf("csv: %w", err)
+		}
+
+		return nil
+	default:
+		buf, err := json.MarshalIndent(&AggrStats{Ver: AggrStatsVersion, Stats: stats, Errors: errs}, " ", " ")
+		if err != nil {
+			return fmt.Errorf("encode: %w", err)
+		}
+
+		if _, err := fmt.Fprintln(w, string(buf)); err != nil {
+			return fmt.Errorf("write: %w", err)
+		}
+
+		return nil
+	}
+}
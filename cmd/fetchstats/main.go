@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"encoding/base32"
 	"encoding/json"
 	"errors"
@@ -12,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/vpngen/keydesk/kdlib/lockedfile"
@@ -23,8 +25,16 @@ const AggrStatsVersion = 1
 
 // AggrStats is a structure for aggregated stats.
 type AggrStats struct {
-	Ver   int              `json:"version"`
-	Stats []*storage.Stats `json:"stats"`
+	Ver    int              `json:"version"`
+	Stats  []*storage.Stats `json:"stats"`
+	Errors []StatsError     `json:"errors,omitempty"`
+}
+
+// StatsError is a brigade stats file which can't be read.
+type StatsError struct {
+	BrigadeID string `json:"brigade_id"`
+	File      string `json:"file"`
+	Error     string `json:"error"`
 }
 
 var (
@@ -32,22 +42,53 @@ var (
 	ErrEmptyBrigadeName = errors.New("empty name")
 	// ErrEmptyBrigadeList is an error for empty brigade list.
 	ErrEmptyBrigadeList = errors.New("empty list")
+	// ErrUnknownFormat is an error for unknown output format.
+	ErrUnknownFormat = errors.New("unknown format")
 )
 
+// Filter selects the brigades stats to output.
+type Filter struct {
+	ActiveSince time.Time // zero - any
+	VIPOnly     bool
+}
+
+// Match reports whether the stats pass the filter.
+func (f Filter) Match(stats *storage.Stats) bool {
+	if !f.ActiveSince.IsZero() && stats.LastActivity.Before(f.ActiveSince) {
+		return false
+	}
+
+	if f.VIPOnly && !stats.VIP {
+		return false
+	}
+
+	return true
+}
+
+type config struct {
+	chunked      bool
+	statsBaseDir string
+	brigades     []string
+	format       string
+	columns      []string
+	filter       Filter
+}
+
 func main() {
 	var w io.WriteCloser
 
-	chunked, statsBaseDir, brigades, err := parseArgs()
+	cfg, err := parseArgs()
 	if err != nil {
 		log.Fatalf("Invalid flags: %s\n", err)
 	}
 
-	stats, err := getStats(statsBaseDir, brigades)
-	if err != nil {
-		log.Fatalf("Can't harvest: %s\n", err)
+	stats, errs := getStats(cfg.statsBaseDir, cfg.brigades, cfg.filter)
+
+	for _, e := range errs {
+		fmt.Fprintf(os.Stderr, "Brigade %s: %s: %s\n", e.BrigadeID, e.File, e.Error)
 	}
 
-	switch chunked {
+	switch cfg.chunked {
 	case true:
 		w = httputil.NewChunkedWriter(os.Stdout)
 		defer w.Close()
@@ -55,38 +96,41 @@ func main() {
 		w = os.Stdout
 	}
 
-	if _, err := w.Write(stats); err != nil {
+	if err := writeStats(w, cfg.format, cfg.columns, stats, errs); err != nil {
 		log.Fatalf("Print stats: %s", err)
 	}
-
-	fmt.Fprintln(w)
 }
 
-func getStats(statsBaseDir string, brigades []string) ([]byte, error) {
-	astats := &AggrStats{
-		Ver: AggrStatsVersion,
-	}
+// getStats reads the brigades stats, the unreadable files are reported as errors.
+func getStats(statsBaseDir string, brigades []string, filter Filter) ([]*storage.Stats, []StatsError) {
+	var (
+		list []*storage.Stats
+		errs []StatsError
+	)
 
 	for _, id := range brigades {
-		buf, err := readStatsFile(filepath.Join(statsBaseDir, id, storage.StatsFilename))
+		filename := filepath.Join(statsBaseDir, id, storage.StatsFilename)
+
+		buf, err := readStatsFile(filename)
 		if err != nil {
+			errs = append(errs, StatsError{BrigadeID: id, File: filename, Error: err.Error()})
+
 			continue
 		}
 
 		stats := &storage.Stats{}
 		if err := json.Unmarshal(buf, stats); err != nil {
+			errs = append(errs, StatsError{BrigadeID: id, File: filename, Error: fmt.Sprintf("decode: %s", err)})
+
 			continue
 		}
 
-		astats.Stats = append(astats.Stats, stats)
-	}
-
-	buf, err := json.MarshalIndent(astats, " ", " ")
-	if err != nil {
-		return nil, fmt.Errorf("encode: %w", err)
+		if filter.Match(stats) {
+			list = append(list, stats)
+		}
 	}
 
-	return buf, nil
+	return list, errs
 }
 
 func readStatsFile(filename string) ([]byte, error) {
@@ -105,42 +149,158 @@ func readStatsFile(filename string) ([]byte, error) {
 	return buf, nil
 }
 
-func parseArgs() (bool, string, []string, error) {
-	var statsdir string
+// discoverBrigades lists the brigades by the stats base dir subdirectories.
+func discoverBrigades(statsBaseDir string) ([]string, error) {
+	entries, err := os.ReadDir(statsBaseDir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir: %w", err)
+	}
+
+	var brigades []string
+
+	for _, entry := range entries {
+		if !entry.IsDir() || checkBrigadeID(entry.Name()) != nil {
+			continue
+		}
+
+		brigades = append(brigades, entry.Name())
+	}
+
+	return brigades, nil
+}
+
+// readBrigadesList reads the brigades list file, the first field of each line is a brigade id.
+func readBrigadesList(filename string) ([]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	defer f.Close()
+
+	var brigades []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.FieldsFunc(scanner.Text(), func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ';' || r == ','
+		})
+
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		brigades = append(brigades, fields[0])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	return brigades, nil
+}
+
+func checkBrigadeID(id string) error {
+	if id == "" {
+		return fmt.Errorf("brigade id: %w", ErrEmptyBrigadeName)
+	}
+
+	// brigadeID must be base32 decodable.
+	binID, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(id)
+	if err != nil {
+		return fmt.Errorf("id base32: %s: %w", id, err)
+	}
+
+	_, err = uuid.FromBytes(binID)
+	if err != nil {
+		return fmt.Errorf("id uuid: %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// parseSince parses the time as RFC3339, date or duration back from now.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q: not a time, date or duration", s)
+}
+
+func parseArgs() (*config, error) {
+	cfg := &config{}
 
 	// is id only for debug?
 	chunked := flag.Bool("ch", false, "chunked output")
-	brigadesList := flag.String("b", "", "Brigaders list by commas")
+	brigadesList := flag.String("b", "", "Brigaders list by commas. Default: all the brigades of the -l file or the stats base dir")
+	brigadesFile := flag.String("l", "", "Brigades list file, i.e. /var/lib/vgkeydesk/brigades.lst, the first field of a line is the brigade id")
 	statsBaseDir := flag.String("s", storage.DefaultStatsDir, "Dir base for dirs brigades statistics.")
+	format := flag.String("f", formatJSON, "Output format: "+strings.Join(formats, ", "))
+	columns := flag.String("columns", strings.Join(defaultColumns, ","), "CSV columns by commas: "+strings.Join(csvColumnNames(), ", "))
+	activeSince := flag.String("active-since", "", "Only the brigades active since the time (RFC3339), the date (YYYY-MM-DD) or the duration ago (i.e. 720h)")
+	vip := flag.Bool("vip", false, "Only the VIP brigades")
 
 	flag.Parse()
 
 	statsdir, err := filepath.Abs(*statsBaseDir)
 	if err != nil {
-		return false, "", nil, fmt.Errorf("statsdir dir: %w", err)
+		return nil, fmt.Errorf("statsdir dir: %w", err)
 	}
 
-	if *brigadesList == "" {
-		return false, "", nil, fmt.Errorf("brigades list: %w", ErrEmptyBrigadeList)
+	cfg.chunked = *chunked
+	cfg.statsBaseDir = statsdir
+	cfg.filter.VIPOnly = *vip
+
+	cfg.filter.ActiveSince, err = parseSince(*activeSince, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("active since: %w", err)
 	}
 
-	brigades := strings.Split(*brigadesList, ",")
-	for _, id := range brigades {
-		if id == "" {
-			return false, "", nil, fmt.Errorf("brigade id: %w", ErrEmptyBrigadeName)
-		}
+	cfg.format = *format
+	if !isFormat(cfg.format) {
+		return nil, fmt.Errorf("format: %s: %w", cfg.format, ErrUnknownFormat)
+	}
+
+	cfg.columns = strings.Split(*columns, ",")
+	if err := checkColumns(cfg.columns); err != nil {
+		return nil, fmt.Errorf("columns: %w", err)
+	}
 
-		// brigadeID must be base32 decodable.
-		binID, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(id)
+	switch {
+	case *brigadesList != "":
+		cfg.brigades = strings.Split(*brigadesList, ",")
+	case *brigadesFile != "":
+		cfg.brigades, err = readBrigadesList(*brigadesFile)
 		if err != nil {
-			return false, "", nil, fmt.Errorf("id base32: %s: %w", id, err)
+			return nil, fmt.Errorf("brigades list file: %w", err)
 		}
 
-		_, err = uuid.FromBytes(binID)
+		if len(cfg.brigades) == 0 {
+			return nil, fmt.Errorf("brigades list file: %w", ErrEmptyBrigadeList)
+		}
+	default:
+		cfg.brigades, err = discoverBrigades(statsdir)
 		if err != nil {
-			return false, "", nil, fmt.Errorf("id uuid: %s: %w", id, err)
+			return nil, fmt.Errorf("discover brigades: %w", err)
+		}
+	}
+
+	for _, id := range cfg.brigades {
+		if err := checkBrigadeID(id); err != nil {
+			return nil, err
 		}
 	}
 
-	return *chunked, statsdir, brigades, nil
+	return cfg, nil
 }
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// Commands.
+const (
+	cmdList    = "list"
+	cmdShow    = "show"
+	cmdRebuild = "rebuild"
+)
+
+// ErrInvalidArgs - invalid arguments.
+var ErrInvalidArgs = errors.New("invalid arguments")
+
+func main() {
+	cmd, seq, journalFilename, err := parseArgs()
+	if err != nil {
+		log.Fatalf("Can't init: %s\n", err)
+	}
+
+	entries, err := storage.ReadJournal(journalFilename)
+	if err != nil {
+		log.Fatalf("Can't read journal: %s\n", err)
+	}
+
+	switch cmd {
+	case cmdList:
+		for _, entry := range entries {
+			fmt.Printf("%d\t%s\t%s\t%d\n", entry.Seq, entry.Time.Format("2006-01-02T15:04:05Z"), entry.Op, len(entry.Diff))
+		}
+	case cmdShow:
+		for _, entry := range entries {
+			if entry.Seq != seq {
+				continue
+			}
+
+			if err := printJSON(&entry); err != nil {
+				log.Fatalf("Can't show: %s\n", err)
+			}
+
+			return
+		}
+
+		log.Fatalf("Can't show: %d: %s\n", seq, storage.ErrJournalPosition)
+	case cmdRebuild:
+		data, err := storage.RebuildJournal(entries, seq)
+		if err != nil {
+			log.Fatalf("Can't rebuild: %s\n", err)
+		}
+
+		if err := printJSON(data); err != nil {
+			log.Fatalf("Can't rebuild: %s\n", err)
+		}
+	}
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent(" ", " ")
+
+	return enc.Encode(v)
+}
+
+func parseArgs() (string, uint64, string, error) {
+	var (
+		id    string
+		dbdir string
+		seq   uint64
+		err   error
+	)
+
+	sysUser, err := user.Current()
+	if err != nil {
+		return "", 0, "", fmt.Errorf("cannot define user: %w", err)
+	}
+
+	brigadeID := flag.String("id", "", "BrigadeID (for test)")
+	filedbDir := flag.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+	journal := flag.String("j", "", "Journal file, i.e. the rotated one. Default: <dir>/"+storage.BrigadeJournalFilename)
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] %s | %s <seq> | %s <seq>\n", filepath.Base(os.Args[0]), cmdList, cmdShow, cmdRebuild)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	cmd := flag.Arg(0)
+	switch cmd {
+	case cmdList:
+		if flag.NArg() != 1 {
+			return "", 0, "", fmt.Errorf("%s: %w", cmd, ErrInvalidArgs)
+		}
+	case cmdShow, cmdRebuild:
+		if flag.NArg() != 2 {
+			return "", 0, "", fmt.Errorf("%s: %w", cmd, ErrInvalidArgs)
+		}
+
+		seq, err = strconv.ParseUint(flag.Arg(1), 10, 64)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("seq: %w", err)
+		}
+	default:
+		return "", 0, "", fmt.Errorf("command %q: %w", cmd, ErrInvalidArgs)
+	}
+
+	if *journal != "" {
+		filename, err := filepath.Abs(*journal)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("journal: %w", err)
+		}
+
+		return cmd, seq, filename, nil
+	}
+
+	if *filedbDir != "" {
+		dbdir, err = filepath.Abs(*filedbDir)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("dbdir dir: %w", err)
+		}
+	}
+
+	switch *brigadeID {
+	case "", sysUser.Username:
+		id = sysUser.Username
+
+		if *filedbDir == "" {
+			dbdir = filepath.Join(storage.DefaultHomeDir, id)
+		}
+	default:
+		id = *brigadeID
+
+		cwd, err := os.Getwd()
+		if err == nil {
+			cwd, _ = filepath.Abs(cwd)
+		}
+
+		if *filedbDir == "" {
+			dbdir = cwd
+		}
+	}
+
+	return cmd, seq, filepath.Join(dbdir, storage.BrigadeJournalFilename), nil
+}
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/vpngen/keydesk/keydesk"
+	"github.com/vpngen/keydesk/keydesk/storage"
+	jwtsvc "github.com/vpngen/keydesk/pkg/jwt"
+	"github.com/vpngen/keydesk/vpnapi"
+	"github.com/vpngen/vpngine/naclkey"
+)
+
+// Bundle subcommands names.
+const (
+	exportCommand = "export"
+	importCommand = "import"
+)
+
+// bundleStorage - the brigade storage for the bundle subcommands.
+func bundleStorage(brigadeID, filedbDir string, addr netip.AddrPort) (*storage.BrigadeStorage, error) {
+	id, dbDir, err := fsckPaths(brigadeID, filedbDir)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(os.Stderr, "Brigade: %s\n", id)
+	fmt.Fprintf(os.Stderr, "DBDir: %s\n", dbDir)
+
+	return &storage.BrigadeStorage{
+		BrigadeID:       id,
+		BrigadeFilename: filepath.Join(dbDir, storage.BrigadeFilename),
+		BrigadeSpinlock: filepath.Join(dbDir, storage.BrigadeSpinlockFilename),
+		APIAddrPort:     addr,
+		BrigadeStorageOpts: storage.BrigadeStorageOpts{
+			MaxUsers:               keydesk.MaxUsers,
+			MonthlyQuotaRemaining:  keydesk.MonthlyQuotaRemaining,
+			MaxUserInctivityPeriod: keydesk.DefaultMaxUserInactivityPeriod,
+		},
+		Migration: storage.MigrationEnv{
+			Proto0FakeDomains: keydesk.GetRandomSites0,
+		},
+	}, nil
+}
+
+func bundleKeyFilename(keyFilename, etcDir string) string {
+	if keyFilename != "" {
+		return keyFilename
+	}
+
+	if etcDir == "" {
+		etcDir = keydesk.DefaultEtcDir
+	}
+
+	return filepath.Join(etcDir, etcSubdir, keydeskJwtPrivkeyFileName)
+}
+
+// export - write the signed brigade bundle.
+func export(args []string) {
+	flagSet := flag.NewFlagSet(exportCommand, flag.ExitOnError)
+
+	keyFilename := flagSet.String("k", "", fmt.Sprintf("Path to the signing SSH private key. Default: %s/%s/%s", keydesk.DefaultEtcDir, etcSubdir, keydeskJwtPrivkeyFileName))
+	output := flagSet.String("o", "", "Output file. Default: stdout")
+	etcDir := flagSet.String("c", "", "Dir for config files (for test). Default: "+keydesk.DefaultEtcDir)
+	brigadeID := flagSet.String("id", "", "BrigadeID (for test)")
+	filedbDir := flagSet.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	// ignore errors, see original flag.Parse() func
+	_ = flagSet.Parse(args)
+
+	method, key, _, keyID, err := jwtsvc.ReadPrivateSSHKey(bundleKeyFilename(*keyFilename, *etcDir))
+	if err != nil {
+		errQuit("Can't read signing key", err)
+	}
+
+	db, err := bundleStorage(*brigadeID, *filedbDir, netip.AddrPort{})
+	if err != nil {
+		errQuit("Can't init", err)
+	}
+
+	if err := db.SelfCheck(); err != nil {
+		errQuit("Storage initialization", err)
+	}
+
+	data, err := db.ExportBrigade()
+	if err != nil {
+		errQuit("Export", err)
+	}
+
+	bundle, err := keydesk.NewBundle(data, method, key, keyID)
+	if err != nil {
+		errQuit("Bundle", err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.OpenFile(*output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, storage.FileDbMode)
+		if err != nil {
+			errQuit("Can't create output", err)
+		}
+
+		defer f.Close()
+
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent(" ", " ")
+
+	if err := enc.Encode(bundle); err != nil {
+		errQuit("Write", err)
+	}
+}
+
+// readBundleVerifyKey - the public key from the private or public SSH key file.
+func readBundleVerifyKey(filename string) (gojwt.SigningMethod, crypto.PublicKey, error) {
+	method, _, pub, _, err := jwtsvc.ReadPrivateSSHKey(filename)
+	if err == nil {
+		return method, pub, nil
+	}
+
+	method, pub, perr := jwtsvc.ReadPublicSSHKey(filename)
+	if perr != nil {
+		return nil, nil, fmt.Errorf("%w: %w", err, perr)
+	}
+
+	return method, pub, nil
+}
+
+// readOldRouterKey - the old node router keypair from the file or stdin.
+func readOldRouterKey(filename string) (*naclkey.NaclBoxKeypair, error) {
+	if filename != "" {
+		keys, err := naclkey.ReadKeypairFile(filename)
+		if err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+
+		return &keys, nil
+	}
+
+	if stat, _ := os.Stdin.Stat(); (stat.Mode() & os.ModeCharDevice) != 0 {
+		return nil, nil
+	}
+
+	blob, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	if len(blob) == 0 {
+		return nil, nil
+	}
+
+	keys, err := naclkey.UnmarshalKeypair(blob)
+	if err != nil {
+		return nil, fmt.Errorf("parse key: %w", err)
+	}
+
+	return &keys, nil
+}
+
+// bundleImport - verify the bundle, prepare it for this node,
+// store and replay the brigade to the endpoint.
+func bundleImport(args []string) {
+	flagSet := flag.NewFlagSet(importCommand, flag.ExitOnError)
+
+	keyFilename := flagSet.String("k", "", fmt.Sprintf("Path to the SSH private or public key to verify the bundle. Default: %s/%s/%s", keydesk.DefaultEtcDir, etcSubdir, keydeskJwtPrivkeyFileName))
+	routerKeyFilename := flagSet.String("r", "", "Old router NaCl keypair file to reseal the secrets. Default: stdin")
+	ep4 := flagSet.String("ep4", "", "New endpoint IPv4 address")
+	kd6 := flagSet.String("kd6", "", "New keydesk IPv6 address")
+	etcDir := flagSet.String("c", "", "Dir for config files (for test). Default: "+keydesk.DefaultEtcDir)
+	addr := flagSet.String("a", vpnapi.TemplatedAddrPort, "API endpoint address:port")
+	brigadeID := flagSet.String("id", "", "BrigadeID (for test)")
+	filedbDir := flagSet.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	// ignore errors, see original flag.Parse() func
+	_ = flagSet.Parse(args)
+
+	if flagSet.NArg() != 1 {
+		errQuit("Can't init", fmt.Errorf("bundle file: %w", ErrInvalidArgs))
+	}
+
+	var (
+		endpointIPv4, keydeskIPv6 netip.Addr
+		apiAddr                   netip.AddrPort
+		err                       error
+	)
+
+	if *ep4 != "" {
+		if endpointIPv4, err = netip.ParseAddr(*ep4); err != nil || !endpointIPv4.Is4() {
+			errQuit("Can't init", fmt.Errorf("endpoint ipv4: %w", ErrInvalidArgs))
+		}
+	}
+
+	if *kd6 != "" {
+		if keydeskIPv6, err = netip.ParseAddr(*kd6); err != nil || !keydeskIPv6.Is6() {
+			errQuit("Can't init", fmt.Errorf("keydesk ipv6: %w", ErrInvalidArgs))
+		}
+	}
+
+	if *addr != "-" {
+		if apiAddr, err = netip.ParseAddrPort(*addr); err != nil {
+			errQuit("Can't init", fmt.Errorf("api addr: %w", err))
+		}
+	}
+
+	dir := *etcDir
+	if dir == "" {
+		dir = keydesk.DefaultEtcDir
+	}
+
+	method, pub, err := readBundleVerifyKey(bundleKeyFilename(*keyFilename, *etcDir))
+	if err != nil {
+		errQuit("Can't read verify key", err)
+	}
+
+	oldRouterKeys, err := readOldRouterKey(*routerKeyFilename)
+	if err != nil {
+		errQuit("Can't read old router key", err)
+	}
+
+	buf, err := os.ReadFile(flagSet.Arg(0))
+	if err != nil {
+		errQuit("Can't read bundle", err)
+	}
+
+	bundle := &keydesk.Bundle{}
+	if err := json.Unmarshal(buf, bundle); err != nil {
+		errQuit("Can't decode bundle", err)
+	}
+
+	data, err := bundle.Open(method, pub)
+	if err != nil {
+		errQuit("Bundle", err)
+	}
+
+	switch oldRouterKeys {
+	case nil:
+		fmt.Fprintln(os.Stderr, "WARNING: old router key is not set, secrets are kept as is")
+	default:
+		routerPublicKey, shufflerPublicKey, err := readPubKeys(dir)
+		if err != nil {
+			errQuit("Can't read keys", err)
+		}
+
+		if err := keydesk.ResealBrigade(data, &oldRouterKeys.Private, &oldRouterKeys.Public, &routerPublicKey, &shufflerPublicKey); err != nil {
+			errQuit("Reseal", err)
+		}
+	}
+
+	keydesk.RelocateBrigade(data, endpointIPv4, keydeskIPv6)
+
+	db, err := bundleStorage(*brigadeID, *filedbDir, apiAddr)
+	if err != nil {
+		errQuit("Can't init", err)
+	}
+
+	if err := db.ImportBrigade(data); err != nil {
+		errQuit("Import", err)
+	}
+
+	if err := db.SelfCheckAndInit(); err != nil {
+		errQuit("Storage initialization", err)
+	}
+
+	if err := db.ReplayBrigade(context.Background(), true, false, false, true, false); err != nil {
+		errQuit("Replay", err)
+	}
+}
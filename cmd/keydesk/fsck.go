@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/vpngen/keydesk/keydesk"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// fsckCommand - subcommand name.
+const fsckCommand = "fsck"
+
+// fsckExitProblems - exit code if unfixed problems remain.
+const fsckExitProblems = 2
+
+// fsck - check the brigade and print the JSON report.
+func fsck(args []string) {
+	flagSet := flag.NewFlagSet(fsckCommand, flag.ExitOnError)
+
+	fix := flagSet.Bool("fix", false, "Do the safe repairs")
+	ttl := flagSet.Duration("ttl", keydesk.DefaultEndpointsTTL, "Endpoints TTL")
+	brigadeID := flagSet.String("id", "", "BrigadeID (for test)")
+	filedbDir := flagSet.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	// ignore errors, see original flag.Parse() func
+	_ = flagSet.Parse(args)
+
+	id, dbDir, err := fsckPaths(*brigadeID, *filedbDir)
+	if err != nil {
+		errQuit("Can't init", err)
+	}
+
+	db := &storage.BrigadeStorage{
+		BrigadeID:       id,
+		BrigadeFilename: filepath.Join(dbDir, storage.BrigadeFilename),
+		BrigadeSpinlock: filepath.Join(dbDir, storage.BrigadeSpinlockFilename),
+		BrigadeStorageOpts: storage.BrigadeStorageOpts{
+			MaxUsers:               keydesk.MaxUsers,
+			MonthlyQuotaRemaining:  keydesk.MonthlyQuotaRemaining,
+			MaxUserInctivityPeriod: keydesk.DefaultMaxUserInactivityPeriod,
+		},
+	}
+
+	if err := db.SelfCheck(); err != nil {
+		errQuit("Storage initialization", err)
+	}
+
+	report, err := db.Fsck(*fix, *ttl)
+	if err != nil {
+		errQuit("Fsck", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent(" ", " ")
+
+	if err := enc.Encode(report); err != nil {
+		errQuit("Report", err)
+	}
+
+	if len(report.Problems) > report.Fixed {
+		os.Exit(fsckExitProblems)
+	}
+}
+
+func fsckPaths(brigadeID, filedbDir string) (string, string, error) {
+	var (
+		id, dbdir string
+		err       error
+	)
+
+	sysUser, err := user.Current()
+	if err != nil {
+		return "", "", fmt.Errorf("cannot define user: %w", err)
+	}
+
+	if filedbDir != "" {
+		dbdir, err = filepath.Abs(filedbDir)
+		if err != nil {
+			return "", "", fmt.Errorf("dbdir dir: %w", err)
+		}
+	}
+
+	switch brigadeID {
+	case "", sysUser.Username:
+		id = sysUser.Username
+
+		if filedbDir == "" {
+			dbdir = filepath.Join(storage.DefaultHomeDir, id)
+		}
+	default:
+		id = brigadeID
+
+		cwd, err := os.Getwd()
+		if err == nil {
+			cwd, _ = filepath.Abs(cwd)
+		}
+
+		if filedbDir == "" {
+			dbdir = cwd
+		}
+	}
+
+	return id, dbdir, nil
+}
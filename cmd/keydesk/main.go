@@ -25,6 +25,8 @@ import (
 	"github.com/vpngen/keydesk/internal/maintenance"
 	msgapp "github.com/vpngen/keydesk/internal/messages/app"
 	msgsvc "github.com/vpngen/keydesk/internal/messages/service"
+	"github.com/vpngen/keydesk/internal/metrics"
+	"github.com/vpngen/keydesk/internal/outbox"
 	"github.com/vpngen/keydesk/internal/server"
 	shflrapp "github.com/vpngen/keydesk/internal/shuffler/app"
 	"github.com/vpngen/keydesk/internal/stat"
@@ -57,6 +59,7 @@ var (
 	ErrInvalidPersonURL     = stderrors.New("invalid person url")
 	ErrStaticDirEmpty       = stderrors.New("empty static dirname")
 	ErrNotAlowedInThisMode  = stderrors.New("not allowed in this mode")
+	ErrInvalidArgs          = stderrors.New("invalid arguments")
 )
 
 func errQuit(msg string, err error) {
@@ -65,6 +68,30 @@ func errQuit(msg string, err error) {
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == fsckCommand {
+		fsck(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == exportCommand {
+		export(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == importCommand {
+		bundleImport(os.Args[2:])
+
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == secretsCommand {
+		secrets(os.Args[2:])
+
+		return
+	}
+
 	cfg, err := parseArgs2(parseFlags(flag.CommandLine, os.Args[1:]))
 	if err != nil {
 		errQuit("Can't init", err)
@@ -87,6 +114,14 @@ func main() {
 			MaxUsers:               keydesk.MaxUsers,
 			MonthlyQuotaRemaining:  keydesk.MonthlyQuotaRemaining,
 			MaxUserInctivityPeriod: keydesk.DefaultMaxUserInactivityPeriod,
+			Snapshots:              cfg.snapshots,
+			Throttle:               cfg.throttle,
+			Geo:                    cfg.geo,
+			Alerts:                 cfg.alerts,
+			EndpointTimeouts:       cfg.endpointTimeouts,
+		},
+		Migration: storage.MigrationEnv{
+			Proto0FakeDomains: keydesk.GetRandomSites0,
 		},
 	}
 	if err := db.SelfCheckAndInit(); err != nil {
@@ -110,14 +145,6 @@ func main() {
 	ip := brigade.EndpointIPv4.String()
 	cfg.jwtKeydeskIssuer.SetExternalIP(ip)
 
-	if brigade.Ver < 11 && brigade.Proto0Port != 0 && len(brigade.Proto0FakeDomains) == 0 {
-		brigade.Proto0FakeDomains = keydesk.GetRandomSites0()
-
-		if err := raw.Commit(brigade); err != nil {
-			errQuit("commit db", err)
-		}
-	}
-
 	if err = raw.Close(); err != nil {
 		errQuit("close db", err)
 	}
@@ -176,6 +203,11 @@ func main() {
 		cfg.listeners = append(cfg.listeners, l)
 	}
 
+	_, rdata := os.LookupEnv("VGSTATS_RANDOM_DATA")
+
+	statMetrics := &stat.Metrics{}
+	collector := stat.NewCollector(db, rdata, cfg.statsDir, cfg.statsSchedule, statMetrics)
+
 	handler := initSwaggerAPI(
 		db,
 		&routerPublicKey,
@@ -183,6 +215,7 @@ func main() {
 		cfg.enableCORS,
 		cfg.webDir,
 		allowedAddress,
+		collector,
 		cfg.jwtKeydeskIssuer,
 		cfg.jwtKeydesAuthorizer,
 	)
@@ -253,11 +286,9 @@ func main() {
 		})
 	}
 
-	_, rdata := os.LookupEnv("VGSTATS_RANDOM_DATA")
-
 	r.AddTask("stat", runner.Task{
 		Func: func(ctx context.Context) error {
-			stat.CollectingData(db, statDone, rdata, cfg.statsDir)
+			collector.Run(statDone)
 			return nil
 		},
 		Shutdown: func(ctx context.Context) error {
@@ -266,6 +297,20 @@ func main() {
 		},
 	})
 
+	outboxDone := make(chan struct{})
+	outboxWorker := outbox.NewWorker(db, outbox.DefaultInterval)
+
+	r.AddTask("outbox", runner.Task{
+		Func: func(ctx context.Context) error {
+			outboxWorker.Run(outboxDone)
+			return nil
+		},
+		Shutdown: func(ctx context.Context) error {
+			close(outboxDone)
+			return nil
+		},
+	})
+
 	fmt.Fprintf(os.Stderr, "Brigade mode: %s \n", brigade.Mode)
 
 	if brigade.Mode == storage.ModeBrigade &&
@@ -295,7 +340,7 @@ func main() {
 
 	// start socket interface for any mode to stats access
 	if cfg.shufflerAPISocket != nil {
-		echoSrv, err := shflrapp.SetupServer(db, cfg.jwtMsgAuthorizer, routerPublicKey, shufflerPublicKey)
+		echoSrv, err := shflrapp.SetupServer(db, collector, cfg.jwtMsgAuthorizer, routerPublicKey, shufflerPublicKey)
 		if err != nil {
 			errQuit("shuffler server", err)
 		}
@@ -316,6 +361,27 @@ func main() {
 		})
 	}
 
+	if cfg.metricsSocket != nil {
+		metricsSrv, err := metrics.NewServer(db, statMetrics)
+		if err != nil {
+			errQuit("metrics server", err)
+		}
+
+		r.AddTask("metrics", runner.Task{
+			Func: func(ctx context.Context) error {
+				fmt.Fprintf(os.Stderr, "Listen metrics: %s\n", cfg.metricsSocket.Addr().String())
+				if err := metricsSrv.Serve(cfg.metricsSocket); err != nil && !stderrors.Is(err, http.ErrServerClosed) {
+					return err
+				}
+
+				return nil
+			},
+			Shutdown: func(ctx context.Context) error {
+				return metricsSrv.Shutdown(ctx)
+			},
+		})
+	}
+
 	r.Run()
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
@@ -361,7 +427,7 @@ func createBrigadier(db *storage.BrigadeStorage,
 	}
 
 	// TODO: do we have to print wgconf, filename?
-	_, _, confJson, creationErr := keydesk.AddBrigadier(db, name, person, replace, vpnCfgs, routerPublicKey, shufflerPublicKey)
+	_, _, confJson, creationErr := keydesk.AddBrigadier(context.Background(), db, name, person, replace, vpnCfgs, routerPublicKey, shufflerPublicKey)
 
 	enc := json.NewEncoder(w)
 
@@ -415,12 +481,14 @@ func initSwaggerAPI(
 	pcors bool,
 	webDir string,
 	allowedAddr string,
+	stats keydesk.StatsRefresher,
 	issuer jwtsvc.KeydeskTokenIssuer,
 	authorizer jwtsvc.KeydeskTokenAuthorizer,
 ) http.Handler {
 	api := server.NewServer(
 		db,
 		msgsvc.New(db),
+		stats,
 		issuer,
 		goSwaggerAuth.NewService(authorizer),
 		routerPublicKey,
@@ -436,7 +504,19 @@ func initSwaggerAPI(
 	)(handler)
 	handler = uiMiddlewareBuilder(webDir, allowedAddr)(handler)
 	if pcors {
-		return cors.AllowAll().Handler(handler)
+		return cors.New(cors.Options{
+			AllowedOrigins: []string{"*"},
+			AllowedMethods: []string{
+				http.MethodHead,
+				http.MethodGet,
+				http.MethodPost,
+				http.MethodPut,
+				http.MethodPatch,
+				http.MethodDelete,
+			},
+			AllowedHeaders: []string{"*"},
+			ExposedHeaders: []string{"ETag"}, // the brigade revision for If-Match
+		}).Handler(handler)
 	}
 	return handler
 }
@@ -12,10 +12,13 @@ import (
 	"os/user"
 	"path/filepath"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/internal/stat"
+	"github.com/vpngen/keydesk/kdlib"
 	"github.com/vpngen/keydesk/keydesk"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	jwtsvc "github.com/vpngen/keydesk/pkg/jwt"
@@ -55,19 +58,47 @@ type flags struct {
 	unixSocketDir             *string
 	messageAPI                *string
 	shufflerAPI               *string
+	metricsAPI                *string
 	msgJwtPubkeyFilename      *string
 	keydeskJwtPrivkeyFilename *string
+
+	snapshotsLast     *int
+	snapshotsDaily    *int
+	snapshotsInterval *time.Duration
+
+	throttleQuota *bool
+	dailyBurstGB  *uint64
+
+	endpointWgTimeout   *time.Duration
+	endpointPeerTimeout *time.Duration
+	endpointStatTimeout *time.Duration
+
+	statsInterval *time.Duration
+	statsJitter   *time.Duration
+	geoDB         *string
+
+	alertSpike      *float64
+	alertSpikeMinGB *uint64
+	alertThrottling *bool
+	alertCooldown   *time.Duration
 }
 
 const (
 	defaultUnixSocketDir      = "/var/lib/dcapi"
 	defaultMessageSocket      = "messages.sock"
 	defaultShufflerSocket     = "shuffler.sock"
+	defaultMetricsSocket      = "metrics.sock"
 	jwtPubKeyFileName         = "jwt-pub-msg.pem"
 	msgJwtPubkeyFilename      = "msg-jwt.pub"
 	keydeskJwtPrivkeyFileName = "keydesk-jwt.key"
 	etcSubdir                 = "vg-keydesk"
 	defaultVipEndpoint        = "vip.vpn.works"
+	defaultSnapshotsLast      = 0 // snapshots are opt-in
+	defaultSnapshotsDaily     = 0
+	defaultSnapshotsInterval  = time.Hour
+	defaultAlertSpike         = 5
+	defaultAlertSpikeMinGB    = 1
+	defaultAlertCooldown      = 24 * time.Hour
 )
 
 func parseFlags(flagSet *flag.FlagSet, args []string) flags {
@@ -103,9 +134,29 @@ func parseFlags(flagSet *flag.FlagSet, args []string) flags {
 	f.unixSocketDir = flagSet.String("socket-dir", defaultUnixSocketDir, fmt.Sprintf("Unix sockets dir. Default: %s", defaultUnixSocketDir))
 	f.messageAPI = flagSet.String("m", "", fmt.Sprintf("Message API unix socket path. Default: %s/<BrigadeID>/messages.sock '-' to disable", *f.unixSocketDir))
 	f.shufflerAPI = flagSet.String("shuffler", "", fmt.Sprintf("Shuffler API unix socket path. Default: %s/<BrigadeID>/shuffler.sock '-' to disable", *f.unixSocketDir))
+	f.metricsAPI = flagSet.String("metrics", "-", fmt.Sprintf("Metrics unix socket path, '' for %s/<BrigadeID>/metrics.sock. Default: '-' disabled", *f.unixSocketDir))
 	f.msgJwtPubkeyFilename = flagSet.String("msgjwt", "", fmt.Sprintf("Path to Messages JWT public key file. Default: %s/%s", keydesk.DefaultEtcDir, jwtPubKeyFileName))
 	f.keydeskJwtPrivkeyFilename = flagSet.String("kdjwt", "", fmt.Sprintf("Path to Keydesk JWT private key file. Default: %s/%s", keydesk.DefaultEtcDir, keydeskJwtPrivkeyFileName))
 
+	f.snapshotsLast = flagSet.Int("snapshots-last", defaultSnapshotsLast, "Keep N last brigade snapshots, 0 and -snapshots-daily 0 disable them (default)")
+	f.snapshotsDaily = flagSet.Int("snapshots-daily", defaultSnapshotsDaily, "Keep daily brigade snapshots for D days")
+	f.snapshotsInterval = flagSet.Duration("snapshots-interval", defaultSnapshotsInterval, "Minimal interval between brigade snapshots")
+
+	f.throttleQuota = flagSet.Bool("throttle-quota", false, "Throttle a VPN-user till the quota reset when the monthly quota is used up")
+	f.dailyBurstGB = flagSet.Uint64("daily-burst", 0, "Throttle a VPN-user till the day end after N GB (in+out) a day, 0 to disable")
+
+	f.endpointWgTimeout = flagSet.Duration("endpoint-wg-timeout", vpnapi.CallTimeout, "Timeout of the endpoint wg_add and wg_del calls")
+	f.endpointPeerTimeout = flagSet.Duration("endpoint-peer-timeout", vpnapi.CallTimeout, "Timeout of the endpoint peer calls: peer_add, peer_del, throttle_on/off, peer_list")
+	f.endpointStatTimeout = flagSet.Duration("endpoint-stat-timeout", vpnapi.CallTimeout, "Timeout of the endpoint stat call")
+
+	f.statsInterval = flagSet.Duration("stats-interval", stat.DefaultSchedule.Interval, "Interval between the endpoint stats collections")
+	f.statsJitter = flagSet.Duration("stats-jitter", stat.DefaultSchedule.Jitter, "Max random delay of the first stats collection, 0 to disable")
+	f.alertSpike = flagSet.Float64("alert-spike", defaultAlertSpike, "Alert the brigadier on a VPN-user day traffic over N times the week average, 0 to disable")
+	f.alertSpikeMinGB = flagSet.Uint64("alert-spike-min", defaultAlertSpikeMinGB, "Don't alert on the day traffic spikes under N GB (in+out)")
+	f.alertThrottling = flagSet.Bool("alert-throttling", true, "Alert the brigadier on the VPN-user quota exhausting and throttling")
+	f.alertCooldown = flagSet.Duration("alert-cooldown", defaultAlertCooldown, "Minimal interval between the alerts of a kind per VPN-user")
+	f.geoDB = flagSet.String("geodb", "", "MaxMind format database file for the users countries and ASNs summary, empty to disable")
+
 	// ignore errors, see original flag.Parse() func
 	_ = flagSet.Parse(args)
 
@@ -132,9 +183,16 @@ type config struct {
 	unixSocketDir       string
 	messageAPISocket    net.Listener
 	shufflerAPISocket   net.Listener
+	metricsSocket       net.Listener
 	jwtKeydeskIssuer    jwtsvc.KeydeskTokenIssuer
 	jwtKeydesAuthorizer jwtsvc.KeydeskTokenAuthorizer
 	jwtMsgAuthorizer    jwtsvc.MessagesJwtAuthorizer
+	snapshots           kdlib.SnapshotRetention
+	throttle            storage.ThrottlePolicy
+	endpointTimeouts    vpnapi.Timeouts
+	statsSchedule       stat.Schedule
+	geo                 storage.GeoLocator
+	alerts              storage.AlertPolicy
 }
 
 func parseArgs2(flags flags) (config, error) {
@@ -143,6 +201,42 @@ func parseArgs2(flags flags) (config, error) {
 		jsonOut:       *flags.jsonOut,
 		enableCORS:    *flags.pcors,
 		unixSocketDir: *flags.unixSocketDir,
+		snapshots: kdlib.SnapshotRetention{
+			Last:     *flags.snapshotsLast,
+			Daily:    *flags.snapshotsDaily,
+			Interval: *flags.snapshotsInterval,
+		},
+		throttle: storage.ThrottlePolicy{
+			Quota:      *flags.throttleQuota,
+			DailyBurst: *flags.dailyBurstGB * 1024 * 1024 * 1024,
+		},
+		endpointTimeouts: vpnapi.Timeouts{
+			WgAdd:    *flags.endpointWgTimeout,
+			WgDel:    *flags.endpointWgTimeout,
+			PeerAdd:  *flags.endpointPeerTimeout,
+			PeerDel:  *flags.endpointPeerTimeout,
+			Throttle: *flags.endpointPeerTimeout,
+			PeerList: *flags.endpointPeerTimeout,
+			Stat:     *flags.endpointStatTimeout,
+		},
+		statsSchedule: stat.Schedule{
+			Interval: *flags.statsInterval,
+			Jitter:   *flags.statsJitter,
+		},
+		alerts: storage.AlertPolicy{
+			SpikeFactor: *flags.alertSpike,
+			SpikeMin:    *flags.alertSpikeMinGB * 1024 * 1024 * 1024,
+			Throttling:  *flags.alertThrottling,
+			Cooldown:    *flags.alertCooldown,
+		},
+	}
+
+	if cfg.statsSchedule.Interval <= 0 || cfg.statsSchedule.Jitter < 0 {
+		return cfg, fmt.Errorf("stats schedule: %w", ErrInvalidArgs)
+	}
+
+	if cfg.alerts.SpikeFactor < 0 || cfg.alerts.Cooldown < 0 {
+		return cfg, fmt.Errorf("alerts: %w", ErrInvalidArgs)
 	}
 
 	sysUser, err := user.Current()
@@ -303,6 +397,15 @@ func parseArgs2(flags flags) (config, error) {
 			return cfg, fmt.Errorf("jwt vip private key file %s set but does not exist", vipPrivkeyFn)
 		}
 
+		if *flags.geoDB != "" {
+			geo, err := stat.OpenGeoDB(*flags.geoDB)
+			if err != nil {
+				return cfg, fmt.Errorf("geo db: %w", err)
+			}
+
+			cfg.geo = geo
+		}
+
 		listener, err := createUnixSocketListener(*flags.messageAPI, cfg.brigadeID, cfg.unixSocketDir, defaultMessageSocket)
 		if err != nil {
 			return cfg, fmt.Errorf("create messages listener: %w", err)
@@ -315,6 +418,12 @@ func parseArgs2(flags flags) (config, error) {
 		}
 		cfg.shufflerAPISocket = listener
 
+		listener, err = createUnixSocketListener(*flags.metricsAPI, cfg.brigadeID, cfg.unixSocketDir, defaultMetricsSocket)
+		if err != nil {
+			return cfg, fmt.Errorf("create metrics listener: %w", err)
+		}
+		cfg.metricsSocket = listener
+
 		// get listeners from argument
 		for _, laddr := range strings.Split(*flags.listenAddr, ",") {
 			if laddr == "" {
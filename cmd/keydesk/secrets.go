@@ -0,0 +1,83 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/vpngen/keydesk/keydesk"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// secretsCommand - subcommand name.
+const secretsCommand = "secrets"
+
+// Secrets migrations.
+const (
+	secretsSeal   = "seal"
+	secretsUnseal = "unseal"
+)
+
+// secrets - seal the plaintext brigade secrets with the local key or unseal them back.
+func secrets(args []string) {
+	flagSet := flag.NewFlagSet(secretsCommand, flag.ExitOnError)
+
+	keyFilename := flagSet.String("k", filepath.Join(storage.DefaultEtcDir, storage.SealKeyFilename), "Seal key file")
+	genKey := flagSet.Bool("g", false, "Generate the seal key if it doesn't exist")
+	brigadeID := flagSet.String("id", "", "BrigadeID (for test)")
+	filedbDir := flagSet.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	flagSet.Usage = func() {
+		fmt.Fprintf(flagSet.Output(), "Usage: %s %s [flags] %s | %s\n", filepath.Base(os.Args[0]), secretsCommand, secretsSeal, secretsUnseal)
+		flagSet.PrintDefaults()
+	}
+
+	// ignore errors, see original flag.Parse() func
+	_ = flagSet.Parse(args)
+
+	cmd := flagSet.Arg(0)
+	if cmd != secretsSeal && cmd != secretsUnseal {
+		flagSet.Usage()
+		errQuit("Can't init", fmt.Errorf("%w: %q", ErrInvalidArgs, cmd))
+	}
+
+	id, dbDir, err := fsckPaths(*brigadeID, *filedbDir)
+	if err != nil {
+		errQuit("Can't init", err)
+	}
+
+	if cmd == secretsSeal && *genKey {
+		if _, err := os.Stat(*keyFilename); errors.Is(err, fs.ErrNotExist) {
+			if _, err := storage.GenSealKeyFile(*keyFilename); err != nil {
+				errQuit("Seal key", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Seal key generated: %s\n", *keyFilename)
+		}
+	}
+
+	db := &storage.BrigadeStorage{
+		BrigadeID:       id,
+		BrigadeFilename: filepath.Join(dbDir, storage.BrigadeFilename),
+		BrigadeSpinlock: filepath.Join(dbDir, storage.BrigadeSpinlockFilename),
+		SealKeyFilename: *keyFilename,
+		BrigadeStorageOpts: storage.BrigadeStorageOpts{
+			MaxUsers:               keydesk.MaxUsers,
+			MonthlyQuotaRemaining:  keydesk.MonthlyQuotaRemaining,
+			MaxUserInctivityPeriod: keydesk.DefaultMaxUserInactivityPeriod,
+		},
+	}
+
+	if err := db.SelfCheck(); err != nil {
+		errQuit("Storage initialization", err)
+	}
+
+	if err := db.SealSecrets(cmd == secretsSeal); err != nil {
+		errQuit("Can't "+cmd, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Brigade %s secrets: %sed\n", id, cmd)
+}
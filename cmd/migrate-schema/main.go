@@ -0,0 +1,109 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/netip"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/vpngen/keydesk/keydesk"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+func main() {
+	dryRun, brigadeID, dbDir, err := parseArgs()
+	if err != nil {
+		log.Fatalf("Can't init: %s\n", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Brigade: %s\n", brigadeID)
+	fmt.Fprintf(os.Stderr, "DBDir: %s\n", dbDir)
+
+	if dryRun {
+		fmt.Fprintln(os.Stderr, "Dry run")
+	}
+
+	db := &storage.BrigadeStorage{
+		BrigadeID:       brigadeID,
+		BrigadeFilename: filepath.Join(dbDir, storage.BrigadeFilename),
+		BrigadeSpinlock: filepath.Join(dbDir, storage.BrigadeSpinlockFilename),
+		APIAddrPort:     netip.AddrPort{},
+		BrigadeStorageOpts: storage.BrigadeStorageOpts{
+			MaxUsers:               keydesk.MaxUsers,
+			MonthlyQuotaRemaining:  keydesk.MonthlyQuotaRemaining,
+			MaxUserInctivityPeriod: keydesk.DefaultMaxUserInactivityPeriod,
+		},
+		Migration: storage.MigrationEnv{
+			Proto0FakeDomains: keydesk.GetRandomSites0,
+		},
+	}
+
+	// SelfCheckAndInit migrates itself, so only check the config here.
+	if err := db.SelfCheck(); err != nil {
+		log.Fatalf("Storage initialization: %s\n", err)
+	}
+
+	results, err := db.Migrate(dryRun)
+	if err != nil {
+		log.Fatalf("Can't migrate: %s\n", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent(" ", " ")
+
+	if err := enc.Encode(results); err != nil {
+		log.Fatalf("Can't print: %s\n", err)
+	}
+}
+
+func parseArgs() (bool, string, string, error) {
+	var (
+		id    string
+		dbdir string
+		err   error
+	)
+
+	sysUser, err := user.Current()
+	if err != nil {
+		return false, "", "", fmt.Errorf("cannot define user: %w", err)
+	}
+
+	dryRun := flag.Bool("n", false, "Dry run, print the planned diffs")
+	brigadeID := flag.String("id", "", "BrigadeID (for test)")
+	filedbDir := flag.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	flag.Parse()
+
+	if *filedbDir != "" {
+		dbdir, err = filepath.Abs(*filedbDir)
+		if err != nil {
+			return false, "", "", fmt.Errorf("dbdir dir: %w", err)
+		}
+	}
+
+	switch *brigadeID {
+	case "", sysUser.Username:
+		id = sysUser.Username
+
+		if *filedbDir == "" {
+			dbdir = filepath.Join(storage.DefaultHomeDir, id)
+		}
+	default:
+		id = *brigadeID
+
+		cwd, err := os.Getwd()
+		if err == nil {
+			cwd, _ = filepath.Abs(cwd)
+		}
+
+		if *filedbDir == "" {
+			dbdir = cwd
+		}
+	}
+
+	return *dryRun, id, dbdir, nil
+}
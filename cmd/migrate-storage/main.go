@@ -0,0 +1,138 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// Storage backends.
+const (
+	backendJSON = "json"
+	backendBolt = "bolt"
+)
+
+// migratedSuffix - suffix for the source file after the migration.
+const migratedSuffix = ".migrated"
+
+// ErrInvalidArgs - invalid arguments.
+var ErrInvalidArgs = errors.New("invalid arguments")
+
+func main() {
+	to, brigadeID, dbDir, err := parseArgs()
+	if err != nil {
+		log.Fatalf("Can't init: %s\n", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Brigade: %s\n", brigadeID)
+	fmt.Fprintf(os.Stderr, "DBDir: %s\n", dbDir)
+	fmt.Fprintf(os.Stderr, "Migrate to: %s\n", to)
+
+	if err := Do(brigadeID, dbDir, to); err != nil {
+		log.Fatalf("Can't do: %s\n", err)
+	}
+
+	fmt.Fprintln(os.Stderr, "Done")
+}
+
+func parseArgs() (string, string, string, error) {
+	var (
+		id    string
+		dbdir string
+		err   error
+	)
+
+	sysUser, err := user.Current()
+	if err != nil {
+		return "", "", "", fmt.Errorf("cannot define user: %w", err)
+	}
+
+	to := flag.String("to", backendBolt, "Destination storage backend ("+backendBolt+"|"+backendJSON+")")
+	brigadeID := flag.String("id", "", "BrigadeID (for test)")
+	filedbDir := flag.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	flag.Parse()
+
+	if *to != backendBolt && *to != backendJSON {
+		return "", "", "", fmt.Errorf("backend %q: %w", *to, ErrInvalidArgs)
+	}
+
+	if *filedbDir != "" {
+		dbdir, err = filepath.Abs(*filedbDir)
+		if err != nil {
+			return "", "", "", fmt.Errorf("dbdir dir: %w", err)
+		}
+	}
+
+	switch *brigadeID {
+	case "", sysUser.Username:
+		id = sysUser.Username
+
+		if *filedbDir == "" {
+			dbdir = filepath.Join(storage.DefaultHomeDir, id)
+		}
+	default:
+		id = *brigadeID
+
+		cwd, err := os.Getwd()
+		if err == nil {
+			cwd, _ = filepath.Abs(cwd)
+		}
+
+		if *filedbDir == "" {
+			dbdir = cwd
+		}
+	}
+
+	return *to, id, dbdir, nil
+}
+
+// Do - copy the brigade to the destination backend
+// and put the source file aside, so the destination is picked up.
+func Do(brigadeID, dbDir, to string) error {
+	jsonFilename := filepath.Join(dbDir, storage.BrigadeFilename)
+	boltFilename := filepath.Join(dbDir, storage.BrigadeBoltFilename)
+	spinlock := filepath.Join(dbDir, storage.BrigadeSpinlockFilename)
+
+	// The destination gets its own spinlock, the source one
+	// guards the brigade during the whole migration.
+	var (
+		from, dst   storage.Backend
+		srcFilename string
+	)
+
+	switch to {
+	case backendBolt:
+		from = &storage.JSONBackend{Filename: jsonFilename, Spinlock: spinlock}
+		dst = &storage.BoltBackend{Filename: boltFilename, Spinlock: spinlock + migratedSuffix}
+		srcFilename = jsonFilename
+	default:
+		from = &storage.BoltBackend{Filename: boltFilename, Spinlock: spinlock}
+		dst = &storage.JSONBackend{Filename: jsonFilename, Spinlock: spinlock + migratedSuffix}
+		srcFilename = boltFilename
+	}
+
+	if _, err := os.Stat(srcFilename); err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+
+	if err := storage.CopyBackend(brigadeID, from, dst); err != nil {
+		return fmt.Errorf("copy: %w", err)
+	}
+
+	if err := os.Rename(srcFilename, srcFilename+migratedSuffix); err != nil {
+		return fmt.Errorf("put source aside: %w", err)
+	}
+
+	if err := os.Remove(spinlock + migratedSuffix); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove temp spinlock: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/base64"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/netip"
+	"os"
+	"os/user"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/keydesk"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// Commands.
+const (
+	cmdStatus = "status"
+	cmdRetry  = "retry"
+	cmdDrop   = "drop"
+)
+
+// ErrInvalidArgs - invalid arguments.
+var ErrInvalidArgs = errors.New("invalid arguments")
+
+func main() {
+	cmd, opID, brigadeID, dbDir, err := parseArgs()
+	if err != nil {
+		log.Fatalf("Can't init: %s\n", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Brigade: %s\n", brigadeID)
+	fmt.Fprintf(os.Stderr, "DBDir: %s\n", dbDir)
+
+	db := &storage.BrigadeStorage{
+		BrigadeID:       brigadeID,
+		BrigadeFilename: filepath.Join(dbDir, storage.BrigadeFilename),
+		BrigadeSpinlock: filepath.Join(dbDir, storage.BrigadeSpinlockFilename),
+		APIAddrPort:     netip.AddrPort{},
+		BrigadeStorageOpts: storage.BrigadeStorageOpts{
+			MaxUsers:               keydesk.MaxUsers,
+			MonthlyQuotaRemaining:  keydesk.MonthlyQuotaRemaining,
+			MaxUserInctivityPeriod: keydesk.DefaultMaxUserInactivityPeriod,
+		},
+	}
+
+	if err := db.SelfCheck(); err != nil {
+		log.Fatalf("Storage initialization: %s\n", err)
+	}
+
+	switch cmd {
+	case cmdStatus:
+		ops, err := db.Outbox()
+		if err != nil {
+			log.Fatalf("Can't read: %s\n", err)
+		}
+
+		printStatus(ops)
+	case cmdRetry:
+		if err := db.RetryOutboxOp(opID); err != nil {
+			log.Fatalf("Can't retry: %s\n", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Retry: %s\n", opID)
+	case cmdDrop:
+		if err := db.DropOutboxOp(opID); err != nil {
+			log.Fatalf("Can't drop: %s\n", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Dropped: %s\n", opID)
+	}
+}
+
+// printStatus - the operations table in the processing order.
+func printStatus(ops []storage.OutboxOp) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "ID\tKIND\tUSER\tPEER\tCREATED\tATTEMPTS\tSTATE\tLAST ERROR")
+
+	for _, op := range ops {
+		state := "next " + op.NextAttempt.Format(time.RFC3339)
+		if op.IsDead() {
+			state = "dead " + op.DeadAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			op.ID, op.Kind, op.UserID, base64.StdEncoding.EncodeToString(op.WgPublicKey),
+			op.CreatedAt.Format(time.RFC3339), op.Attempts, state, op.LastError)
+	}
+
+	w.Flush()
+}
+
+func parseArgs() (string, uuid.UUID, string, string, error) {
+	var (
+		id    string
+		dbdir string
+		opID  uuid.UUID
+		err   error
+	)
+
+	sysUser, err := user.Current()
+	if err != nil {
+		return "", opID, "", "", fmt.Errorf("cannot define user: %w", err)
+	}
+
+	brigadeID := flag.String("id", "", "BrigadeID (for test)")
+	filedbDir := flag.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] %s | %s <id> | %s <id>\n", filepath.Base(os.Args[0]), cmdStatus, cmdRetry, cmdDrop)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	cmd := flag.Arg(0)
+	switch cmd {
+	case cmdStatus:
+		if flag.NArg() != 1 {
+			return "", opID, "", "", fmt.Errorf("%s: %w", cmd, ErrInvalidArgs)
+		}
+	case cmdRetry, cmdDrop:
+		if flag.NArg() != 2 {
+			return "", opID, "", "", fmt.Errorf("%s: %w", cmd, ErrInvalidArgs)
+		}
+
+		opID, err = uuid.Parse(flag.Arg(1))
+		if err != nil {
+			return "", opID, "", "", fmt.Errorf("operation id: %w", err)
+		}
+	default:
+		return "", opID, "", "", fmt.Errorf("command %q: %w", cmd, ErrInvalidArgs)
+	}
+
+	if *filedbDir != "" {
+		dbdir, err = filepath.Abs(*filedbDir)
+		if err != nil {
+			return "", opID, "", "", fmt.Errorf("dbdir dir: %w", err)
+		}
+	}
+
+	switch *brigadeID {
+	case "", sysUser.Username:
+		id = sysUser.Username
+
+		if *filedbDir == "" {
+			dbdir = filepath.Join(storage.DefaultHomeDir, id)
+		}
+	default:
+		id = *brigadeID
+
+		cwd, err := os.Getwd()
+		if err == nil {
+			cwd, _ = filepath.Abs(cwd)
+		}
+
+		if *filedbDir == "" {
+			dbdir = cwd
+		}
+	}
+
+	return cmd, opID, id, dbdir, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"flag"
@@ -85,14 +86,14 @@ func main() {
 			os.Exit(2)
 		case errors.Is(errDo, ErrNeedFullReplay):
 			fmt.Fprintln(os.Stderr, "Need full replay. Do it")
-			if err := db.ReplayBrigade(true, false, false, true, false); err != nil {
+			if err := db.ReplayBrigade(context.Background(), true, false, false, true, false); err != nil {
 				log.Fatalf("replay brigade: %s", err)
 			}
 		case errDo != nil:
 			log.Fatalf("Can't do: %s\n", errDo)
 		default:
 			fmt.Fprintln(os.Stderr, "Done")
-			if err := db.ReplayBrigade(false, false, false, true, true); err != nil {
+			if err := db.ReplayBrigade(context.Background(), false, false, false, true, true); err != nil {
 				log.Fatalf("replay brigade: %s", err)
 			}
 		}
@@ -250,6 +251,7 @@ func patchBrigadeCommon(fresh, old *storage.Brigade) (*storage.Brigade, error) {
 	newdata.BrigadeCounters = old.BrigadeCounters
 	newdata.StatsCountersStack = old.StatsCountersStack
 	newdata.Endpoints = old.Endpoints
+	newdata.Geo = old.Geo
 
 	newdata.Users = make([]*storage.User, 0, len(fresh.Users))
 
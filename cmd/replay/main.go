@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"encoding/base64"
 	"errors"
 	"flag"
 	"fmt"
@@ -9,7 +11,9 @@ import (
 	"os"
 	"os/user"
 	"path/filepath"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/vpngen/keydesk/keydesk"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/vpnapi"
@@ -19,7 +23,7 @@ import (
 var ErrInvalidArgs = errors.New("invalid arguments")
 
 func main() {
-	fresh, bonly, uonly, erase, delayed, donly, brigadeID, dbDir, addr, err := parseArgs()
+	fresh, bonly, uonly, erase, delayed, donly, reconcile, dryRun, brigadeID, dbDir, addr, err := parseArgs()
 	if err != nil {
 		log.Fatalf("Can't init: %s\n", err)
 		os.Exit(1)
@@ -51,12 +55,20 @@ func main() {
 		log.Fatalf("Storage initialization: %s\n", err)
 	}
 
+	if reconcile {
+		if err := Reconcile(db, dryRun); err != nil {
+			log.Fatalf("Can't reconcile: %s\n", err)
+		}
+
+		return
+	}
+
 	if err = Do(db, fresh, bonly, uonly, erase, delayed, donly, addr); err != nil {
 		log.Fatalf("Can't do: %s\n", err)
 	}
 }
 
-func parseArgs() (bool, bool, bool, bool, bool, bool, string, string, netip.AddrPort, error) {
+func parseArgs() (bool, bool, bool, bool, bool, bool, bool, bool, string, string, netip.AddrPort, error) {
 	var (
 		id       string
 		dbdir    string
@@ -66,7 +78,7 @@ func parseArgs() (bool, bool, bool, bool, bool, bool, string, string, netip.Addr
 
 	sysUser, err := user.Current()
 	if err != nil {
-		return false, false, false, false, true, false, "", "", addrPort, fmt.Errorf("cannot define user: %w", err)
+		return false, false, false, false, true, false, false, false, "", "", addrPort, fmt.Errorf("cannot define user: %w", err)
 	}
 
 	nodelayed := flag.Bool("nd", false, "no apply delayed actions")
@@ -75,6 +87,8 @@ func parseArgs() (bool, bool, bool, bool, bool, bool, string, string, netip.Addr
 	uonly := flag.Bool("u", false, "users only, don't use with -b or -r or -e flags")
 	fresh := flag.Bool("r", false, "clean before (with deletion), don't use with -u or -e flags")
 	erase := flag.Bool("e", false, "only delete brigades and users (without creation), don't use with any other mode flags")
+	reconcile := flag.Bool("c", false, "reconcile the endpoint peers with the brigade, don't use with any other mode flags")
+	dryRun := flag.Bool("n", false, "dry run, only report the reconcile diff, use with -c")
 	brigadeID := flag.String("id", "", "BrigadeID (for test)")
 	addr := flag.String("a", vpnapi.TemplatedAddrPort, "API endpoint address:port")
 	filedbDir := flag.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
@@ -82,21 +96,22 @@ func parseArgs() (bool, bool, bool, bool, bool, bool, string, string, netip.Addr
 	flag.Parse()
 
 	if (*bonly && *uonly) || (*fresh && *uonly) || (*erase && *uonly) || (*erase && *bonly) || (*fresh && *erase) ||
-		(*donly && *uonly) || (*donly && *bonly) || (*donly && *fresh) || (*donly && *erase) || (*donly && *nodelayed) {
-		return false, false, false, false, true, false, "", "", addrPort, ErrInvalidArgs
+		(*donly && *uonly) || (*donly && *bonly) || (*donly && *fresh) || (*donly && *erase) || (*donly && *nodelayed) ||
+		(*reconcile && (*bonly || *uonly || *fresh || *erase || *donly || *nodelayed)) || (*dryRun && !*reconcile) {
+		return false, false, false, false, true, false, false, false, "", "", addrPort, ErrInvalidArgs
 	}
 
 	if *filedbDir != "" {
 		dbdir, err = filepath.Abs(*filedbDir)
 		if err != nil {
-			return false, false, false, false, true, false, "", "", addrPort, fmt.Errorf("dbdir dir: %w", err)
+			return false, false, false, false, true, false, false, false, "", "", addrPort, fmt.Errorf("dbdir dir: %w", err)
 		}
 	}
 
 	if *addr != "-" {
 		addrPort, err = netip.ParseAddrPort(*addr)
 		if err != nil {
-			return false, false, false, false, true, false, "", "", addrPort, fmt.Errorf("addr: %w", err)
+			return false, false, false, false, true, false, false, false, "", "", addrPort, fmt.Errorf("addr: %w", err)
 		}
 	}
 
@@ -120,22 +135,44 @@ func parseArgs() (bool, bool, bool, bool, bool, bool, string, string, netip.Addr
 		}
 	}
 
-	return *fresh, *bonly, *uonly, *erase, !*nodelayed, *donly, id, dbdir, addrPort, nil
+	return *fresh, *bonly, *uonly, *erase, !*nodelayed, *donly, *reconcile, *dryRun, id, dbdir, addrPort, nil
 }
 
 // Do - do replay.
 func Do(db *storage.BrigadeStorage, fresh, bonly, uonly, erase, delayed, donly bool, addr netip.AddrPort) error {
 	if erase {
-		if err := db.DestroyBrigade(); err != nil {
+		if err := db.DestroyBrigade(context.Background()); err != nil {
 			return fmt.Errorf("destroy brigade: %w", err)
 		}
 
 		return nil
 	}
 
-	if err := db.ReplayBrigade(fresh, bonly, uonly, delayed, donly); err != nil {
+	if err := db.ReplayBrigade(context.Background(), fresh, bonly, uonly, delayed, donly); err != nil {
 		return fmt.Errorf("replay brigade: %w", err)
 	}
 
 	return nil
 }
+
+// Reconcile - converge the endpoint peers and print the diff.
+func Reconcile(db *storage.BrigadeStorage, dryRun bool) error {
+	diff, err := db.ReconcileBrigade(context.Background(), dryRun)
+
+	for _, peer := range diff {
+		userID := "-"
+		if peer.UserID != uuid.Nil {
+			userID = peer.UserID.String()
+		}
+
+		fmt.Printf("%s\t%s\t%s\t%s\t%s\n", peer.Reason, userID, base64.StdEncoding.EncodeToString(peer.WgPublicKey), peer.Name, strings.Join(peer.Params, ","))
+	}
+
+	if err != nil {
+		return fmt.Errorf("reconcile brigade: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Peers out of sync: %d (dry run: %v)\n", len(diff), dryRun)
+
+	return nil
+}
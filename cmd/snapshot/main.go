@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/netip"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib"
+	"github.com/vpngen/keydesk/keydesk"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// Commands.
+const (
+	cmdList    = "list"
+	cmdTake    = "take"
+	cmdRestore = "restore"
+)
+
+// ErrInvalidArgs - invalid arguments.
+var ErrInvalidArgs = errors.New("invalid arguments")
+
+func main() {
+	cmd, filename, keep, brigadeID, dbDir, err := parseArgs()
+	if err != nil {
+		log.Fatalf("Can't init: %s\n", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Brigade: %s\n", brigadeID)
+	fmt.Fprintf(os.Stderr, "DBDir: %s\n", dbDir)
+
+	db := &storage.BrigadeStorage{
+		BrigadeID:       brigadeID,
+		BrigadeFilename: filepath.Join(dbDir, storage.BrigadeFilename),
+		BrigadeSpinlock: filepath.Join(dbDir, storage.BrigadeSpinlockFilename),
+		APIAddrPort:     netip.AddrPort{},
+		BrigadeStorageOpts: storage.BrigadeStorageOpts{
+			MaxUsers:               keydesk.MaxUsers,
+			MonthlyQuotaRemaining:  keydesk.MonthlyQuotaRemaining,
+			MaxUserInctivityPeriod: keydesk.DefaultMaxUserInactivityPeriod,
+		},
+	}
+
+	// the brigade can be broken, so no SelfCheckAndInit.
+	if err := db.SelfCheck(); err != nil {
+		log.Fatalf("Storage initialization: %s\n", err)
+	}
+
+	switch cmd {
+	case cmdList:
+		list, err := kdlib.ListSnapshots(db.BrigadeFilename)
+		if err != nil {
+			log.Fatalf("Can't list: %s\n", err)
+		}
+
+		for _, s := range list {
+			fmt.Printf("%s\t%s\n", s.Time.Format(time.RFC3339), s.Filename)
+		}
+	case cmdTake:
+		f, data, err := db.OpenDbToModify()
+		if err != nil {
+			log.Fatalf("Can't open: %s\n", err)
+		}
+
+		// after the opening, so it doesn't take its own one.
+		db.Snapshots = keep
+
+		fn, err := db.TakeSnapshot(data, time.Now().UTC())
+		f.Close()
+
+		if err != nil {
+			log.Fatalf("Can't take: %s\n", err)
+		}
+
+		fmt.Println(fn)
+	case cmdRestore:
+		if err := db.RestoreSnapshot(filename); err != nil {
+			log.Fatalf("Can't restore: %s\n", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "Restored: %s\n", filename)
+	}
+}
+
+func parseArgs() (string, string, kdlib.SnapshotRetention, string, string, error) {
+	var (
+		id       string
+		dbdir    string
+		filename string
+		keep     kdlib.SnapshotRetention
+		err      error
+	)
+
+	sysUser, err := user.Current()
+	if err != nil {
+		return "", "", keep, "", "", fmt.Errorf("cannot define user: %w", err)
+	}
+
+	last := flag.Int("last", 24, "Keep N last snapshots (take)")
+	daily := flag.Int("daily", 7, "Keep daily snapshots for D days (take)")
+	brigadeID := flag.String("id", "", "BrigadeID (for test)")
+	filedbDir := flag.String("d", "", "Dir for db files (for test). Default: "+storage.DefaultHomeDir+"/<BrigadeID>")
+
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] %s | %s | %s <snapshot>\n", filepath.Base(os.Args[0]), cmdList, cmdTake, cmdRestore)
+		flag.PrintDefaults()
+	}
+
+	flag.Parse()
+
+	cmd := flag.Arg(0)
+	switch cmd {
+	case cmdList, cmdTake:
+		if flag.NArg() != 1 {
+			return "", "", keep, "", "", fmt.Errorf("%s: %w", cmd, ErrInvalidArgs)
+		}
+	case cmdRestore:
+		if flag.NArg() != 2 {
+			return "", "", keep, "", "", fmt.Errorf("%s: %w", cmd, ErrInvalidArgs)
+		}
+
+		filename, err = filepath.Abs(flag.Arg(1))
+		if err != nil {
+			return "", "", keep, "", "", fmt.Errorf("snapshot: %w", err)
+		}
+	default:
+		return "", "", keep, "", "", fmt.Errorf("command %q: %w", cmd, ErrInvalidArgs)
+	}
+
+	keep = kdlib.SnapshotRetention{Last: *last, Daily: *daily}
+
+	if *filedbDir != "" {
+		dbdir, err = filepath.Abs(*filedbDir)
+		if err != nil {
+			return "", "", keep, "", "", fmt.Errorf("dbdir dir: %w", err)
+		}
+	}
+
+	switch *brigadeID {
+	case "", sysUser.Username:
+		id = sysUser.Username
+
+		if *filedbDir == "" {
+			dbdir = filepath.Join(storage.DefaultHomeDir, id)
+		}
+	default:
+		id = *brigadeID
+
+		cwd, err := os.Getwd()
+		if err == nil {
+			cwd, _ = filepath.Abs(cwd)
+		}
+
+		if *filedbDir == "" {
+			dbdir = cwd
+		}
+	}
+
+	return cmd, filename, keep, id, dbdir, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -170,7 +171,7 @@ func Do(db *storage.BrigadeStorage, replay, purge bool, routerPublicKey, shuffle
 	}
 
 	if replay {
-		if err := db.ReplayBrigade(true, false, false, true, false); err != nil {
+		if err := db.ReplayBrigade(context.Background(), true, false, false, true, false); err != nil {
 			return fmt.Errorf("replay brigade: %w", err)
 		}
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"errors"
@@ -233,6 +234,7 @@ func addUser(
 	}
 
 	if _, err := db.CreateUser(
+		context.Background(),
 		userID,
 		vpnCfgs, fullname, person,
 		false, false,
@@ -241,6 +243,8 @@ func addUser(
 		"", "", "", "",
 		outlineSecretRouterEnc, outlineSecretShufflerEnc,
 		"", "",
+		storage.UserQuotaLimits{},
+		nil,
 	); err != nil {
 		fmt.Fprintf(os.Stderr, "put: %s\n", err)
 
@@ -16,6 +16,11 @@ go build -C keydesk/cmd/turnon-proto0 -o ../../../bin/turnon-proto0
 go build -C keydesk/cmd/turnon-vip -o ../../../bin/turnon-vip
 go build -C keydesk/cmd/destroybrigade -o ../../../bin/destroybrigade
 go build -C keydesk/cmd/fetchstats -o ../../../bin/fetchstats
+go build -C keydesk/cmd/migrate-storage -o ../../../bin/migrate-storage
+go build -C keydesk/cmd/keydesk-journal -o ../../../bin/keydesk-journal
+go build -C keydesk/cmd/migrate-schema -o ../../../bin/migrate-schema
+go build -C keydesk/cmd/snapshot -o ../../../bin/snapshot
+go build -C keydesk/cmd/outbox -o ../../../bin/outbox
 
 go install github.com/goreleaser/nfpm/v2/cmd/nfpm@v2.43.1
 
@@ -61,6 +61,12 @@ DeleteUserUserIDParams contains all the parameters to send to the API endpoint
 */
 type DeleteUserUserIDParams struct {
 
+	/* IfMatch.
+
+	   The brigade revision from the ETag, 400 if malformed.
+	*/
+	IfMatch *string
+
 	// UserID.
 	UserID string
 
@@ -117,6 +123,17 @@ func (o *DeleteUserUserIDParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIfMatch adds the ifMatch to the delete user user ID params
+func (o *DeleteUserUserIDParams) WithIfMatch(ifMatch *string) *DeleteUserUserIDParams {
+	o.SetIfMatch(ifMatch)
+	return o
+}
+
+// SetIfMatch adds the ifMatch to the delete user user ID params
+func (o *DeleteUserUserIDParams) SetIfMatch(ifMatch *string) {
+	o.IfMatch = ifMatch
+}
+
 // WithUserID adds the userID to the delete user user ID params
 func (o *DeleteUserUserIDParams) WithUserID(userID string) *DeleteUserUserIDParams {
 	o.SetUserID(userID)
@@ -136,6 +153,14 @@ func (o *DeleteUserUserIDParams) WriteToRequest(r runtime.ClientRequest, reg str
 	}
 	var res []error
 
+	if o.IfMatch != nil {
+
+		// header param If-Match
+		if err := r.SetHeaderParam("If-Match", *o.IfMatch); err != nil {
+			return err
+		}
+	}
+
 	// path param UserID
 	if err := r.SetPathParam("UserID", o.UserID); err != nil {
 		return err
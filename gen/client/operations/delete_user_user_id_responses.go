@@ -36,6 +36,12 @@ func (o *DeleteUserUserIDReader) ReadResponse(response runtime.ClientResponse, c
 			return nil, err
 		}
 		return nil, result
+	case 412:
+		result := NewDeleteUserUserIDPreconditionFailed()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewDeleteUserUserIDInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -172,6 +178,62 @@ func (o *DeleteUserUserIDForbidden) readResponse(response runtime.ClientResponse
 	return nil
 }
 
+// NewDeleteUserUserIDPreconditionFailed creates a DeleteUserUserIDPreconditionFailed with default headers values
+func NewDeleteUserUserIDPreconditionFailed() *DeleteUserUserIDPreconditionFailed {
+	return &DeleteUserUserIDPreconditionFailed{}
+}
+
+/*
+DeleteUserUserIDPreconditionFailed describes a response with status code 412, with default header values.
+
+The brigade revision is stale
+*/
+type DeleteUserUserIDPreconditionFailed struct {
+}
+
+// IsSuccess returns true when this delete user user Id precondition failed response has a 2xx status code
+func (o *DeleteUserUserIDPreconditionFailed) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this delete user user Id precondition failed response has a 3xx status code
+func (o *DeleteUserUserIDPreconditionFailed) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this delete user user Id precondition failed response has a 4xx status code
+func (o *DeleteUserUserIDPreconditionFailed) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this delete user user Id precondition failed response has a 5xx status code
+func (o *DeleteUserUserIDPreconditionFailed) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this delete user user Id precondition failed response a status code equal to that given
+func (o *DeleteUserUserIDPreconditionFailed) IsCode(code int) bool {
+	return code == 412
+}
+
+// Code gets the status code for the delete user user Id precondition failed response
+func (o *DeleteUserUserIDPreconditionFailed) Code() int {
+	return 412
+}
+
+func (o *DeleteUserUserIDPreconditionFailed) Error() string {
+	return fmt.Sprintf("[DELETE /user/{UserID}][%d] deleteUserUserIdPreconditionFailed", 412)
+}
+
+func (o *DeleteUserUserIDPreconditionFailed) String() string {
+	return fmt.Sprintf("[DELETE /user/{UserID}][%d] deleteUserUserIdPreconditionFailed", 412)
+}
+
+func (o *DeleteUserUserIDPreconditionFailed) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
 // NewDeleteUserUserIDInternalServerError creates a DeleteUserUserIDInternalServerError with default headers values
 func NewDeleteUserUserIDInternalServerError() *DeleteUserUserIDInternalServerError {
 	return &DeleteUserUserIDInternalServerError{}
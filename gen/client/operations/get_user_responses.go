@@ -71,6 +71,11 @@ GetUserOK describes a response with status code 200, with default header values.
 A list of users.
 */
 type GetUserOK struct {
+
+	/* The brigade revision.
+	 */
+	ETag string
+
 	Payload []*models.User
 }
 
@@ -120,6 +125,13 @@ func (o *GetUserOK) GetPayload() []*models.User {
 
 func (o *GetUserOK) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
 
+	// hydrates response header ETag
+	hdrETag := response.GetHeader("ETag")
+
+	if hdrETag != "" {
+		o.ETag = hdrETag
+	}
+
 	// response payload
 	if err := consumer.Consume(response.Body(), &o.Payload); err != nil && err != io.EOF {
 		return err
@@ -60,16 +60,30 @@ type ClientService interface {
 
 	GetUser(params *GetUserParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUserOK, error)
 
+	GetUserUserIDEvents(params *GetUserUserIDEventsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUserUserIDEventsOK, error)
+
+	GetUserUserIDTraffic(params *GetUserUserIDTrafficParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUserUserIDTrafficOK, error)
+
 	GetUsersStats(params *GetUsersStatsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUsersStatsOK, error)
 
+	GetUsersStatsGeo(params *GetUsersStatsGeoParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUsersStatsGeoOK, error)
+
+	GetUsersStatsTraffic(params *GetUsersStatsTrafficParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUsersStatsTrafficOK, error)
+
 	PatchUserUserIDBlock(params *PatchUserUserIDBlockParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PatchUserUserIDBlockOK, error)
 
+	PatchUserUserIDQuota(params *PatchUserUserIDQuotaParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PatchUserUserIDQuotaOK, error)
+
+	PatchUserUserIDThrottle(params *PatchUserUserIDThrottleParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PatchUserUserIDThrottleOK, error)
+
 	PatchUserUserIDUnblock(params *PatchUserUserIDUnblockParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PatchUserUserIDUnblockOK, error)
 
 	PostToken(params *PostTokenParams, opts ...ClientOption) (*PostTokenCreated, error)
 
 	PostUser(params *PostUserParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PostUserCreated, error)
 
+	PostUsersStatsRefresh(params *PostUsersStatsRefreshParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PostUsersStatsRefreshOK, error)
+
 	GetMessages(params *GetMessagesParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetMessagesOK, error)
 
 	MarkMessageAsRead(params *MarkMessageAsReadParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*MarkMessageAsReadOK, error)
@@ -153,6 +167,82 @@ func (a *Client) GetUser(params *GetUserParams, authInfo runtime.ClientAuthInfoW
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+GetUserUserIDEvents get user user ID events API
+*/
+func (a *Client) GetUserUserIDEvents(params *GetUserUserIDEventsParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUserUserIDEventsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetUserUserIDEventsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetUserUserIDEvents",
+		Method:             "GET",
+		PathPattern:        "/user/{UserID}/events",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetUserUserIDEventsReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetUserUserIDEventsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetUserUserIDEventsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+GetUserUserIDTraffic get user user ID traffic API
+*/
+func (a *Client) GetUserUserIDTraffic(params *GetUserUserIDTrafficParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUserUserIDTrafficOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetUserUserIDTrafficParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetUserUserIDTraffic",
+		Method:             "GET",
+		PathPattern:        "/user/{UserID}/traffic",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetUserUserIDTrafficReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetUserUserIDTrafficOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetUserUserIDTrafficDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 GetUsersStats get users stats API
 */
@@ -191,6 +281,82 @@ func (a *Client) GetUsersStats(params *GetUsersStatsParams, authInfo runtime.Cli
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+GetUsersStatsGeo get users stats geo API
+*/
+func (a *Client) GetUsersStatsGeo(params *GetUsersStatsGeoParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUsersStatsGeoOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetUsersStatsGeoParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetUsersStatsGeo",
+		Method:             "GET",
+		PathPattern:        "/users/stats/geo",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetUsersStatsGeoReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetUsersStatsGeoOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetUsersStatsGeoDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+GetUsersStatsTraffic get users stats traffic API
+*/
+func (a *Client) GetUsersStatsTraffic(params *GetUsersStatsTrafficParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*GetUsersStatsTrafficOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewGetUsersStatsTrafficParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "GetUsersStatsTraffic",
+		Method:             "GET",
+		PathPattern:        "/users/stats/traffic",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &GetUsersStatsTrafficReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*GetUsersStatsTrafficOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*GetUsersStatsTrafficDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 PatchUserUserIDBlock patch user user ID block API
 */
@@ -229,6 +395,82 @@ func (a *Client) PatchUserUserIDBlock(params *PatchUserUserIDBlockParams, authIn
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+PatchUserUserIDQuota patch user user ID quota API
+*/
+func (a *Client) PatchUserUserIDQuota(params *PatchUserUserIDQuotaParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PatchUserUserIDQuotaOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPatchUserUserIDQuotaParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PatchUserUserIDQuota",
+		Method:             "PATCH",
+		PathPattern:        "/user/{UserID}/quota",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PatchUserUserIDQuotaReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PatchUserUserIDQuotaOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*PatchUserUserIDQuotaDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+PatchUserUserIDThrottle patch user user ID throttle API
+*/
+func (a *Client) PatchUserUserIDThrottle(params *PatchUserUserIDThrottleParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PatchUserUserIDThrottleOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPatchUserUserIDThrottleParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PatchUserUserIDThrottle",
+		Method:             "PATCH",
+		PathPattern:        "/user/{UserID}/throttle",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PatchUserUserIDThrottleReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PatchUserUserIDThrottleOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*PatchUserUserIDThrottleDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 PatchUserUserIDUnblock patch user user ID unblock API
 */
@@ -342,6 +584,44 @@ func (a *Client) PostUser(params *PostUserParams, authInfo runtime.ClientAuthInf
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+PostUsersStatsRefresh post users stats refresh API
+*/
+func (a *Client) PostUsersStatsRefresh(params *PostUsersStatsRefreshParams, authInfo runtime.ClientAuthInfoWriter, opts ...ClientOption) (*PostUsersStatsRefreshOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewPostUsersStatsRefreshParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "PostUsersStatsRefresh",
+		Method:             "POST",
+		PathPattern:        "/users/stats/refresh",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &PostUsersStatsRefreshReader{formats: a.formats},
+		AuthInfo:           authInfo,
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*PostUsersStatsRefreshOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*PostUsersStatsRefreshDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 GetMessages gets messages
 
@@ -61,6 +61,12 @@ PatchUserUserIDBlockParams contains all the parameters to send to the API endpoi
 */
 type PatchUserUserIDBlockParams struct {
 
+	/* IfMatch.
+
+	   The brigade revision from the ETag, 400 if malformed.
+	*/
+	IfMatch *string
+
 	// UserID.
 	UserID string
 
@@ -117,6 +123,17 @@ func (o *PatchUserUserIDBlockParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIfMatch adds the ifMatch to the patch user user ID block params
+func (o *PatchUserUserIDBlockParams) WithIfMatch(ifMatch *string) *PatchUserUserIDBlockParams {
+	o.SetIfMatch(ifMatch)
+	return o
+}
+
+// SetIfMatch adds the ifMatch to the patch user user ID block params
+func (o *PatchUserUserIDBlockParams) SetIfMatch(ifMatch *string) {
+	o.IfMatch = ifMatch
+}
+
 // WithUserID adds the userID to the patch user user ID block params
 func (o *PatchUserUserIDBlockParams) WithUserID(userID string) *PatchUserUserIDBlockParams {
 	o.SetUserID(userID)
@@ -136,6 +153,14 @@ func (o *PatchUserUserIDBlockParams) WriteToRequest(r runtime.ClientRequest, reg
 	}
 	var res []error
 
+	if o.IfMatch != nil {
+
+		// header param If-Match
+		if err := r.SetHeaderParam("If-Match", *o.IfMatch); err != nil {
+			return err
+		}
+	}
+
 	// path param UserID
 	if err := r.SetPathParam("UserID", o.UserID); err != nil {
 		return err
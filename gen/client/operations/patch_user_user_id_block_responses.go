@@ -36,6 +36,12 @@ func (o *PatchUserUserIDBlockReader) ReadResponse(response runtime.ClientRespons
 			return nil, err
 		}
 		return nil, result
+	case 412:
+		result := NewPatchUserUserIDBlockPreconditionFailed()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewPatchUserUserIDBlockInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -172,6 +178,62 @@ func (o *PatchUserUserIDBlockForbidden) readResponse(response runtime.ClientResp
 	return nil
 }
 
+// NewPatchUserUserIDBlockPreconditionFailed creates a PatchUserUserIDBlockPreconditionFailed with default headers values
+func NewPatchUserUserIDBlockPreconditionFailed() *PatchUserUserIDBlockPreconditionFailed {
+	return &PatchUserUserIDBlockPreconditionFailed{}
+}
+
+/*
+PatchUserUserIDBlockPreconditionFailed describes a response with status code 412, with default header values.
+
+The brigade revision is stale
+*/
+type PatchUserUserIDBlockPreconditionFailed struct {
+}
+
+// IsSuccess returns true when this patch user user Id block precondition failed response has a 2xx status code
+func (o *PatchUserUserIDBlockPreconditionFailed) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this patch user user Id block precondition failed response has a 3xx status code
+func (o *PatchUserUserIDBlockPreconditionFailed) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this patch user user Id block precondition failed response has a 4xx status code
+func (o *PatchUserUserIDBlockPreconditionFailed) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this patch user user Id block precondition failed response has a 5xx status code
+func (o *PatchUserUserIDBlockPreconditionFailed) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this patch user user Id block precondition failed response a status code equal to that given
+func (o *PatchUserUserIDBlockPreconditionFailed) IsCode(code int) bool {
+	return code == 412
+}
+
+// Code gets the status code for the patch user user Id block precondition failed response
+func (o *PatchUserUserIDBlockPreconditionFailed) Code() int {
+	return 412
+}
+
+func (o *PatchUserUserIDBlockPreconditionFailed) Error() string {
+	return fmt.Sprintf("[PATCH /user/{UserID}/block][%d] patchUserUserIdBlockPreconditionFailed", 412)
+}
+
+func (o *PatchUserUserIDBlockPreconditionFailed) String() string {
+	return fmt.Sprintf("[PATCH /user/{UserID}/block][%d] patchUserUserIdBlockPreconditionFailed", 412)
+}
+
+func (o *PatchUserUserIDBlockPreconditionFailed) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
 // NewPatchUserUserIDBlockInternalServerError creates a PatchUserUserIDBlockInternalServerError with default headers values
 func NewPatchUserUserIDBlockInternalServerError() *PatchUserUserIDBlockInternalServerError {
 	return &PatchUserUserIDBlockInternalServerError{}
@@ -61,6 +61,12 @@ PatchUserUserIDUnblockParams contains all the parameters to send to the API endp
 */
 type PatchUserUserIDUnblockParams struct {
 
+	/* IfMatch.
+
+	   The brigade revision from the ETag, 400 if malformed.
+	*/
+	IfMatch *string
+
 	// UserID.
 	UserID string
 
@@ -117,6 +123,17 @@ func (o *PatchUserUserIDUnblockParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIfMatch adds the ifMatch to the patch user user ID unblock params
+func (o *PatchUserUserIDUnblockParams) WithIfMatch(ifMatch *string) *PatchUserUserIDUnblockParams {
+	o.SetIfMatch(ifMatch)
+	return o
+}
+
+// SetIfMatch adds the ifMatch to the patch user user ID unblock params
+func (o *PatchUserUserIDUnblockParams) SetIfMatch(ifMatch *string) {
+	o.IfMatch = ifMatch
+}
+
 // WithUserID adds the userID to the patch user user ID unblock params
 func (o *PatchUserUserIDUnblockParams) WithUserID(userID string) *PatchUserUserIDUnblockParams {
 	o.SetUserID(userID)
@@ -136,6 +153,14 @@ func (o *PatchUserUserIDUnblockParams) WriteToRequest(r runtime.ClientRequest, r
 	}
 	var res []error
 
+	if o.IfMatch != nil {
+
+		// header param If-Match
+		if err := r.SetHeaderParam("If-Match", *o.IfMatch); err != nil {
+			return err
+		}
+	}
+
 	// path param UserID
 	if err := r.SetPathParam("UserID", o.UserID); err != nil {
 		return err
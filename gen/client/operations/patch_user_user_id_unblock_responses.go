@@ -36,6 +36,12 @@ func (o *PatchUserUserIDUnblockReader) ReadResponse(response runtime.ClientRespo
 			return nil, err
 		}
 		return nil, result
+	case 412:
+		result := NewPatchUserUserIDUnblockPreconditionFailed()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewPatchUserUserIDUnblockInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -172,6 +178,62 @@ func (o *PatchUserUserIDUnblockForbidden) readResponse(response runtime.ClientRe
 	return nil
 }
 
+// NewPatchUserUserIDUnblockPreconditionFailed creates a PatchUserUserIDUnblockPreconditionFailed with default headers values
+func NewPatchUserUserIDUnblockPreconditionFailed() *PatchUserUserIDUnblockPreconditionFailed {
+	return &PatchUserUserIDUnblockPreconditionFailed{}
+}
+
+/*
+PatchUserUserIDUnblockPreconditionFailed describes a response with status code 412, with default header values.
+
+The brigade revision is stale
+*/
+type PatchUserUserIDUnblockPreconditionFailed struct {
+}
+
+// IsSuccess returns true when this patch user user Id unblock precondition failed response has a 2xx status code
+func (o *PatchUserUserIDUnblockPreconditionFailed) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this patch user user Id unblock precondition failed response has a 3xx status code
+func (o *PatchUserUserIDUnblockPreconditionFailed) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this patch user user Id unblock precondition failed response has a 4xx status code
+func (o *PatchUserUserIDUnblockPreconditionFailed) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this patch user user Id unblock precondition failed response has a 5xx status code
+func (o *PatchUserUserIDUnblockPreconditionFailed) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this patch user user Id unblock precondition failed response a status code equal to that given
+func (o *PatchUserUserIDUnblockPreconditionFailed) IsCode(code int) bool {
+	return code == 412
+}
+
+// Code gets the status code for the patch user user Id unblock precondition failed response
+func (o *PatchUserUserIDUnblockPreconditionFailed) Code() int {
+	return 412
+}
+
+func (o *PatchUserUserIDUnblockPreconditionFailed) Error() string {
+	return fmt.Sprintf("[PATCH /user/{UserID}/unblock][%d] patchUserUserIdUnblockPreconditionFailed", 412)
+}
+
+func (o *PatchUserUserIDUnblockPreconditionFailed) String() string {
+	return fmt.Sprintf("[PATCH /user/{UserID}/unblock][%d] patchUserUserIdUnblockPreconditionFailed", 412)
+}
+
+func (o *PatchUserUserIDUnblockPreconditionFailed) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
 // NewPatchUserUserIDUnblockInternalServerError creates a PatchUserUserIDUnblockInternalServerError with default headers values
 func NewPatchUserUserIDUnblockInternalServerError() *PatchUserUserIDUnblockInternalServerError {
 	return &PatchUserUserIDUnblockInternalServerError{}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewPostUserParams creates a new PostUserParams object,
@@ -60,6 +61,25 @@ PostUserParams contains all the parameters to send to the API endpoint
 	Typically these are written to a http.Request.
 */
 type PostUserParams struct {
+
+	/* IfMatch.
+
+	   The brigade revision from the ETag, 400 if malformed.
+	*/
+	IfMatch *string
+
+	/* MonthlyQuotaGB.
+
+	   The monthly quota, 0 for the brigade default.
+	*/
+	MonthlyQuotaGB *int64
+
+	/* MonthlyQuotaResetDay.
+
+	   The monthly quota reset day, 0 for the 1st.
+	*/
+	MonthlyQuotaResetDay *int64
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -113,6 +133,39 @@ func (o *PostUserParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIfMatch adds the ifMatch to the post user params
+func (o *PostUserParams) WithIfMatch(ifMatch *string) *PostUserParams {
+	o.SetIfMatch(ifMatch)
+	return o
+}
+
+// SetIfMatch adds the ifMatch to the post user params
+func (o *PostUserParams) SetIfMatch(ifMatch *string) {
+	o.IfMatch = ifMatch
+}
+
+// WithMonthlyQuotaGB adds the monthlyQuotaGB to the post user params
+func (o *PostUserParams) WithMonthlyQuotaGB(monthlyQuotaGB *int64) *PostUserParams {
+	o.SetMonthlyQuotaGB(monthlyQuotaGB)
+	return o
+}
+
+// SetMonthlyQuotaGB adds the monthlyQuotaGB to the post user params
+func (o *PostUserParams) SetMonthlyQuotaGB(monthlyQuotaGB *int64) {
+	o.MonthlyQuotaGB = monthlyQuotaGB
+}
+
+// WithMonthlyQuotaResetDay adds the monthlyQuotaResetDay to the post user params
+func (o *PostUserParams) WithMonthlyQuotaResetDay(monthlyQuotaResetDay *int64) *PostUserParams {
+	o.SetMonthlyQuotaResetDay(monthlyQuotaResetDay)
+	return o
+}
+
+// SetMonthlyQuotaResetDay adds the monthlyQuotaResetDay to the post user params
+func (o *PostUserParams) SetMonthlyQuotaResetDay(monthlyQuotaResetDay *int64) {
+	o.MonthlyQuotaResetDay = monthlyQuotaResetDay
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *PostUserParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -121,6 +174,48 @@ func (o *PostUserParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Regi
 	}
 	var res []error
 
+	if o.IfMatch != nil {
+
+		// header param If-Match
+		if err := r.SetHeaderParam("If-Match", *o.IfMatch); err != nil {
+			return err
+		}
+	}
+
+	if o.MonthlyQuotaGB != nil {
+
+		// query param MonthlyQuotaGB
+		var qrMonthlyQuotaGB int64
+
+		if o.MonthlyQuotaGB != nil {
+			qrMonthlyQuotaGB = *o.MonthlyQuotaGB
+		}
+		qMonthlyQuotaGB := swag.FormatInt64(qrMonthlyQuotaGB)
+		if qMonthlyQuotaGB != "" {
+
+			if err := r.SetQueryParam("MonthlyQuotaGB", qMonthlyQuotaGB); err != nil {
+				return err
+			}
+		}
+	}
+
+	if o.MonthlyQuotaResetDay != nil {
+
+		// query param MonthlyQuotaResetDay
+		var qrMonthlyQuotaResetDay int64
+
+		if o.MonthlyQuotaResetDay != nil {
+			qrMonthlyQuotaResetDay = *o.MonthlyQuotaResetDay
+		}
+		qMonthlyQuotaResetDay := swag.FormatInt64(qrMonthlyQuotaResetDay)
+		if qMonthlyQuotaResetDay != "" {
+
+			if err := r.SetQueryParam("MonthlyQuotaResetDay", qMonthlyQuotaResetDay); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
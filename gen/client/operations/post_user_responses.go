@@ -36,6 +36,12 @@ func (o *PostUserReader) ReadResponse(response runtime.ClientResponse, consumer
 			return nil, err
 		}
 		return nil, result
+	case 412:
+		result := NewPostUserPreconditionFailed()
+		if err := result.readResponse(response, consumer, o.formats); err != nil {
+			return nil, err
+		}
+		return nil, result
 	case 500:
 		result := NewPostUserInternalServerError()
 		if err := result.readResponse(response, consumer, o.formats); err != nil {
@@ -186,6 +192,62 @@ func (o *PostUserForbidden) readResponse(response runtime.ClientResponse, consum
 	return nil
 }
 
+// NewPostUserPreconditionFailed creates a PostUserPreconditionFailed with default headers values
+func NewPostUserPreconditionFailed() *PostUserPreconditionFailed {
+	return &PostUserPreconditionFailed{}
+}
+
+/*
+PostUserPreconditionFailed describes a response with status code 412, with default header values.
+
+The brigade revision is stale
+*/
+type PostUserPreconditionFailed struct {
+}
+
+// IsSuccess returns true when this post user precondition failed response has a 2xx status code
+func (o *PostUserPreconditionFailed) IsSuccess() bool {
+	return false
+}
+
+// IsRedirect returns true when this post user precondition failed response has a 3xx status code
+func (o *PostUserPreconditionFailed) IsRedirect() bool {
+	return false
+}
+
+// IsClientError returns true when this post user precondition failed response has a 4xx status code
+func (o *PostUserPreconditionFailed) IsClientError() bool {
+	return true
+}
+
+// IsServerError returns true when this post user precondition failed response has a 5xx status code
+func (o *PostUserPreconditionFailed) IsServerError() bool {
+	return false
+}
+
+// IsCode returns true when this post user precondition failed response a status code equal to that given
+func (o *PostUserPreconditionFailed) IsCode(code int) bool {
+	return code == 412
+}
+
+// Code gets the status code for the post user precondition failed response
+func (o *PostUserPreconditionFailed) Code() int {
+	return 412
+}
+
+func (o *PostUserPreconditionFailed) Error() string {
+	return fmt.Sprintf("[POST /user][%d] postUserPreconditionFailed", 412)
+}
+
+func (o *PostUserPreconditionFailed) String() string {
+	return fmt.Sprintf("[POST /user][%d] postUserPreconditionFailed", 412)
+}
+
+func (o *PostUserPreconditionFailed) readResponse(response runtime.ClientResponse, consumer runtime.Consumer, formats strfmt.Registry) error {
+
+	return nil
+}
+
 // NewPostUserInternalServerError creates a PostUserInternalServerError with default headers values
 func NewPostUserInternalServerError() *PostUserInternalServerError {
 	return &PostUserInternalServerError{}
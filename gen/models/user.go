@@ -38,10 +38,17 @@ type User struct {
 	// Format: date-time
 	LastVisitHour *strfmt.DateTime `json:"LastVisitHour,omitempty"`
 
+	// The user monthly quota, absent for the brigade default
+	MonthlyQuotaGB int64 `json:"MonthlyQuotaGB,omitempty"`
+
 	// monthly quota remaining g b
 	// Required: true
 	MonthlyQuotaRemainingGB *float32 `json:"MonthlyQuotaRemainingGB"`
 
+	// monthly quota reset on
+	// Format: date-time
+	MonthlyQuotaResetOn *strfmt.DateTime `json:"MonthlyQuotaResetOn,omitempty"`
+
 	// monthly traffic
 	MonthlyTraffic int64 `json:"MonthlyTraffic,omitempty"`
 
@@ -103,6 +110,10 @@ func (m *User) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateMonthlyQuotaResetOn(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateStatus(formats); err != nil {
 		res = append(res, err)
 	}
@@ -171,6 +182,18 @@ func (m *User) validateMonthlyQuotaRemainingGB(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *User) validateMonthlyQuotaResetOn(formats strfmt.Registry) error {
+	if swag.IsZero(m.MonthlyQuotaResetOn) { // not required
+		return nil
+	}
+
+	if err := validate.FormatOf("MonthlyQuotaResetOn", "body", "date-time", m.MonthlyQuotaResetOn.String(), formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (m *User) validateStatus(formats strfmt.Registry) error {
 
 	if err := validate.Required("Status", "body", m.Status); err != nil {
@@ -60,6 +60,11 @@ func configureAPI(api *operations.UserAPI) http.Handler {
 			return middleware.NotImplemented("operation operations.GetUser has not yet been implemented")
 		})
 	}
+	if api.GetUserUserIDEventsHandler == nil {
+		api.GetUserUserIDEventsHandler = operations.GetUserUserIDEventsHandlerFunc(func(params operations.GetUserUserIDEventsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation operations.GetUserUserIDEvents has not yet been implemented")
+		})
+	}
 	if api.GetUsersStatsHandler == nil {
 		api.GetUsersStatsHandler = operations.GetUsersStatsHandlerFunc(func(params operations.GetUsersStatsParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation operations.GetUsersStats has not yet been implemented")
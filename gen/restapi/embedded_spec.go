@@ -184,6 +184,12 @@ func init() {
               "items": {
                 "$ref": "#/definitions/user"
               }
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The brigade revision."
+              }
             }
           },
           "403": {
@@ -215,6 +221,30 @@ func init() {
         "produces": [
           "application/json"
         ],
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "maximum": 17179869183,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota, 0 for the brigade default.",
+            "name": "MonthlyQuotaGB",
+            "in": "query"
+          },
+          {
+            "maximum": 31,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota reset day, 0 for the 1st.",
+            "name": "MonthlyQuotaResetDay",
+            "in": "query"
+          }
+        ],
         "responses": {
           "201": {
             "description": "New user created.",
@@ -225,6 +255,9 @@ func init() {
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -259,6 +292,12 @@ func init() {
             "name": "UserID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -268,6 +307,9 @@ func init() {
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -302,6 +344,12 @@ func init() {
             "name": "UserID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
           }
         ],
         "responses": {
@@ -311,6 +359,9 @@ func init() {
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -329,8 +380,8 @@ func init() {
         }
       }
     },
-    "/user/{UserID}/unblock": {
-      "patch": {
+    "/user/{UserID}/events": {
+      "get": {
         "security": [
           {
             "Bearer": []
@@ -349,11 +400,20 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "User unblocked."
+            "description": "User events, the oldest first.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/user_event"
+              }
+            }
           },
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "404": {
+            "description": "User not found"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -372,8 +432,8 @@ func init() {
         }
       }
     },
-    "/users/stats": {
-      "get": {
+    "/user/{UserID}/quota": {
+      "patch": {
         "security": [
           {
             "Bearer": []
@@ -382,16 +442,112 @@ func init() {
         "produces": [
           "application/json"
         ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "maximum": 17179869183,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota, 0 for the brigade default.",
+            "name": "MonthlyQuotaGB",
+            "in": "query"
+          },
+          {
+            "maximum": 31,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota reset day, 0 for the 1st.",
+            "name": "MonthlyQuotaResetDay",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "A list of stats.",
+            "description": "User quota set."
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "404": {
+            "description": "User not found"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
             "schema": {
-              "$ref": "#/definitions/stats"
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
             }
+          }
+        }
+      }
+    },
+    "/user/{UserID}/throttle": {
+      "patch": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "maximum": 744,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The throttling period in hours, 0 lifts the brigadier throttling.",
+            "name": "Hours",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "User throttling set."
           },
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "404": {
+            "description": "User not found"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -409,82 +565,349 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
-    "Message": {
-      "type": "object",
-      "required": [
-        "text",
-        "title"
-      ],
-      "properties": {
-        "id": {
-          "type": "string",
-          "format": "uuid"
-        },
-        "is_read": {
-          "type": "boolean"
-        },
-        "priority": {
-          "type": "integer"
-        },
-        "text": {
-          "type": "string"
-        },
-        "time": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "title": {
-          "type": "string"
-        },
-        "ttl": {
-          "type": "string"
+    },
+    "/user/{UserID}/traffic": {
+      "get": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 90,
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of days up to today, 30 if absent.",
+            "name": "days",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "User daily traffic, the oldest day first.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/traffic_day"
+              }
+            }
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "404": {
+            "description": "User not found"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
       }
     },
-    "Messages": {
-      "type": "object",
-      "required": [
-        "messages",
-        "total"
-      ],
-      "properties": {
-        "messages": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/Message"
+    "/user/{UserID}/unblock": {
+      "patch": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "User unblocked."
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
-        },
-        "total": {
-          "type": "integer"
         }
       }
     },
-    "VGC": {
-      "type": "string"
-    },
-    "error": {
-      "type": "object",
-      "required": [
-        "message"
-      ],
-      "properties": {
-        "code": {
+    "/users/stats": {
+      "get": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of stats.",
+            "schema": {
+              "$ref": "#/definitions/stats"
+            }
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/users/stats/geo": {
+      "get": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "responses": {
+          "200": {
+            "description": "Active users by countries and autonomous systems.",
+            "schema": {
+              "$ref": "#/definitions/geo_stats"
+            }
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "404": {
+            "description": "No geo database or no stats yet"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/users/stats/refresh": {
+      "post": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "responses": {
+          "200": {
+            "description": "The stats after the refresh.",
+            "schema": {
+              "$ref": "#/definitions/stats"
+            }
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "429": {
+            "description": "Too many refreshes, retry later"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/users/stats/traffic": {
+      "get": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
+          {
+            "maximum": 90,
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of days up to today, 30 if absent.",
+            "name": "days",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Brigade daily traffic, the oldest day first.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/traffic_day"
+              }
+            }
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "Message": {
+      "type": "object",
+      "required": [
+        "text",
+        "title"
+      ],
+      "properties": {
+        "id": {
+          "type": "string",
+          "format": "uuid"
+        },
+        "is_read": {
+          "type": "boolean"
+        },
+        "priority": {
           "type": "integer"
         },
-        "message": {
+        "text": {
+          "type": "string"
+        },
+        "time": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "title": {
+          "type": "string"
+        },
+        "ttl": {
           "type": "string"
         }
       }
     },
-    "maintenance_error": {
+    "Messages": {
       "type": "object",
       "required": [
-        "code",
-        "message",
-        "retry_after"
+        "messages",
+        "total"
+      ],
+      "properties": {
+        "messages": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Message"
+          }
+        },
+        "total": {
+          "type": "integer"
+        }
+      }
+    },
+    "VGC": {
+      "type": "string"
+    },
+    "error": {
+      "type": "object",
+      "required": [
+        "message"
       ],
       "properties": {
         "code": {
@@ -492,54 +915,142 @@ func init() {
         },
         "message": {
           "type": "string"
-        },
-        "retry_after": {
-          "type": "string"
         }
       }
     },
-    "newuser": {
+    "geo_asn": {
       "type": "object",
       "required": [
-        "UserID",
-        "UserName",
-        "TotalSlots",
-        "FreeSlots"
+        "ASN",
+        "Users"
       ],
       "properties": {
-        "AmnzOvcConfig": {
-          "type": "object",
-          "required": [
-            "TonnelName",
-            "FileName",
-            "FileContent"
-          ],
-          "properties": {
-            "FileContent": {
-              "type": "string"
-            },
-            "FileName": {
-              "type": "string"
-            },
-            "TonnelName": {
-              "type": "string"
-            }
-          }
+        "ASN": {
+          "description": "Autonomous system number",
+          "type": "integer"
         },
-        "Domain": {
+        "Org": {
+          "description": "Autonomous system organization",
           "type": "string"
         },
-        "FreeSlots": {
+        "Users": {
+          "description": "Active users from the autonomous system",
           "type": "integer"
+        }
+      }
+    },
+    "geo_country": {
+      "type": "object",
+      "required": [
+        "Country",
+        "Users"
+      ],
+      "properties": {
+        "Country": {
+          "description": "ISO 3166-1 alpha-2 country code",
+          "type": "string"
         },
-        "IPSecL2TPManualConfig": {
-          "type": "object",
-          "required": [
-            "Server",
-            "PSK",
-            "Username",
-            "Password"
-          ],
+        "Users": {
+          "description": "Active users from the country",
+          "type": "integer"
+        }
+      }
+    },
+    "geo_stats": {
+      "type": "object",
+      "required": [
+        "UpdateTime",
+        "Countries",
+        "ASNs",
+        "Unknown"
+      ],
+      "properties": {
+        "ASNs": {
+          "description": "Active users by autonomous systems, the most users first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/geo_asn"
+          }
+        },
+        "Countries": {
+          "description": "Active users by countries, the most users first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/geo_country"
+          }
+        },
+        "Unknown": {
+          "description": "Active users without the location",
+          "type": "integer"
+        },
+        "UpdateTime": {
+          "description": "The stats collection time",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
+    "maintenance_error": {
+      "type": "object",
+      "required": [
+        "code",
+        "message",
+        "retry_after"
+      ],
+      "properties": {
+        "code": {
+          "type": "integer"
+        },
+        "message": {
+          "type": "string"
+        },
+        "retry_after": {
+          "type": "string"
+        }
+      }
+    },
+    "newuser": {
+      "type": "object",
+      "required": [
+        "UserID",
+        "UserName",
+        "TotalSlots",
+        "FreeSlots"
+      ],
+      "properties": {
+        "AmnzOvcConfig": {
+          "type": "object",
+          "required": [
+            "TonnelName",
+            "FileName",
+            "FileContent"
+          ],
+          "properties": {
+            "FileContent": {
+              "type": "string"
+            },
+            "FileName": {
+              "type": "string"
+            },
+            "TonnelName": {
+              "type": "string"
+            }
+          }
+        },
+        "Domain": {
+          "type": "string"
+        },
+        "FreeSlots": {
+          "type": "integer"
+        },
+        "IPSecL2TPManualConfig": {
+          "type": "object",
+          "required": [
+            "Server",
+            "PSK",
+            "Username",
+            "Password"
+          ],
           "properties": {
             "PSK": {
               "type": "string"
@@ -683,236 +1194,728 @@ func init() {
         }
       }
     },
-    "token": {
-      "type": "object",
-      "required": [
-        "Token"
-      ],
-      "properties": {
-        "Token": {
-          "type": "string"
+    "token": {
+      "type": "object",
+      "required": [
+        "Token"
+      ],
+      "properties": {
+        "Token": {
+          "type": "string"
+        }
+      }
+    },
+    "traffic_day": {
+      "type": "object",
+      "required": [
+        "Date",
+        "TotalRx",
+        "TotalTx"
+      ],
+      "properties": {
+        "Date": {
+          "type": "string",
+          "format": "date"
+        },
+        "IPSecRx": {
+          "description": "IPSec received bytes",
+          "type": "integer"
+        },
+        "IPSecTx": {
+          "description": "IPSec sent bytes",
+          "type": "integer"
+        },
+        "OpenVPNRx": {
+          "description": "OpenVPN over Cloak received bytes",
+          "type": "integer"
+        },
+        "OpenVPNTx": {
+          "description": "OpenVPN over Cloak sent bytes",
+          "type": "integer"
+        },
+        "OutlineRx": {
+          "description": "Outline received bytes",
+          "type": "integer"
+        },
+        "OutlineTx": {
+          "description": "Outline sent bytes",
+          "type": "integer"
+        },
+        "Proto0Rx": {
+          "description": "Protocol0 received bytes",
+          "type": "integer"
+        },
+        "Proto0Tx": {
+          "description": "Protocol0 sent bytes",
+          "type": "integer"
+        },
+        "TotalRx": {
+          "description": "All protocols received bytes",
+          "type": "integer"
+        },
+        "TotalTx": {
+          "description": "All protocols sent bytes",
+          "type": "integer"
+        },
+        "WireguardRx": {
+          "description": "Wireguard received bytes",
+          "type": "integer"
+        },
+        "WireguardTx": {
+          "description": "Wireguard sent bytes",
+          "type": "integer"
+        }
+      }
+    },
+    "user": {
+      "type": "object",
+      "required": [
+        "UserID",
+        "UserName",
+        "CreatedAt",
+        "Status",
+        "MonthlyQuotaRemainingGB"
+      ],
+      "properties": {
+        "Blocked": {
+          "type": "boolean"
+        },
+        "BlockedAt": {
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "CreatedAt": {
+          "type": "string",
+          "format": "date-time"
+        },
+        "DailyTraffic": {
+          "type": "number",
+          "format": "integer"
+        },
+        "LastVisitHour": {
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "MonthlyQuotaGB": {
+          "description": "The user monthly quota, absent for the brigade default",
+          "type": "integer"
+        },
+        "MonthlyQuotaRemainingGB": {
+          "type": "number",
+          "format": "float"
+        },
+        "MonthlyQuotaResetOn": {
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "MonthlyTraffic": {
+          "type": "number",
+          "format": "integer"
+        },
+        "PersonDesc": {
+          "type": "string"
+        },
+        "PersonDescLink": {
+          "type": "string"
+        },
+        "PersonName": {
+          "type": "string"
+        },
+        "PrevDayTraffic": {
+          "type": "number",
+          "format": "integer"
+        },
+        "Status": {
+          "type": "string"
+        },
+        "ThrottlingTill": {
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
+        "TotalTraffic": {
+          "type": "number",
+          "format": "integer"
+        },
+        "UserID": {
+          "type": "string"
+        },
+        "UserName": {
+          "type": "string"
+        },
+        "WeeklyTraffic": {
+          "type": "number",
+          "format": "integer"
+        },
+        "YearlyTraffic": {
+          "type": "number",
+          "format": "integer"
+        }
+      }
+    },
+    "user_event": {
+      "type": "object",
+      "required": [
+        "Kind",
+        "Time"
+      ],
+      "properties": {
+        "Detail": {
+          "description": "The protocol for first_connect",
+          "type": "string"
+        },
+        "Kind": {
+          "description": "created, blocked, unblocked, throttled, throttle_lifted, quota_reset, quota_changed, config_reissued, first_connect",
+          "type": "string"
+        },
+        "Time": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    }
+  },
+  "securityDefinitions": {
+    "Bearer": {
+      "type": "apiKey",
+      "name": "Authorization",
+      "in": "header"
+    }
+  }
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "swagger": "2.0",
+  "info": {
+    "title": "Keydesk server",
+    "version": "1.0.0"
+  },
+  "basePath": "/",
+  "paths": {
+    "/messages": {
+      "get": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "description": "Get messages, used by frontend. JWT token is required.",
+        "summary": "Get messages",
+        "operationId": "getMessages",
+        "parameters": [
+          {
+            "type": "integer",
+            "default": 0,
+            "name": "offset",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "default": 25,
+            "name": "limit",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "name": "read",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "name": "priority",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "name": "sort-priority",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "asc",
+              "desc"
+            ],
+            "type": "string",
+            "name": "sort-time",
+            "in": "query"
+          },
+          {
+            "enum": [
+              "eq",
+              "ne",
+              "gt",
+              "lt",
+              "ge",
+              "le"
+            ],
+            "type": "string",
+            "default": "eq",
+            "name": "priority-op",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "List of messages",
+            "schema": {
+              "$ref": "#/definitions/Messages"
+            }
+          },
+          "500": {
+            "description": ""
+          }
+        }
+      }
+    },
+    "/messages/{id}/read": {
+      "post": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "description": "Used by frontend. JWT token is required.",
+        "summary": "Mark message as read",
+        "operationId": "markMessageAsRead",
+        "parameters": [
+          {
+            "type": "string",
+            "format": "uuid",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK"
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/token": {
+      "post": {
+        "produces": [
+          "application/json"
+        ],
+        "responses": {
+          "201": {
+            "description": "Token created.",
+            "schema": {
+              "$ref": "#/definitions/token"
+            }
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/user": {
+      "get": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "responses": {
+          "200": {
+            "description": "A list of users.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/user"
+              }
+            },
+            "headers": {
+              "ETag": {
+                "type": "string",
+                "description": "The brigade revision."
+              }
+            }
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "maximum": 17179869183,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota, 0 for the brigade default.",
+            "name": "MonthlyQuotaGB",
+            "in": "query"
+          },
+          {
+            "maximum": 31,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota reset day, 0 for the 1st.",
+            "name": "MonthlyQuotaResetDay",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "201": {
+            "description": "New user created.",
+            "schema": {
+              "$ref": "#/definitions/newuser"
+            }
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{UserID}": {
+      "delete": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "User deleted."
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{UserID}/block": {
+      "patch": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "User blocked."
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
+          },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
         }
       }
     },
-    "user": {
-      "type": "object",
-      "required": [
-        "UserID",
-        "UserName",
-        "CreatedAt",
-        "Status",
-        "MonthlyQuotaRemainingGB"
-      ],
-      "properties": {
-        "Blocked": {
-          "type": "boolean"
-        },
-        "BlockedAt": {
-          "type": "string",
-          "format": "date-time",
-          "x-nullable": true
-        },
-        "CreatedAt": {
-          "type": "string",
-          "format": "date-time"
-        },
-        "DailyTraffic": {
-          "type": "number",
-          "format": "integer"
-        },
-        "LastVisitHour": {
-          "type": "string",
-          "format": "date-time",
-          "x-nullable": true
-        },
-        "MonthlyQuotaRemainingGB": {
-          "type": "number",
-          "format": "float"
-        },
-        "MonthlyTraffic": {
-          "type": "number",
-          "format": "integer"
-        },
-        "PersonDesc": {
-          "type": "string"
-        },
-        "PersonDescLink": {
-          "type": "string"
-        },
-        "PersonName": {
-          "type": "string"
-        },
-        "PrevDayTraffic": {
-          "type": "number",
-          "format": "integer"
-        },
-        "Status": {
-          "type": "string"
-        },
-        "ThrottlingTill": {
-          "type": "string",
-          "format": "date-time",
-          "x-nullable": true
-        },
-        "TotalTraffic": {
-          "type": "number",
-          "format": "integer"
-        },
-        "UserID": {
-          "type": "string"
-        },
-        "UserName": {
-          "type": "string"
-        },
-        "WeeklyTraffic": {
-          "type": "number",
-          "format": "integer"
-        },
-        "YearlyTraffic": {
-          "type": "number",
-          "format": "integer"
-        }
-      }
-    }
-  },
-  "securityDefinitions": {
-    "Bearer": {
-      "type": "apiKey",
-      "name": "Authorization",
-      "in": "header"
-    }
-  }
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "swagger": "2.0",
-  "info": {
-    "title": "Keydesk server",
-    "version": "1.0.0"
-  },
-  "basePath": "/",
-  "paths": {
-    "/messages": {
+    "/user/{UserID}/events": {
       "get": {
         "security": [
           {
             "Bearer": []
           }
         ],
-        "description": "Get messages, used by frontend. JWT token is required.",
-        "summary": "Get messages",
-        "operationId": "getMessages",
+        "produces": [
+          "application/json"
+        ],
         "parameters": [
           {
-            "type": "integer",
-            "default": 0,
-            "name": "offset",
-            "in": "query"
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "User events, the oldest first.",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/user_event"
+              }
+            }
           },
-          {
-            "type": "integer",
-            "default": 25,
-            "name": "limit",
-            "in": "query"
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
           },
-          {
-            "type": "boolean",
-            "name": "read",
-            "in": "query"
+          "404": {
+            "description": "User not found"
           },
-          {
-            "type": "integer",
-            "name": "priority",
-            "in": "query"
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
+            "schema": {
+              "$ref": "#/definitions/maintenance_error"
+            }
           },
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{UserID}/quota": {
+      "patch": {
+        "security": [
+          {
+            "Bearer": []
+          }
+        ],
+        "produces": [
+          "application/json"
+        ],
+        "parameters": [
           {
-            "enum": [
-              "asc",
-              "desc"
-            ],
             "type": "string",
-            "name": "sort-priority",
-            "in": "query"
+            "name": "UserID",
+            "in": "path",
+            "required": true
           },
           {
-            "enum": [
-              "asc",
-              "desc"
-            ],
             "type": "string",
-            "name": "sort-time",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "maximum": 17179869183,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota, 0 for the brigade default.",
+            "name": "MonthlyQuotaGB",
             "in": "query"
           },
           {
-            "enum": [
-              "eq",
-              "ne",
-              "gt",
-              "lt",
-              "ge",
-              "le"
-            ],
-            "type": "string",
-            "default": "eq",
-            "name": "priority-op",
+            "maximum": 31,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The monthly quota reset day, 0 for the 1st.",
+            "name": "MonthlyQuotaResetDay",
             "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "List of messages",
+            "description": "User quota set."
+          },
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "404": {
+            "description": "User not found"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
+          "500": {
+            "description": "Internal server error"
+          },
+          "503": {
+            "description": "Maintenance",
             "schema": {
-              "$ref": "#/definitions/Messages"
+              "$ref": "#/definitions/maintenance_error"
             }
           },
-          "500": {
-            "description": ""
+          "default": {
+            "description": "error",
+            "schema": {
+              "$ref": "#/definitions/error"
+            }
           }
         }
       }
     },
-    "/messages/{id}/read": {
-      "post": {
+    "/user/{UserID}/throttle": {
+      "patch": {
         "security": [
           {
             "Bearer": []
           }
         ],
-        "description": "Used by frontend. JWT token is required.",
-        "summary": "Mark message as read",
-        "operationId": "markMessageAsRead",
+        "produces": [
+          "application/json"
+        ],
         "parameters": [
           {
             "type": "string",
-            "format": "uuid",
-            "name": "id",
+            "name": "UserID",
             "in": "path",
             "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          },
+          {
+            "maximum": 744,
+            "minimum": 0,
+            "type": "integer",
+            "description": "The throttling period in hours, 0 lifts the brigadier throttling.",
+            "name": "Hours",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "OK"
+            "description": "User throttling set."
           },
-          "default": {
-            "description": "error",
-            "schema": {
-              "$ref": "#/definitions/error"
-            }
-          }
-        }
-      }
-    },
-    "/token": {
-      "post": {
-        "produces": [
-          "application/json"
-        ],
-        "responses": {
-          "201": {
-            "description": "Token created.",
-            "schema": {
-              "$ref": "#/definitions/token"
-            }
+          "403": {
+            "description": "You do not have necessary permissions for the resource"
+          },
+          "404": {
+            "description": "User not found"
+          },
+          "412": {
+            "description": "The brigade revision is stale"
           },
           "500": {
             "description": "Internal server error"
@@ -932,7 +1935,7 @@ func init() {
         }
       }
     },
-    "/user": {
+    "/user/{UserID}/traffic": {
       "get": {
         "security": [
           {
@@ -942,19 +1945,38 @@ func init() {
         "produces": [
           "application/json"
         ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "maximum": 90,
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of days up to today, 30 if absent.",
+            "name": "days",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "A list of users.",
+            "description": "User daily traffic, the oldest day first.",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/user"
+                "$ref": "#/definitions/traffic_day"
               }
             }
           },
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "404": {
+            "description": "User not found"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -971,8 +1993,10 @@ func init() {
             }
           }
         }
-      },
-      "post": {
+      }
+    },
+    "/user/{UserID}/unblock": {
+      "patch": {
         "security": [
           {
             "Bearer": []
@@ -981,16 +2005,30 @@ func init() {
         "produces": [
           "application/json"
         ],
+        "parameters": [
+          {
+            "type": "string",
+            "name": "UserID",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "string",
+            "description": "The brigade revision from the ETag, 400 if malformed.",
+            "name": "If-Match",
+            "in": "header"
+          }
+        ],
         "responses": {
-          "201": {
-            "description": "New user created.",
-            "schema": {
-              "$ref": "#/definitions/newuser"
-            }
+          "200": {
+            "description": "User unblocked."
           },
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "412": {
+            "description": "The brigade revision is stale"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -1009,8 +2047,8 @@ func init() {
         }
       }
     },
-    "/user/{UserID}": {
-      "delete": {
+    "/users/stats": {
+      "get": {
         "security": [
           {
             "Bearer": []
@@ -1019,17 +2057,12 @@ func init() {
         "produces": [
           "application/json"
         ],
-        "parameters": [
-          {
-            "type": "string",
-            "name": "UserID",
-            "in": "path",
-            "required": true
-          }
-        ],
         "responses": {
-          "204": {
-            "description": "User deleted."
+          "200": {
+            "description": "A list of stats.",
+            "schema": {
+              "$ref": "#/definitions/stats"
+            }
           },
           "403": {
             "description": "You do not have necessary permissions for the resource"
@@ -1052,8 +2085,8 @@ func init() {
         }
       }
     },
-    "/user/{UserID}/block": {
-      "patch": {
+    "/users/stats/geo": {
+      "get": {
         "security": [
           {
             "Bearer": []
@@ -1062,21 +2095,19 @@ func init() {
         "produces": [
           "application/json"
         ],
-        "parameters": [
-          {
-            "type": "string",
-            "name": "UserID",
-            "in": "path",
-            "required": true
-          }
-        ],
         "responses": {
           "200": {
-            "description": "User blocked."
+            "description": "Active users by countries and autonomous systems.",
+            "schema": {
+              "$ref": "#/definitions/geo_stats"
+            }
           },
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "404": {
+            "description": "No geo database or no stats yet"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -1095,8 +2126,8 @@ func init() {
         }
       }
     },
-    "/user/{UserID}/unblock": {
-      "patch": {
+    "/users/stats/refresh": {
+      "post": {
         "security": [
           {
             "Bearer": []
@@ -1105,21 +2136,19 @@ func init() {
         "produces": [
           "application/json"
         ],
-        "parameters": [
-          {
-            "type": "string",
-            "name": "UserID",
-            "in": "path",
-            "required": true
-          }
-        ],
         "responses": {
           "200": {
-            "description": "User unblocked."
+            "description": "The stats after the refresh.",
+            "schema": {
+              "$ref": "#/definitions/stats"
+            }
           },
           "403": {
             "description": "You do not have necessary permissions for the resource"
           },
+          "429": {
+            "description": "Too many refreshes, retry later"
+          },
           "500": {
             "description": "Internal server error"
           },
@@ -1138,7 +2167,7 @@ func init() {
         }
       }
     },
-    "/users/stats": {
+    "/users/stats/traffic": {
       "get": {
         "security": [
           {
@@ -1148,11 +2177,24 @@ func init() {
         "produces": [
           "application/json"
         ],
+        "parameters": [
+          {
+            "maximum": 90,
+            "minimum": 1,
+            "type": "integer",
+            "description": "The number of days up to today, 30 if absent.",
+            "name": "days",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "A list of stats.",
+            "description": "Brigade daily traffic, the oldest day first.",
             "schema": {
-              "$ref": "#/definitions/stats"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/traffic_day"
+              }
             }
           },
           "403": {
@@ -1374,6 +2416,78 @@ func init() {
         }
       }
     },
+    "geo_asn": {
+      "type": "object",
+      "required": [
+        "ASN",
+        "Users"
+      ],
+      "properties": {
+        "ASN": {
+          "description": "Autonomous system number",
+          "type": "integer"
+        },
+        "Org": {
+          "description": "Autonomous system organization",
+          "type": "string"
+        },
+        "Users": {
+          "description": "Active users from the autonomous system",
+          "type": "integer"
+        }
+      }
+    },
+    "geo_country": {
+      "type": "object",
+      "required": [
+        "Country",
+        "Users"
+      ],
+      "properties": {
+        "Country": {
+          "description": "ISO 3166-1 alpha-2 country code",
+          "type": "string"
+        },
+        "Users": {
+          "description": "Active users from the country",
+          "type": "integer"
+        }
+      }
+    },
+    "geo_stats": {
+      "type": "object",
+      "required": [
+        "UpdateTime",
+        "Countries",
+        "ASNs",
+        "Unknown"
+      ],
+      "properties": {
+        "ASNs": {
+          "description": "Active users by autonomous systems, the most users first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/geo_asn"
+          }
+        },
+        "Countries": {
+          "description": "Active users by countries, the most users first",
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/geo_country"
+          }
+        },
+        "Unknown": {
+          "description": "Active users without the location",
+          "type": "integer"
+        },
+        "UpdateTime": {
+          "description": "The stats collection time",
+          "type": "string",
+          "format": "date-time"
+        }
+      }
+    },
     "maintenance_error": {
       "type": "object",
       "required": [
@@ -1552,6 +2666,68 @@ func init() {
         }
       }
     },
+    "traffic_day": {
+      "type": "object",
+      "required": [
+        "Date",
+        "TotalRx",
+        "TotalTx"
+      ],
+      "properties": {
+        "Date": {
+          "type": "string",
+          "format": "date"
+        },
+        "IPSecRx": {
+          "description": "IPSec received bytes",
+          "type": "integer"
+        },
+        "IPSecTx": {
+          "description": "IPSec sent bytes",
+          "type": "integer"
+        },
+        "OpenVPNRx": {
+          "description": "OpenVPN over Cloak received bytes",
+          "type": "integer"
+        },
+        "OpenVPNTx": {
+          "description": "OpenVPN over Cloak sent bytes",
+          "type": "integer"
+        },
+        "OutlineRx": {
+          "description": "Outline received bytes",
+          "type": "integer"
+        },
+        "OutlineTx": {
+          "description": "Outline sent bytes",
+          "type": "integer"
+        },
+        "Proto0Rx": {
+          "description": "Protocol0 received bytes",
+          "type": "integer"
+        },
+        "Proto0Tx": {
+          "description": "Protocol0 sent bytes",
+          "type": "integer"
+        },
+        "TotalRx": {
+          "description": "All protocols received bytes",
+          "type": "integer"
+        },
+        "TotalTx": {
+          "description": "All protocols sent bytes",
+          "type": "integer"
+        },
+        "WireguardRx": {
+          "description": "Wireguard received bytes",
+          "type": "integer"
+        },
+        "WireguardTx": {
+          "description": "Wireguard sent bytes",
+          "type": "integer"
+        }
+      }
+    },
     "user": {
       "type": "object",
       "required": [
@@ -1583,10 +2759,19 @@ func init() {
           "format": "date-time",
           "x-nullable": true
         },
+        "MonthlyQuotaGB": {
+          "description": "The user monthly quota, absent for the brigade default",
+          "type": "integer"
+        },
         "MonthlyQuotaRemainingGB": {
           "type": "number",
           "format": "float"
         },
+        "MonthlyQuotaResetOn": {
+          "type": "string",
+          "format": "date-time",
+          "x-nullable": true
+        },
         "MonthlyTraffic": {
           "type": "number",
           "format": "integer"
@@ -1631,6 +2816,27 @@ func init() {
           "format": "integer"
         }
       }
+    },
+    "user_event": {
+      "type": "object",
+      "required": [
+        "Kind",
+        "Time"
+      ],
+      "properties": {
+        "Detail": {
+          "description": "The protocol for first_connect",
+          "type": "string"
+        },
+        "Kind": {
+          "description": "created, blocked, unblocked, throttled, throttle_lifted, quota_reset, quota_changed, config_reissued, first_connect",
+          "type": "string"
+        },
+        "Time": {
+          "type": "string",
+          "format": "date-time"
+        }
+      }
     }
   },
   "securityDefinitions": {
@@ -63,6 +63,31 @@ func (o *DeleteUserUserIDForbidden) WriteResponse(rw http.ResponseWriter, produc
 	rw.WriteHeader(403)
 }
 
+// DeleteUserUserIDPreconditionFailedCode is the HTTP code returned for type DeleteUserUserIDPreconditionFailed
+const DeleteUserUserIDPreconditionFailedCode int = 412
+
+/*
+DeleteUserUserIDPreconditionFailed The brigade revision is stale
+
+swagger:response deleteUserUserIdPreconditionFailed
+*/
+type DeleteUserUserIDPreconditionFailed struct {
+}
+
+// NewDeleteUserUserIDPreconditionFailed creates DeleteUserUserIDPreconditionFailed with default headers values
+func NewDeleteUserUserIDPreconditionFailed() *DeleteUserUserIDPreconditionFailed {
+
+	return &DeleteUserUserIDPreconditionFailed{}
+}
+
+// WriteResponse to the client
+func (o *DeleteUserUserIDPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(412)
+}
+
 // DeleteUserUserIDInternalServerErrorCode is the HTTP code returned for type DeleteUserUserIDInternalServerError
 const DeleteUserUserIDInternalServerErrorCode int = 500
 
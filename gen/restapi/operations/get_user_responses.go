@@ -22,6 +22,10 @@ GetUserOK A list of users.
 swagger:response getUserOK
 */
 type GetUserOK struct {
+	/*The brigade revision.
+
+	 */
+	ETag string `json:"ETag"`
 
 	/*
 	  In: Body
@@ -35,6 +39,17 @@ func NewGetUserOK() *GetUserOK {
 	return &GetUserOK{}
 }
 
+// WithETag adds the eTag to the get user o k response
+func (o *GetUserOK) WithETag(eTag string) *GetUserOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the get user o k response
+func (o *GetUserOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the get user o k response
 func (o *GetUserOK) WithPayload(payload []*models.User) *GetUserOK {
 	o.Payload = payload
@@ -49,6 +64,13 @@ func (o *GetUserOK) SetPayload(payload []*models.User) {
 // WriteResponse to the client
 func (o *GetUserOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if payload == nil {
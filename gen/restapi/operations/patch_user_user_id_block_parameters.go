@@ -30,6 +30,11 @@ type PatchUserUserIDBlockParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*The brigade revision from the ETag, 400 if malformed.
+	  In: header
+	*/
+	IfMatch *string
+
 	/*
 	  Required: true
 	  In: path
@@ -46,6 +51,10 @@ func (o *PatchUserUserIDBlockParams) BindRequest(r *http.Request, route *middlew
 
 	o.HTTPRequest = r
 
+	if err := o.bindIfMatch(r.Header[http.CanonicalHeaderKey("If-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	rUserID, rhkUserID, _ := route.Params.GetOK("UserID")
 	if err := o.bindUserID(rUserID, rhkUserID, route.Formats); err != nil {
 		res = append(res, err)
@@ -56,6 +65,23 @@ func (o *PatchUserUserIDBlockParams) BindRequest(r *http.Request, route *middlew
 	return nil
 }
 
+// bindIfMatch binds and validates parameter IfMatch from header.
+func (o *PatchUserUserIDBlockParams) bindIfMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfMatch = &raw
+
+	return nil
+}
+
 // bindUserID binds and validates parameter UserID from path.
 func (o *PatchUserUserIDBlockParams) bindUserID(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
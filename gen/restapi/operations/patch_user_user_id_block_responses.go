@@ -63,6 +63,31 @@ func (o *PatchUserUserIDBlockForbidden) WriteResponse(rw http.ResponseWriter, pr
 	rw.WriteHeader(403)
 }
 
+// PatchUserUserIDBlockPreconditionFailedCode is the HTTP code returned for type PatchUserUserIDBlockPreconditionFailed
+const PatchUserUserIDBlockPreconditionFailedCode int = 412
+
+/*
+PatchUserUserIDBlockPreconditionFailed The brigade revision is stale
+
+swagger:response patchUserUserIdBlockPreconditionFailed
+*/
+type PatchUserUserIDBlockPreconditionFailed struct {
+}
+
+// NewPatchUserUserIDBlockPreconditionFailed creates PatchUserUserIDBlockPreconditionFailed with default headers values
+func NewPatchUserUserIDBlockPreconditionFailed() *PatchUserUserIDBlockPreconditionFailed {
+
+	return &PatchUserUserIDBlockPreconditionFailed{}
+}
+
+// WriteResponse to the client
+func (o *PatchUserUserIDBlockPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(412)
+}
+
 // PatchUserUserIDBlockInternalServerErrorCode is the HTTP code returned for type PatchUserUserIDBlockInternalServerError
 const PatchUserUserIDBlockInternalServerErrorCode int = 500
 
@@ -63,6 +63,31 @@ func (o *PatchUserUserIDUnblockForbidden) WriteResponse(rw http.ResponseWriter,
 	rw.WriteHeader(403)
 }
 
+// PatchUserUserIDUnblockPreconditionFailedCode is the HTTP code returned for type PatchUserUserIDUnblockPreconditionFailed
+const PatchUserUserIDUnblockPreconditionFailedCode int = 412
+
+/*
+PatchUserUserIDUnblockPreconditionFailed The brigade revision is stale
+
+swagger:response patchUserUserIdUnblockPreconditionFailed
+*/
+type PatchUserUserIDUnblockPreconditionFailed struct {
+}
+
+// NewPatchUserUserIDUnblockPreconditionFailed creates PatchUserUserIDUnblockPreconditionFailed with default headers values
+func NewPatchUserUserIDUnblockPreconditionFailed() *PatchUserUserIDUnblockPreconditionFailed {
+
+	return &PatchUserUserIDUnblockPreconditionFailed{}
+}
+
+// WriteResponse to the client
+func (o *PatchUserUserIDUnblockPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(412)
+}
+
 // PatchUserUserIDUnblockInternalServerErrorCode is the HTTP code returned for type PatchUserUserIDUnblockInternalServerError
 const PatchUserUserIDUnblockInternalServerErrorCode int = 500
 
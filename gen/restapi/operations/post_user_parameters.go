@@ -9,7 +9,11 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
+	"github.com/go-openapi/validate"
 )
 
 // NewPostUserParams creates a new PostUserParams object
@@ -28,6 +32,24 @@ type PostUserParams struct {
 
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
+
+	/*The brigade revision from the ETag, 400 if malformed.
+	  In: header
+	*/
+	IfMatch *string
+
+	/*The monthly quota, 0 for the brigade default.
+	  Minimum: 0
+	  In: query
+	*/
+	MonthlyQuotaGB *int64
+
+	/*The monthly quota reset day, 0 for the 1st.
+	  Maximum: 31
+	  Minimum: 0
+	  In: query
+	*/
+	MonthlyQuotaResetDay *int64
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -39,8 +61,123 @@ func (o *PostUserParams) BindRequest(r *http.Request, route *middleware.MatchedR
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	if err := o.bindIfMatch(r.Header[http.CanonicalHeaderKey("If-Match")], true, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qMonthlyQuotaGB, qhkMonthlyQuotaGB, _ := qs.GetOK("MonthlyQuotaGB")
+	if err := o.bindMonthlyQuotaGB(qMonthlyQuotaGB, qhkMonthlyQuotaGB, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qMonthlyQuotaResetDay, qhkMonthlyQuotaResetDay, _ := qs.GetOK("MonthlyQuotaResetDay")
+	if err := o.bindMonthlyQuotaResetDay(qMonthlyQuotaResetDay, qhkMonthlyQuotaResetDay, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindIfMatch binds and validates parameter IfMatch from header.
+func (o *PostUserParams) bindIfMatch(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.IfMatch = &raw
+
+	return nil
+}
+
+// bindMonthlyQuotaGB binds and validates parameter MonthlyQuotaGB from query.
+func (o *PostUserParams) bindMonthlyQuotaGB(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("MonthlyQuotaGB", "query", "int64", raw)
+	}
+	o.MonthlyQuotaGB = &value
+
+	if err := o.validateMonthlyQuotaGB(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMonthlyQuotaGB carries out validations for parameter MonthlyQuotaGB
+func (o *PostUserParams) validateMonthlyQuotaGB(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("MonthlyQuotaGB", "query", *o.MonthlyQuotaGB, 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("MonthlyQuotaGB", "query", *o.MonthlyQuotaGB, 17179869183, false); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// bindMonthlyQuotaResetDay binds and validates parameter MonthlyQuotaResetDay from query.
+func (o *PostUserParams) bindMonthlyQuotaResetDay(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertInt64(raw)
+	if err != nil {
+		return errors.InvalidType("MonthlyQuotaResetDay", "query", "int64", raw)
+	}
+	o.MonthlyQuotaResetDay = &value
+
+	if err := o.validateMonthlyQuotaResetDay(formats); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateMonthlyQuotaResetDay carries out validations for parameter MonthlyQuotaResetDay
+func (o *PostUserParams) validateMonthlyQuotaResetDay(formats strfmt.Registry) error {
+
+	if err := validate.MinimumInt("MonthlyQuotaResetDay", "query", *o.MonthlyQuotaResetDay, 0, false); err != nil {
+		return err
+	}
+
+	if err := validate.MaximumInt("MonthlyQuotaResetDay", "query", *o.MonthlyQuotaResetDay, 31, false); err != nil {
+		return err
+	}
+
+	return nil
+}
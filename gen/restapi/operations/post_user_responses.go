@@ -83,6 +83,31 @@ func (o *PostUserForbidden) WriteResponse(rw http.ResponseWriter, producer runti
 	rw.WriteHeader(403)
 }
 
+// PostUserPreconditionFailedCode is the HTTP code returned for type PostUserPreconditionFailed
+const PostUserPreconditionFailedCode int = 412
+
+/*
+PostUserPreconditionFailed The brigade revision is stale
+
+swagger:response postUserPreconditionFailed
+*/
+type PostUserPreconditionFailed struct {
+}
+
+// NewPostUserPreconditionFailed creates PostUserPreconditionFailed with default headers values
+func NewPostUserPreconditionFailed() *PostUserPreconditionFailed {
+
+	return &PostUserPreconditionFailed{}
+}
+
+// WriteResponse to the client
+func (o *PostUserPreconditionFailed) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(412)
+}
+
 // PostUserInternalServerErrorCode is the HTTP code returned for type PostUserInternalServerError
 const PostUserInternalServerErrorCode int = 500
 
@@ -9,11 +9,18 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // PostUserURL generates an URL for the post user operation
 type PostUserURL struct {
+	MonthlyQuotaGB       *int64
+	MonthlyQuotaResetDay *int64
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -43,6 +50,26 @@ func (o *PostUserURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var monthlyQuotaGBQ string
+	if o.MonthlyQuotaGB != nil {
+		monthlyQuotaGBQ = swag.FormatInt64(*o.MonthlyQuotaGB)
+	}
+	if monthlyQuotaGBQ != "" {
+		qs.Set("MonthlyQuotaGB", monthlyQuotaGBQ)
+	}
+
+	var monthlyQuotaResetDayQ string
+	if o.MonthlyQuotaResetDay != nil {
+		monthlyQuotaResetDayQ = swag.FormatInt64(*o.MonthlyQuotaResetDay)
+	}
+	if monthlyQuotaResetDayQ != "" {
+		qs.Set("MonthlyQuotaResetDay", monthlyQuotaResetDayQ)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -48,12 +48,30 @@ func NewUserAPI(spec *loads.Document) *UserAPI {
 		GetUserHandler: GetUserHandlerFunc(func(params GetUserParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation GetUser has not yet been implemented")
 		}),
+		GetUserUserIDEventsHandler: GetUserUserIDEventsHandlerFunc(func(params GetUserUserIDEventsParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetUserUserIDEvents has not yet been implemented")
+		}),
+		GetUserUserIDTrafficHandler: GetUserUserIDTrafficHandlerFunc(func(params GetUserUserIDTrafficParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetUserUserIDTraffic has not yet been implemented")
+		}),
 		GetUsersStatsHandler: GetUsersStatsHandlerFunc(func(params GetUsersStatsParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation GetUsersStats has not yet been implemented")
 		}),
+		GetUsersStatsGeoHandler: GetUsersStatsGeoHandlerFunc(func(params GetUsersStatsGeoParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetUsersStatsGeo has not yet been implemented")
+		}),
+		GetUsersStatsTrafficHandler: GetUsersStatsTrafficHandlerFunc(func(params GetUsersStatsTrafficParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation GetUsersStatsTraffic has not yet been implemented")
+		}),
 		PatchUserUserIDBlockHandler: PatchUserUserIDBlockHandlerFunc(func(params PatchUserUserIDBlockParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation PatchUserUserIDBlock has not yet been implemented")
 		}),
+		PatchUserUserIDQuotaHandler: PatchUserUserIDQuotaHandlerFunc(func(params PatchUserUserIDQuotaParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation PatchUserUserIDQuota has not yet been implemented")
+		}),
+		PatchUserUserIDThrottleHandler: PatchUserUserIDThrottleHandlerFunc(func(params PatchUserUserIDThrottleParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation PatchUserUserIDThrottle has not yet been implemented")
+		}),
 		PatchUserUserIDUnblockHandler: PatchUserUserIDUnblockHandlerFunc(func(params PatchUserUserIDUnblockParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation PatchUserUserIDUnblock has not yet been implemented")
 		}),
@@ -63,6 +81,9 @@ func NewUserAPI(spec *loads.Document) *UserAPI {
 		PostUserHandler: PostUserHandlerFunc(func(params PostUserParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation PostUser has not yet been implemented")
 		}),
+		PostUsersStatsRefreshHandler: PostUsersStatsRefreshHandlerFunc(func(params PostUsersStatsRefreshParams, principal interface{}) middleware.Responder {
+			return middleware.NotImplemented("operation PostUsersStatsRefresh has not yet been implemented")
+		}),
 		GetMessagesHandler: GetMessagesHandlerFunc(func(params GetMessagesParams, principal interface{}) middleware.Responder {
 			return middleware.NotImplemented("operation GetMessages has not yet been implemented")
 		}),
@@ -123,16 +144,30 @@ type UserAPI struct {
 	DeleteUserUserIDHandler DeleteUserUserIDHandler
 	// GetUserHandler sets the operation handler for the get user operation
 	GetUserHandler GetUserHandler
+	// GetUserUserIDEventsHandler sets the operation handler for the get user user ID events operation
+	GetUserUserIDEventsHandler GetUserUserIDEventsHandler
+	// GetUserUserIDTrafficHandler sets the operation handler for the get user user ID traffic operation
+	GetUserUserIDTrafficHandler GetUserUserIDTrafficHandler
 	// GetUsersStatsHandler sets the operation handler for the get users stats operation
 	GetUsersStatsHandler GetUsersStatsHandler
+	// GetUsersStatsGeoHandler sets the operation handler for the get users stats geo operation
+	GetUsersStatsGeoHandler GetUsersStatsGeoHandler
+	// GetUsersStatsTrafficHandler sets the operation handler for the get users stats traffic operation
+	GetUsersStatsTrafficHandler GetUsersStatsTrafficHandler
 	// PatchUserUserIDBlockHandler sets the operation handler for the patch user user ID block operation
 	PatchUserUserIDBlockHandler PatchUserUserIDBlockHandler
+	// PatchUserUserIDQuotaHandler sets the operation handler for the patch user user ID quota operation
+	PatchUserUserIDQuotaHandler PatchUserUserIDQuotaHandler
+	// PatchUserUserIDThrottleHandler sets the operation handler for the patch user user ID throttle operation
+	PatchUserUserIDThrottleHandler PatchUserUserIDThrottleHandler
 	// PatchUserUserIDUnblockHandler sets the operation handler for the patch user user ID unblock operation
 	PatchUserUserIDUnblockHandler PatchUserUserIDUnblockHandler
 	// PostTokenHandler sets the operation handler for the post token operation
 	PostTokenHandler PostTokenHandler
 	// PostUserHandler sets the operation handler for the post user operation
 	PostUserHandler PostUserHandler
+	// PostUsersStatsRefreshHandler sets the operation handler for the post users stats refresh operation
+	PostUsersStatsRefreshHandler PostUsersStatsRefreshHandler
 	// GetMessagesHandler sets the operation handler for the get messages operation
 	GetMessagesHandler GetMessagesHandler
 	// MarkMessageAsReadHandler sets the operation handler for the mark message as read operation
@@ -224,12 +259,30 @@ func (o *UserAPI) Validate() error {
 	if o.GetUserHandler == nil {
 		unregistered = append(unregistered, "GetUserHandler")
 	}
+	if o.GetUserUserIDEventsHandler == nil {
+		unregistered = append(unregistered, "GetUserUserIDEventsHandler")
+	}
+	if o.GetUserUserIDTrafficHandler == nil {
+		unregistered = append(unregistered, "GetUserUserIDTrafficHandler")
+	}
 	if o.GetUsersStatsHandler == nil {
 		unregistered = append(unregistered, "GetUsersStatsHandler")
 	}
+	if o.GetUsersStatsGeoHandler == nil {
+		unregistered = append(unregistered, "GetUsersStatsGeoHandler")
+	}
+	if o.GetUsersStatsTrafficHandler == nil {
+		unregistered = append(unregistered, "GetUsersStatsTrafficHandler")
+	}
 	if o.PatchUserUserIDBlockHandler == nil {
 		unregistered = append(unregistered, "PatchUserUserIDBlockHandler")
 	}
+	if o.PatchUserUserIDQuotaHandler == nil {
+		unregistered = append(unregistered, "PatchUserUserIDQuotaHandler")
+	}
+	if o.PatchUserUserIDThrottleHandler == nil {
+		unregistered = append(unregistered, "PatchUserUserIDThrottleHandler")
+	}
 	if o.PatchUserUserIDUnblockHandler == nil {
 		unregistered = append(unregistered, "PatchUserUserIDUnblockHandler")
 	}
@@ -239,6 +292,9 @@ func (o *UserAPI) Validate() error {
 	if o.PostUserHandler == nil {
 		unregistered = append(unregistered, "PostUserHandler")
 	}
+	if o.PostUsersStatsRefreshHandler == nil {
+		unregistered = append(unregistered, "PostUsersStatsRefreshHandler")
+	}
 	if o.GetMessagesHandler == nil {
 		unregistered = append(unregistered, "GetMessagesHandler")
 	}
@@ -353,7 +409,23 @@ func (o *UserAPI) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/user/{UserID}/events"] = NewGetUserUserIDEvents(o.context, o.GetUserUserIDEventsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/user/{UserID}/traffic"] = NewGetUserUserIDTraffic(o.context, o.GetUserUserIDTrafficHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/users/stats"] = NewGetUsersStats(o.context, o.GetUsersStatsHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/users/stats/geo"] = NewGetUsersStatsGeo(o.context, o.GetUsersStatsGeoHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/users/stats/traffic"] = NewGetUsersStatsTraffic(o.context, o.GetUsersStatsTrafficHandler)
 	if o.handlers["PATCH"] == nil {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
@@ -361,6 +433,14 @@ func (o *UserAPI) initHandlerCache() {
 	if o.handlers["PATCH"] == nil {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
+	o.handlers["PATCH"]["/user/{UserID}/quota"] = NewPatchUserUserIDQuota(o.context, o.PatchUserUserIDQuotaHandler)
+	if o.handlers["PATCH"] == nil {
+		o.handlers["PATCH"] = make(map[string]http.Handler)
+	}
+	o.handlers["PATCH"]["/user/{UserID}/throttle"] = NewPatchUserUserIDThrottle(o.context, o.PatchUserUserIDThrottleHandler)
+	if o.handlers["PATCH"] == nil {
+		o.handlers["PATCH"] = make(map[string]http.Handler)
+	}
 	o.handlers["PATCH"]["/user/{UserID}/unblock"] = NewPatchUserUserIDUnblock(o.context, o.PatchUserUserIDUnblockHandler)
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
@@ -370,6 +450,10 @@ func (o *UserAPI) initHandlerCache() {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/user"] = NewPostUser(o.context, o.PostUserHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/users/stats/refresh"] = NewPostUsersStatsRefresh(o.context, o.PostUsersStatsRefreshHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -109,6 +109,9 @@ type ClientInterface interface {
 
 	// GetSlots request
 	GetSlots(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostStatsRefresh request
+	PostStatsRefresh(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetActivity(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -195,6 +198,18 @@ func (c *Client) GetSlots(ctx context.Context, reqEditors ...RequestEditorFn) (*
 	return c.Client.Do(req)
 }
 
+func (c *Client) PostStatsRefresh(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostStatsRefreshRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetActivityRequest generates requests for GetActivity
 func NewGetActivityRequest(server string) (*http.Request, error) {
 	var err error
@@ -391,6 +406,33 @@ func NewGetSlotsRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewPostStatsRefreshRequest generates requests for PostStatsRefresh
+func NewPostStatsRefreshRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/stats/refresh")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -453,6 +495,9 @@ type ClientWithResponsesInterface interface {
 
 	// GetSlotsWithResponse request
 	GetSlotsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetSlotsResponse, error)
+
+	// PostStatsRefreshWithResponse request
+	PostStatsRefreshWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostStatsRefreshResponse, error)
 }
 
 type GetActivityResponse struct {
@@ -610,6 +655,29 @@ func (r GetSlotsResponse) StatusCode() int {
 	return 0
 }
 
+type PostStatsRefreshResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Activities
+	JSONDefault  *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r PostStatsRefreshResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostStatsRefreshResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetActivityWithResponse request returning *GetActivityResponse
 func (c *ClientWithResponses) GetActivityWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetActivityResponse, error) {
 	rsp, err := c.GetActivity(ctx, reqEditors...)
@@ -672,6 +740,15 @@ func (c *ClientWithResponses) GetSlotsWithResponse(ctx context.Context, reqEdito
 	return ParseGetSlotsResponse(rsp)
 }
 
+// PostStatsRefreshWithResponse request returning *PostStatsRefreshResponse
+func (c *ClientWithResponses) PostStatsRefreshWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*PostStatsRefreshResponse, error) {
+	rsp, err := c.PostStatsRefresh(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostStatsRefreshResponse(rsp)
+}
+
 // ParseGetActivityResponse parses an HTTP response from a GetActivityWithResponse call
 func ParseGetActivityResponse(rsp *http.Response) (*GetActivityResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -886,3 +963,36 @@ func ParseGetSlotsResponse(rsp *http.Response) (*GetSlotsResponse, error) {
 
 	return response, nil
 }
+
+// ParsePostStatsRefreshResponse parses an HTTP response from a PostStatsRefreshWithResponse call
+func ParsePostStatsRefreshResponse(rsp *http.Response) (*PostStatsRefreshResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostStatsRefreshResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Activities
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
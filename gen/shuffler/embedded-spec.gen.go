@@ -18,26 +18,28 @@ import (
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xX227bOBN+FYL/f6lY7mF3Ad+1ybZwUWSNdQ4XQWDQ1MhmLZEqOXSqDfTuC1KyrWMc",
-	"Y5tFF+idPBwOZ7755uBHylWaKQkSDZ08Ug0mU9KA//G71kr/WUmcgCuJINF9sixLBGcolAy/GCWdzPA1",
-	"pMx9/V9DTCf0f+HBeliemtBbpUVRBDQCw7XInBE6obuDoDLkfXjHUWwFitIjFkXCabNkplUGeid/6r3K",
-	"Qk6LgH47W6kzzDOgE2oN6FHNfO30TKSZ0j7OjOGaTuhK4NouR1yl4TaTK5DhBvIIzCYUEkFLloTOnvd+",
-	"/6C73nAzYiLJF6hZHAvuBLHSKUM6oULir29pQCvfnNGVMxfQhBlcGADZ0I8YwhmKFA53DGohV+5KqiSu",
-	"T34o07BdROzUa6iQJSfesZnzP3p+RA8Am5MDyoHpEy8VAdXw1QrtnLurYX/w+X5/Sy2/AEc6zKr8O3Iq",
-	"lfCXYH/cnJ8rGYtVl1uxSGBRK9AOhl5BshR6T9FKCcnQeQuXunLdcND04r4IqqLue3E6mwP//PpqNhRR",
-	"xox5UDrqvZ2ZTa/cgN5C/4MOzOfFt9cMDk7sTZdvd2hQBHSmFarxUDiMczBmsYH8uAM1XQfiPFFopjJW",
-	"PWnXAAvjzmtWO+U5qNB6t2atebUv3JvZ5WCsJV2PtuY2q4uAiswAP3axzZ0ioMpiImQ/uTOfmGNGG+kr",
-	"Arpd8V5rD0LDyrKSmE8ZvBUaPjrFnc2iB8W20n+jsH2lcasF5nMXbenqp9urd9b1tke6BKZBf9g13E+3",
-	"V7Sa6+758vTQgNeIWbkSiIrlzdVgvrZxnIAmKRjDVkDezabutsAEho+3oE15/9VoPBp7lmQgWSbohL4Z",
-	"jUdvfIHj2jsfstrcXoHH2uXB7zjTiE7oR8BaZ2+sSq/H4++2INV2kp4t6TMzSLiSEriTkGVOyinhFGNm",
-	"Exyyv3c4bC529WTSyV0tjXf3xX1AjU1TpvMSAHIzu/QPGrLHyxkIuadv2biV6UFvpgyeV0ol68DgexXl",
-	"JwEH31iaJZ7KkUqZcJBUMjdJD5Ta9YOy/Gv1VHNUIKSmv2pKAdOa+cVx91abmBdeTmKlazkJiIiJSgUi",
-	"RKRKCiktEGF2Xyom1bzvbjytsty53O3CTU3UFooOM1+dBPAgWE9R9jAKGlh1W1VjWjWhvLTpErSDxWl5",
-	"nnlNwmIETbgG73Lvqieau6S1IupbI4ebZHNKNh27codE7t3be3Z8gfRu8D3pq15aARScMm67jeASHrwr",
-	"pfmAWCm+WiDTC8cxDWi1hMgF98v4tx60VQvml2kgu+AnPn1AWw3l3EuJbITS6Cfho4iK0v0EELpt5cLL",
-	"q8YyjXxH1ywFBG28M75F+JV7R4AyLc2yCWolcIRHLoR/1PxP2eCGF7TncGRu/SIZ2yTJSYmgp8Tb8dsu",
-	"Ja4NaCIVklhZGb0wH6p0tvhQJvNJLoTLRPFN9TeKr3vmjBPv+fDea/8kxRApPJo/Nil8Cp/mhJWnseK6",
-	"0v/JiyFeVIj+2My4lr3U2OMztMXPq5H7Yiv84Q97D84f/oW527O4t8d9URR/BwAA//8buNV8/hUAAA==",
+	"H4sIAAAAAAAC/+xY32/buA//VwR9v49unf24O1zetu42dBh6wdJtD0NRKDadaJUlj6LS+Qr/7wfJdmIn",
+	"drPg1mED9pZSJEV++BFJ944nJi+MBk2WT+84gi2MthD++AvR4NtG4gWJ0QSa/E9RFEomgqTR8SdrtJfZ",
+	"ZAW58L/+j5DxKf9fvPUe16c2Dl55VVURT8EmKAvvhE95exA1jkIMzxKSa0myjkikqfTaQs3QFICt/L77",
+	"Gg8lryL+5WRpTqgsgE+5s4CnHfed0xOZFwZDnoWgFZ/ypaSVW5wmJo/XhV6Cjm+gTMHexFIToBYq9v5C",
+	"9JsLvXkvzFRIVV4TiiyTiRdkBnNBfMqlpt+f8og3sXmnS+8u4kpYurYAuqefCoITkjlsbSyh1EtvkhtN",
+	"q6MvKhDW16k41owMCXWkjSt8/OnXZ3QLcHN0QiUIPNKoijjCZyfRB/exg/025quNlVl8goT4OKvKb8ip",
+	"XMM/Uvz9/uzM6Ewu97mVSQXXnQe6h2FQ0CKHwVNyWoMaO9/BpavcdRz1o7iqouZRD914PptD8ubx5Wws",
+	"o0JYe2swHbQu7M2g3AKuYfhCD+bX5bfRjLZBbFzXd+/RoIr4DA2ZyVg6IknA2usbKA8H0NH1IM6VIXuu",
+	"MzNQdgS4tv6843XveY4q7Nzb8dY3HUr3/exiNNeargdb8y6rq4jLwkJyyHCXO1XEjSMl9TC5i1CYQ057",
+	"5asivl4mg95uJcLSCUwPOfwgEV55xdZnNYDirtLP8bDDS0scSirnPts61NcfLp8539vu+AIEAr5sG+7r",
+	"D5e8mev++vp024BXREW9EsiG5f3VYL5yWaYAWQ7WiiWwZ7Nzby1JwfjxGtDW9o9OJ6eTwJICtCgkn/In",
+	"p5PTJ+GB0yoEH4vO3F5CwNrXIew45ymf8ldAnc7eW5UeTybfbEHq7CQDW9IbYYklRmtIvIQtSlZPCa+Y",
+	"CadozP8m4Li/2HWLyacfO2X8eFVdRdy6PBdY1gCw97OLcKFlG7y8gzgJ9K0bt7ED6M2MpbNGqWYdWHpu",
+	"0vIo4OCLyAsVqJyaXEjNp63MT9Itpdp+UEU776kTqCTI7fCrqQUCUYTFsb1rl5gvgpxlBjs1iZjMmMkl",
+	"EaSsKQqrPTBp218mY82859GBZ9mGvN+F+5qEDqo9Zj46CuBRsO6j7HYU9LDab1W9adWH8sLlC0APi9cK",
+	"PAuaTGQEyBKEEPLgqif7u6RzMh1aI8ebZH9K9gO79IdMb8LbRHZ4gQxhJBvSN720ASg6ZtzuN4ILuA2h",
+	"1O4j5rT87ICdv/AcQyCHGlKf3G+TPwbQNjswP0wDaZOfhvIB32koZ0HKdC+VXj+J72Ra1eErINhvKy+C",
+	"vGks52no6ChyIEAbggktIqzcLQHqsvSfTdR5Agd45FP4T83/mA1ufEH7Go7MXVgkM6dUyWoEAyWeTp7u",
+	"U+KdBWTaEMuM0+kD86Ep5w4f6mLey4V4oUxy03xGJauBOePFGz48D9q/SDFGioDmj02KUML7OeH0cax4",
+	"pxe/eHEvL5z+CZjxTg9SY4PP2BY/b0bug63w2w/2AZxffoe5O7C47477gBSJ4D9DsKvu6t6P+MwoBQkx",
+	"WgELJixDk4c/QaeFkZqYNrdRkCRCKcsEAkP/Lz0lc+mnTjTwOTD3vt42t/9oX1TN3ulTagHyT+Hxn0Mb",
+	"omG50GWrCDbyGxiWTAn6Tt9mDYwh3u03WqiWT7r6dwA2gPlF6hcAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
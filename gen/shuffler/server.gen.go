@@ -35,6 +35,9 @@ type ServerInterface interface {
 	// Get free VPN slots
 	// (GET /slots)
 	GetSlots(ctx echo.Context) error
+	// Refresh the VPN users stats
+	// (POST /stats/refresh)
+	PostStatsRefresh(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -129,6 +132,17 @@ func (w *ServerInterfaceWrapper) GetSlots(ctx echo.Context) error {
 	return err
 }
 
+// PostStatsRefresh converts echo context to params.
+func (w *ServerInterfaceWrapper) PostStatsRefresh(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JWTAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostStatsRefresh(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -163,6 +177,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.PATCH(baseURL+"/configs/:id/block", wrapper.PatchConfigsIdBlock)
 	router.PATCH(baseURL+"/configs/:id/unblock", wrapper.PatchConfigsIdUnblock)
 	router.GET(baseURL+"/slots", wrapper.GetSlots)
+	router.POST(baseURL+"/stats/refresh", wrapper.PostStatsRefresh)
 
 }
 
@@ -389,6 +404,42 @@ func (response GetSlotsdefaultJSONResponse) VisitGetSlotsResponse(w http.Respons
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type PostStatsRefreshRequestObject struct {
+}
+
+type PostStatsRefreshResponseObject interface {
+	VisitPostStatsRefreshResponse(w http.ResponseWriter) error
+}
+
+type PostStatsRefresh200JSONResponse Activities
+
+func (response PostStatsRefresh200JSONResponse) VisitPostStatsRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostStatsRefresh429Response struct {
+}
+
+func (response PostStatsRefresh429Response) VisitPostStatsRefreshResponse(w http.ResponseWriter) error {
+	w.WriteHeader(429)
+	return nil
+}
+
+type PostStatsRefreshdefaultJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response PostStatsRefreshdefaultJSONResponse) VisitPostStatsRefreshResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Get VPN users activity
@@ -409,6 +460,9 @@ type StrictServerInterface interface {
 	// Get free VPN slots
 	// (GET /slots)
 	GetSlots(ctx context.Context, request GetSlotsRequestObject) (GetSlotsResponseObject, error)
+	// Refresh the VPN users stats
+	// (POST /stats/refresh)
+	PostStatsRefresh(ctx context.Context, request PostStatsRefreshRequestObject) (PostStatsRefreshResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -572,3 +626,26 @@ func (sh *strictHandler) GetSlots(ctx echo.Context) error {
 	}
 	return nil
 }
+
+// PostStatsRefresh operation middleware
+func (sh *strictHandler) PostStatsRefresh(ctx echo.Context) error {
+	var request PostStatsRefreshRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostStatsRefresh(ctx.Request().Context(), request.(PostStatsRefreshRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostStatsRefresh")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostStatsRefreshResponseObject); ok {
+		return validResponse.VisitPostStatsRefreshResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
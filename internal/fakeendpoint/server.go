@@ -0,0 +1,425 @@
+// Package fakeendpoint - the endpoint API stand-in with the in-memory state
+// for the integration tests and the development.
+package fakeendpoint
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib"
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+// The endpoint API calls.
+const (
+	CallWgAdd       = "wg_add"
+	CallWgDel       = "wg_del"
+	CallPeerAdd     = "peer_add"
+	CallPeerDel     = "peer_del"
+	CallThrottleOn  = "throttle_on"
+	CallThrottleOff = "throttle_off"
+	CallPeerList    = "peer_list"
+	CallStat        = "stat"
+)
+
+var calls = []string{CallWgAdd, CallWgDel, CallPeerAdd, CallPeerDel, CallThrottleOn, CallThrottleOff, CallPeerList, CallStat}
+
+// The endpoint API result codes the fake responds with.
+const (
+	CodeOK               = "0"
+	CodeBadRequest       = "1"   // unknown call, bad key or parameter, unknown peer; the fake own code, not in the client catalogue
+	CodeInterfaceMissing = "128" // wg_del of the absent interface
+	CodeDelInProgress    = "146" // wg_del is in progress, by the fault injection only
+	CodePeerExists       = "151" // peer_add of the existing peer
+)
+
+const (
+	// DefaultTrafficStep - the peer traffic growth per stat call, bytes.
+	DefaultTrafficStep = 1 << 20
+	// PeerSubnet - the subnet every peer is seen from.
+	PeerSubnet = "203.0.113.0/24"
+	// statProto - the protocol of the peers traffic.
+	statProto = "wireguard"
+)
+
+// Fault - the injected call failure.
+type Fault struct {
+	Latency time.Duration // the response delay
+	Hang    bool          // no response till the client gives up
+	Status  int           // the HTTP status instead of 200, 0 - none
+	Code    string        // the API code instead of the call result, empty - none
+	Times   int           // the faulty calls number, 0 - till it is cleared
+}
+
+// Interface - the brigade interface.
+type Interface struct {
+	ExternalIP    string
+	WireguardPort string
+	InternalNets  string
+}
+
+// Peer - the user peer with the peer_add parameters.
+type Peer struct {
+	vpnapi.PeerInfo
+	Throttled bool
+	Rx, Tx    uint64
+	LastSeen  time.Time
+}
+
+// Server - the fake endpoint API handler, the zero value is ready to use.
+// Interfaces are keyed by the wg_add private key, peers by the interface public key,
+// the fake can't link them.
+type Server struct {
+	Clock       kdlib.Clock // nil means the system clock
+	TrafficStep uint64      // 0 means DefaultTrafficStep, the throttled peers get a tenth
+
+	mu         sync.Mutex
+	interfaces map[string]*Interface
+	peers      map[string]map[string]*Peer
+	faults     map[string]*Fault
+	requests   []string
+}
+
+// New - the fake endpoint.
+func New() *Server {
+	return &Server{}
+}
+
+// Inject - fail the call ("" - any call) by the fault.
+func (s *Server) Inject(call string, fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.faults == nil {
+		s.faults = map[string]*Fault{}
+	}
+
+	s.faults[call] = &fault
+}
+
+// ClearFaults - remove the injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+// Requests - returns and forgets the calls got.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	requests := s.requests
+	s.requests = nil
+
+	return requests
+}
+
+// Interfaces - the interfaces by the wg_add private keys (base64).
+func (s *Server) Interfaces() map[string]Interface {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	m := make(map[string]Interface, len(s.interfaces))
+	for key, iface := range s.interfaces {
+		m[key] = *iface
+	}
+
+	return m
+}
+
+// Peers - the peers of the interface public key by their public keys (base64).
+func (s *Server) Peers(wgPub []byte) map[string]Peer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	peers := s.peers[base64.StdEncoding.EncodeToString(wgPub)]
+
+	m := make(map[string]Peer, len(peers))
+	for key, peer := range peers {
+		m[key] = *peer
+	}
+
+	return m
+}
+
+type response struct {
+	Code    string `json:"code"`
+	Message string `json:"error,omitempty"`
+}
+
+// ServeHTTP - the endpoint API, the call is the query parameter with the key argument.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	call := ""
+	for _, c := range calls {
+		if q.Has(c) {
+			call = c
+
+			break
+		}
+	}
+
+	fault := s.fault(call)
+	if fault != nil {
+		if !s.delay(r, fault) {
+			return
+		}
+
+		switch {
+		case fault.Status != 0:
+			http.Error(w, http.StatusText(fault.Status), fault.Status)
+
+			return
+		case fault.Code != "":
+			writeJSON(w, response{Code: fault.Code, Message: "injected fault"})
+
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = append(s.requests, r.URL.RawQuery)
+
+	switch call {
+	case CallWgAdd:
+		writeJSON(w, s.wgAdd(q.Get(CallWgAdd), q.Get("external-ip"), q.Get("wireguard-port"), q.Get("internal-nets")))
+	case CallWgDel:
+		writeJSON(w, s.wgDel(q.Get(CallWgDel)))
+	case CallPeerAdd:
+		writeJSON(w, s.peerAdd(q.Get("wg-public-key"), q.Get(CallPeerAdd), peerInfo(q), q.Has("openvpn-client-csr")))
+	case CallPeerDel:
+		writeJSON(w, s.peerDel(q.Get("wg-public-key"), q.Get(CallPeerDel)))
+	case CallThrottleOn, CallThrottleOff:
+		writeJSON(w, s.throttle(q.Get("wg-public-key"), q.Get(call), call == CallThrottleOn))
+	case CallPeerList:
+		writeJSON(w, s.peerList(q.Get(CallPeerList)))
+	case CallStat:
+		writeJSON(w, s.stat(q.Get(CallStat)))
+	default:
+		writeJSON(w, response{Code: CodeBadRequest, Message: "unknown call"})
+	}
+}
+
+// fault - the call fault, the exhausted one is removed.
+func (s *Server) fault(call string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, c := range []string{call, ""} {
+		f, ok := s.faults[c]
+		if !ok {
+			continue
+		}
+
+		fault := *f
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				delete(s.faults, c)
+			}
+		}
+
+		return &fault
+	}
+
+	return nil
+}
+
+// delay - wait the fault latency, false if the client has gone.
+func (s *Server) delay(r *http.Request, fault *Fault) bool {
+	if fault.Hang {
+		<-r.Context().Done()
+
+		return false
+	}
+
+	if fault.Latency > 0 {
+		select {
+		case <-r.Context().Done():
+			return false
+		case <-time.After(fault.Latency):
+		}
+	}
+
+	return true
+}
+
+func (s *Server) wgAdd(key, externalIP, port, nets string) response {
+	if !validKey(key, 0) {
+		return response{Code: CodeBadRequest, Message: "bad key"}
+	}
+
+	if s.interfaces == nil {
+		s.interfaces = map[string]*Interface{}
+	}
+
+	s.interfaces[key] = &Interface{ExternalIP: externalIP, WireguardPort: port, InternalNets: nets}
+
+	return response{Code: CodeOK}
+}
+
+func (s *Server) wgDel(key string) response {
+	if _, ok := s.interfaces[key]; !ok {
+		return response{Code: CodeInterfaceMissing, Message: "no such interface"}
+	}
+
+	delete(s.interfaces, key)
+
+	return response{Code: CodeOK}
+}
+
+type peerAddResponse struct {
+	response
+	OpenvpnClientCertificate string `json:"openvpn-client-certificate,omitempty"`
+}
+
+// peerInfo - the peer_add parameters.
+func peerInfo(q url.Values) vpnapi.PeerInfo {
+	return vpnapi.PeerInfo{
+		AllowedIPs:    q.Get("allowed-ips"),
+		WgPSK:         q.Get("wg-psk-key"),
+		CloakUID:      q.Get("cloak-uid"),
+		L2TPUsername:  q.Get("l2tp-username"),
+		L2TPPassword:  q.Get("l2tp-password"),
+		OutlineSecret: q.Get("outline-ss-password"),
+		Proto0Secret:  q.Get("p0-id"),
+		ControlHost:   q.Get("control-host"),
+	}
+}
+
+func (s *Server) peerAdd(wgPub, key string, info vpnapi.PeerInfo, csr bool) peerAddResponse {
+	if !validKey(wgPub, 0) || !validKey(key, 32) {
+		return peerAddResponse{response: response{Code: CodeBadRequest, Message: "bad key"}}
+	}
+
+	if _, ok := s.peers[wgPub][key]; ok {
+		return peerAddResponse{response: response{Code: CodePeerExists, Message: "peer exists"}}
+	}
+
+	if s.peers == nil {
+		s.peers = map[string]map[string]*Peer{}
+	}
+
+	if s.peers[wgPub] == nil {
+		s.peers[wgPub] = map[string]*Peer{}
+	}
+
+	s.peers[wgPub][key] = &Peer{PeerInfo: info}
+
+	resp := peerAddResponse{response: response{Code: CodeOK}}
+	if csr {
+		resp.OpenvpnClientCertificate = vpnapi.TestCert
+	}
+
+	return resp
+}
+
+func (s *Server) peerDel(wgPub, key string) response {
+	delete(s.peers[wgPub], key)
+
+	return response{Code: CodeOK}
+}
+
+func (s *Server) throttle(wgPub, key string, on bool) response {
+	peer, ok := s.peers[wgPub][key]
+	if !ok {
+		return response{Code: CodeBadRequest, Message: "no such peer"}
+	}
+
+	peer.Throttled = on
+
+	return response{Code: CodeOK}
+}
+
+type peerListResponse struct {
+	response
+	Peers map[string]vpnapi.PeerInfo `json:"peers"`
+}
+
+func (s *Server) peerList(wgPub string) peerListResponse {
+	resp := peerListResponse{response: response{Code: CodeOK}, Peers: map[string]vpnapi.PeerInfo{}}
+	for key, peer := range s.peers[wgPub] {
+		resp.Peers[key] = peer.PeerInfo
+	}
+
+	return resp
+}
+
+// stat - every call the peers get the traffic and are seen.
+func (s *Server) stat(wgPub string) vpnapi.WGStatsIn {
+	now := kdlib.Now(s.Clock)
+
+	step := s.TrafficStep
+	if step == 0 {
+		step = DefaultTrafficStep
+	}
+
+	data := vpnapi.WgStatDataIn{
+		WgStatTrafficMapIn:  vpnapi.WgStatTrafficMapIn{},
+		WgStatLastseenMapIn: vpnapi.WgStatLastseenMapIn{},
+		WgStatEndpointMapIn: vpnapi.WgStatEndpointMapIn{},
+	}
+
+	keys := make([]string, 0, len(s.peers[wgPub]))
+	for key := range s.peers[wgPub] {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		peer := s.peers[wgPub][key]
+
+		rx := step
+		if peer.Throttled {
+			rx /= 10
+		}
+
+		peer.Rx += rx
+		peer.Tx += rx / 4
+		peer.LastSeen = now
+
+		data.WgStatTrafficMapIn[key] = vpnapi.WgStatTrafficDataIn{statProto: {
+			Received: strconv.FormatUint(peer.Rx, 10),
+			Sent:     strconv.FormatUint(peer.Tx, 10),
+		}}
+		data.WgStatLastseenMapIn[key] = vpnapi.WgStatLastseenDataIn{statProto: {Timestamp: strconv.FormatInt(now.Unix(), 10)}}
+		data.WgStatEndpointMapIn[key] = vpnapi.WgStatEndpointDataIn{statProto: {Subnet: PeerSubnet}}
+	}
+
+	return vpnapi.WGStatsIn{
+		Code:      CodeOK,
+		Timestamp: strconv.FormatInt(now.Unix(), 10),
+		Data:      data,
+	}
+}
+
+// validKey - the base64 key of the size, 0 - any.
+func validKey(key string, size int) bool {
+	buf, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return false
+	}
+
+	return size == 0 || len(buf) == size
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,148 @@
+package fakeendpoint
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+func testClient(t *testing.T) (*Server, *vpnapi.Client) {
+	t.Helper()
+
+	s := New()
+
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	addr := netip.MustParseAddrPort(srv.Listener.Addr().String())
+
+	return s, vpnapi.NewClient(addr, addr)
+}
+
+func TestServer(t *testing.T) {
+	s, c := testClient(t)
+	ctx := context.Background()
+
+	wgPub, peer := make([]byte, 32), make([]byte, 32)
+	wgPub[0], peer[0] = 1, 2
+
+	if err := c.WgAdd(ctx, vpnapi.WgAddRequest{WgPrivateKey: []byte("router encrypted")}); err != nil {
+		t.Fatalf("wg add: %s", err)
+	}
+
+	req := vpnapi.PeerAddRequest{PeerPublicKey: peer, WgPublicKey: wgPub, OpenVPNCSR: "csr"}
+
+	resp, err := c.PeerAdd(ctx, req)
+	if err != nil || resp.OpenvpnClientCertificate != vpnapi.TestCert {
+		t.Fatalf("peer add: %+v, %v", resp, err)
+	}
+
+	list, err := c.PeerList(ctx, vpnapi.PeerListRequest{WgPublicKey: wgPub})
+	if err != nil || len(list.Peers) != 1 {
+		t.Fatalf("peer list: %+v, %v", list, err)
+	}
+
+	for _, info := range list.Peers {
+		if diff := req.PeerInfo().Diff(info); len(diff) != 0 {
+			t.Errorf("peer info: %v", diff)
+		}
+	}
+
+	// the existing peer is a warning for the client.
+	if _, err := c.PeerAdd(ctx, req); err != nil {
+		t.Errorf("peer exists: %s", err)
+	}
+
+	if _, err := c.PeerAdd(ctx, vpnapi.PeerAddRequest{PeerPublicKey: []byte{1}, WgPublicKey: wgPub}); err == nil {
+		t.Errorf("bad key: no error")
+	}
+
+	var rx uint64
+
+	for range 2 {
+		stats, err := c.Stat(ctx, vpnapi.StatRequest{WgPublicKey: wgPub})
+		if err != nil {
+			t.Fatalf("stat: %s", err)
+		}
+
+		_, traffic, _, endpoints, err := vpnapi.WgStatParse(stats)
+		if err != nil {
+			t.Fatalf("parse: %s", err)
+		}
+
+		if len(traffic.Wg) != 1 {
+			t.Fatalf("traffic: %+v", traffic.Wg)
+		}
+
+		for key, tr := range traffic.Wg {
+			if tr.Rx <= rx || endpoints.Wg[key].String() != PeerSubnet {
+				t.Errorf("traffic: %+v, endpoint %s", tr, endpoints.Wg[key])
+			}
+
+			rx = tr.Rx
+		}
+	}
+
+	if err := c.ThrottleOn(ctx, vpnapi.PeerRequest{PeerPublicKey: peer, WgPublicKey: wgPub}); err != nil {
+		t.Errorf("throttle: %s", err)
+	}
+
+	if err := c.PeerDel(ctx, vpnapi.PeerRequest{PeerPublicKey: peer, WgPublicKey: wgPub}); err != nil {
+		t.Errorf("peer del: %s", err)
+	}
+
+	if len(s.Peers(wgPub)) != 0 {
+		t.Errorf("peers: %+v", s.Peers(wgPub))
+	}
+
+	if err := c.WgDel(ctx, vpnapi.WgDelRequest{WgPrivateKey: []byte("router encrypted")}); err != nil || len(s.Interfaces()) != 0 {
+		t.Errorf("wg del: %v, %+v", err, s.Interfaces())
+	}
+
+	// the absent interface is a warning for the client.
+	if err := c.WgDel(ctx, vpnapi.WgDelRequest{WgPrivateKey: []byte("router encrypted")}); err != nil {
+		t.Errorf("wg del again: %s", err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	s, c := testClient(t)
+	ctx := context.Background()
+
+	s.Inject(CallStat, Fault{Status: 502, Times: 1})
+
+	if _, err := c.Stat(ctx, vpnapi.StatRequest{}); err == nil {
+		t.Errorf("status: no error")
+	}
+
+	if _, err := c.Stat(ctx, vpnapi.StatRequest{}); err != nil {
+		t.Errorf("after the fault: %s", err)
+	}
+
+	s.Inject("", Fault{Code: "7"})
+
+	apiErr := &vpnapi.APIResponse{}
+	if err := c.PeerDel(ctx, vpnapi.PeerRequest{PeerPublicKey: make([]byte, 32)}); !errors.As(err, &apiErr) || apiErr.Code != "7" {
+		t.Errorf("code: %v", err)
+	}
+
+	s.ClearFaults()
+	s.Inject(CallWgAdd, Fault{Hang: true})
+
+	c.Timeouts.WgAdd = 50 * time.Millisecond
+
+	if err := c.WgAdd(ctx, vpnapi.WgAddRequest{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("hang: %v", err)
+	}
+
+	s.Inject(CallWgAdd, Fault{Latency: 10 * time.Millisecond})
+
+	if err := c.WgAdd(ctx, vpnapi.WgAddRequest{}); err != nil {
+		t.Errorf("latency: %s", err)
+	}
+}
@@ -12,8 +12,8 @@ func noTTL() filter.Func[storage.Message] {
 	}
 }
 
-func notOlder(d time.Duration) filter.Func[storage.Message] {
-	t := time.Now().Add(-d)
+func notOlder(d time.Duration, now time.Time) filter.Func[storage.Message] {
+	t := now.Add(-d)
 	return func(message storage.Message) bool {
 		return message.CreatedAt.After(t)
 	}
@@ -25,8 +25,7 @@ func ttlAfterTime(t time.Time) filter.Func[storage.Message] {
 	}
 }
 
-func ttlExpired() filter.Func[storage.Message] {
-	now := time.Now()
+func ttlExpired(now time.Time) filter.Func[storage.Message] {
 	return ttlAfterTime(now).IfOrTrue(noTTL().Not())
 }
 
@@ -48,7 +48,7 @@ func Test_cleanupMessages(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := cleanupMessages(tt.messages); len(got) != tt.wantLen {
+			if got := cleanupMessages(tt.messages, now); len(got) != tt.wantLen {
 				t.Errorf("%s: got %d messages, want %d", tt.name, len(got), tt.wantLen)
 			}
 		})
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/kdlib"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/pkg/filter"
 	"log"
@@ -13,15 +14,22 @@ import (
 )
 
 type Service struct {
-	db *storage.BrigadeStorage
+	db    *storage.BrigadeStorage
+	clock kdlib.Clock
 }
 
+// New - the messages service on the storage clock.
 func New(db *storage.BrigadeStorage) Service {
 	return Service{
-		db: db,
+		db:    db,
+		clock: db.Clock,
 	}
 }
 
+func (s Service) now() time.Time {
+	return kdlib.Now(s.clock)
+}
+
 func (s Service) transaction(fn func(brigade *storage.Brigade) error) error {
 	f, brigade, err := s.db.OpenDbToModify()
 	if err != nil {
@@ -29,7 +37,7 @@ func (s Service) transaction(fn func(brigade *storage.Brigade) error) error {
 	}
 	defer f.Close()
 
-	brigade.Messages = cleanupMessages(brigade.Messages)
+	brigade.Messages = cleanupMessages(brigade.Messages, s.now())
 
 	if err = fn(brigade); err != nil {
 		return fmt.Errorf("run in transaction: %w", err)
@@ -42,6 +50,15 @@ func (s Service) transaction(fn func(brigade *storage.Brigade) error) error {
 	return nil
 }
 
+// view - read messages without the commit, expired ones are cleaned up in memory only.
+func (s Service) view(fn func(brigade *storage.Brigade) error) error {
+	return s.db.ViewTransaction(func(brigade *storage.Brigade) error {
+		brigade.Messages = cleanupMessages(brigade.Messages, s.now())
+
+		return fn(brigade)
+	})
+}
+
 func paginate(messages []storage.Message, offset, limit int) []storage.Message {
 	if offset >= len(messages) {
 		return nil
@@ -79,7 +96,7 @@ func (s Service) GetMessages(
 ) ([]storage.Message, int, error) {
 	var result []storage.Message
 
-	if err := s.transaction(func(brigade *storage.Brigade) error {
+	if err := s.view(func(brigade *storage.Brigade) error {
 		result = brigade.Messages
 		return nil
 	}); err != nil {
@@ -179,7 +196,7 @@ func sortMessagesFactory(result []storage.Message, sortParams map[string]bool) (
 func (s Service) CreateMessage(title, text string, ttl time.Duration, priority int) (storage.Message, error) {
 	var msg storage.Message
 	if err := s.transaction(func(brigade *storage.Brigade) error {
-		now := time.Now()
+		now := s.now()
 		msg = storage.Message{
 			ID:        uuid.New(),
 			Title:     title,
@@ -196,10 +213,10 @@ func (s Service) CreateMessage(title, text string, ttl time.Duration, priority i
 	return msg, nil
 }
 
-func cleanupMessages(messages []storage.Message) []storage.Message {
+func cleanupMessages(messages []storage.Message, now time.Time) []storage.Message {
 	return filter.Filter(
 		messages,
-		ttlExpired(),
+		ttlExpired(now),
 		filter.Fn[storage.Message](func(messages []storage.Message) []storage.Message {
 			var idxs []int
 			i := 0
@@ -221,7 +238,7 @@ func cleanupMessages(messages []storage.Message) []storage.Message {
 			}
 			return messages
 		}),
-		notOlder(24*time.Hour*30).IfOrTrue(noTTL()),
+		notOlder(24*time.Hour*30, now).IfOrTrue(noTTL()),
 		lastN(100),
 	)
 }
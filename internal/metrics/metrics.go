@@ -0,0 +1,209 @@
+// Package metrics - Prometheus/OpenMetrics exporter of the brigade and user counters.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/vpngen/keydesk/internal/stat"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+const namespace = "keydesk"
+
+// Protocol label values.
+const (
+	protoWireguard        = "wireguard"
+	protoIPSec            = "ipsec"
+	protoOpenVPN          = "openvpn"
+	protoOpenVPNOverCloak = "openvpn_over_cloak"
+	protoOutline          = "outline"
+	protoProto0           = "proto0"
+)
+
+// Collector - reads the brigade on every scrape.
+type Collector struct {
+	db    *storage.BrigadeStorage
+	stats *stat.Metrics
+
+	up                  *prometheus.Desc
+	users               *prometheus.Desc
+	activeUsers         *prometheus.Desc
+	protocolActiveUsers *prometheus.Desc
+	throttledUsers      *prometheus.Desc
+	blockedUsers        *prometheus.Desc
+	traffic             *prometheus.Desc
+	protocolTraffic     *prometheus.Desc
+	userTraffic         *prometheus.Desc
+	countersUpdate      *prometheus.Desc
+	lastWgStat          *prometheus.Desc
+	collectionDuration  *prometheus.Desc
+	collections         *prometheus.Desc
+	collectionErrors    *prometheus.Desc
+}
+
+// NewCollector - the brigade collector, stats may be nil.
+func NewCollector(db *storage.BrigadeStorage, stats *stat.Metrics) *Collector {
+	labels := prometheus.Labels{"brigade_id": db.BrigadeID}
+
+	desc := func(name, help string, variable ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, variable, labels)
+	}
+
+	return &Collector{
+		db:    db,
+		stats: stats,
+
+		up:                  desc("up", "Whether the brigade was read."),
+		users:               desc("users", "Users in the brigade."),
+		activeUsers:         desc("active_users", "Users active this month."),
+		protocolActiveUsers: desc("protocol_active_users", "Users active this month by protocol.", "protocol"),
+		throttledUsers:      desc("throttled_users", "Throttled users."),
+		blockedUsers:        desc("blocked_users", "Blocked users."),
+		traffic:             desc("traffic_bytes_total", "Brigade traffic.", "direction"),
+		protocolTraffic:     desc("protocol_traffic_bytes_total", "Brigade traffic by protocol.", "protocol", "direction"),
+		userTraffic:         desc("user_traffic_bytes_total", "User traffic by protocol.", "user_id", "protocol", "direction"),
+		countersUpdate:      desc("counters_update_timestamp_seconds", "Endpoint time of the last merged stats."),
+		lastWgStat:          desc("last_wg_stat_timestamp_seconds", "Time of the last successful endpoint stat call."),
+		collectionDuration:  desc("stats_collection_duration_seconds", "Duration of the last stats collection."),
+		collections:         desc("stats_collections_total", "Stats collections."),
+		collectionErrors:    desc("stats_collection_errors_total", "Failed stats collections."),
+	}
+}
+
+// Describe - prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.up, c.users, c.activeUsers, c.protocolActiveUsers, c.throttledUsers, c.blockedUsers,
+		c.traffic, c.protocolTraffic, c.userTraffic, c.countersUpdate,
+		c.lastWgStat, c.collectionDuration, c.collections, c.collectionErrors,
+	} {
+		ch <- d
+	}
+}
+
+// Collect - prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.collectStats(ch)
+
+	err := c.db.ViewTransaction(func(brigade *storage.Brigade) error {
+		c.collectBrigade(ch, brigade)
+
+		return nil
+	})
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 0)
+
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, 1)
+}
+
+func (c *Collector) collectStats(ch chan<- prometheus.Metric) {
+	if c.stats == nil {
+		return
+	}
+
+	s := c.stats.Snapshot()
+
+	ch <- prometheus.MustNewConstMetric(c.collections, prometheus.CounterValue, float64(s.Collections))
+	ch <- prometheus.MustNewConstMetric(c.collectionErrors, prometheus.CounterValue, float64(s.Errors))
+	ch <- prometheus.MustNewConstMetric(c.collectionDuration, prometheus.GaugeValue, s.LastDuration.Seconds())
+
+	if !s.LastWgStat.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.lastWgStat, prometheus.GaugeValue, timestamp(s.LastWgStat))
+	}
+}
+
+func (c *Collector) collectBrigade(ch chan<- prometheus.Metric, brigade *storage.Brigade) {
+	gauge := func(desc *prometheus.Desc, v int, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(v), labels...)
+	}
+
+	rxtx := func(desc *prometheus.Desc, v storage.RxTx, labels ...string) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(v.Rx), append(labels, "rx")...)
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(v.Tx), append(labels, "tx")...)
+	}
+
+	gauge(c.users, brigade.TotalUsersCount)
+	gauge(c.activeUsers, brigade.ActiveUsersCount)
+	gauge(c.protocolActiveUsers, brigade.ActiveWgUsersCount, protoWireguard)
+	gauge(c.protocolActiveUsers, brigade.ActiveIPSecUsersCount, protoIPSec)
+	gauge(c.protocolActiveUsers, brigade.ActiveOvcUsersCount, protoOpenVPN)
+	gauge(c.protocolActiveUsers, brigade.ActiveOlcUsersCount, protoOpenVPNOverCloak)
+	gauge(c.protocolActiveUsers, brigade.ActiveOutlineUsersCount, protoOutline)
+	gauge(c.protocolActiveUsers, brigade.ActiveProto0UsersCount, protoProto0)
+	gauge(c.throttledUsers, brigade.ThrottledUsersCount)
+	gauge(c.blockedUsers, brigade.BlockedUsersCount)
+
+	rxtx(c.traffic, brigade.TotalTraffic.Total)
+	rxtx(c.protocolTraffic, brigade.TotalWgTraffic.Total, protoWireguard)
+	rxtx(c.protocolTraffic, brigade.TotalIPSecTraffic.Total, protoIPSec)
+	rxtx(c.protocolTraffic, brigade.TotalOvcTraffic.Total, protoOpenVPN)
+	rxtx(c.protocolTraffic, brigade.TotalOutlineTraffic.Total, protoOutline)
+	rxtx(c.protocolTraffic, brigade.TotalProto0Traffic.Total, protoProto0)
+
+	for _, user := range brigade.Users {
+		id := user.UserID.String()
+
+		rxtx(c.userTraffic, user.Quotas.CountersWg.Total, id, protoWireguard)
+		rxtx(c.userTraffic, user.Quotas.CountersIPSec.Total, id, protoIPSec)
+		rxtx(c.userTraffic, user.Quotas.CountersOvc.Total, id, protoOpenVPN)
+		rxtx(c.userTraffic, user.Quotas.CountersOutline.Total, id, protoOutline)
+		rxtx(c.userTraffic, user.Quotas.CountersProto0.Total, id, protoProto0)
+	}
+
+	if !brigade.CountersUpdateTime.IsZero() {
+		ch <- prometheus.MustNewConstMetric(c.countersUpdate, prometheus.GaugeValue, timestamp(brigade.CountersUpdateTime))
+	}
+}
+
+func timestamp(t time.Time) float64 {
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// Handler - /metrics in the negotiated format, OpenMetrics if it's accepted.
+func Handler(g prometheus.Gatherer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mfs, err := g.Gather()
+		if err != nil && len(mfs) == 0 {
+			http.Error(w, fmt.Sprintf("gather: %s", err), http.StatusInternalServerError)
+
+			return
+		}
+
+		format := expfmt.NegotiateIncludingOpenMetrics(r.Header)
+		w.Header().Set("Content-Type", string(format))
+
+		enc := expfmt.NewEncoder(w, format)
+		for _, mf := range mfs {
+			if err := enc.Encode(mf); err != nil {
+				return
+			}
+		}
+
+		if closer, ok := enc.(expfmt.Closer); ok {
+			_ = closer.Close()
+		}
+	})
+}
+
+// NewServer - the /metrics server for the brigade.
+func NewServer(db *storage.BrigadeStorage, stats *stat.Metrics) (*http.Server, error) {
+	reg := prometheus.NewRegistry()
+	if err := reg.Register(NewCollector(db, stats)); err != nil {
+		return nil, fmt.Errorf("register: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler(reg))
+
+	return &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}, nil
+}
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/vpngen/keydesk/internal/stat"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+var db storage.BrigadeStorage
+
+func TestMain(m *testing.M) {
+	mw := storage.BrigadeTestMiddleware(&db, func(m *testing.M) int { return m.Run() })
+	os.Exit(mw(m))
+}
+
+func TestHandler(t *testing.T) {
+	srv, err := NewServer(&db, &stat.Metrics{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		accept, contentType string
+	}{
+		{"", "text/plain"},
+		{"application/openmetrics-text; version=1.0.0", "application/openmetrics-text"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept", tc.accept)
+		}
+
+		rec := httptest.NewRecorder()
+		srv.Handler.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), tc.contentType) {
+			t.Fatalf("%q: %d %s", tc.accept, rec.Code, rec.Header().Get("Content-Type"))
+		}
+
+		body := rec.Body.String()
+		for _, want := range []string{
+			`keydesk_up{brigade_id="` + db.BrigadeID + `"} 1`,
+			"keydesk_users{",
+			`keydesk_protocol_traffic_bytes_total{brigade_id="` + db.BrigadeID + `",direction="rx",protocol="wireguard"}`,
+			"keydesk_stats_collections_total{",
+		} {
+			if !strings.Contains(body, want) {
+				t.Errorf("%q: no %s in:\n%s", tc.accept, want, body)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Package outbox - the background retries of the brigade endpoint operations.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// DefaultInterval - the outbox polling interval.
+const DefaultInterval = 10 * time.Second
+
+// Worker - process the brigade outbox by the interval.
+type Worker struct {
+	db       *storage.BrigadeStorage
+	interval time.Duration
+}
+
+// NewWorker - the worker of the brigade outbox, 0 means DefaultInterval.
+func NewWorker(db *storage.BrigadeStorage, interval time.Duration) *Worker {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	return &Worker{db: db, interval: interval}
+}
+
+// Run - process the outbox till the kill, the call in progress is cancelled.
+func (w *Worker) Run(kill <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-kill:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, _, err := w.db.ProcessOutbox(ctx); err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "Outbox: %s\n", err)
+			}
+		case <-ctx.Done():
+			_, _ = fmt.Fprintln(os.Stderr, "Shutting down outbox...")
+			return
+		}
+	}
+}
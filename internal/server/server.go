@@ -20,6 +20,7 @@ import (
 func NewServer(
 	db *storage.BrigadeStorage,
 	msgSvc service.Service,
+	stats keydesk.StatsRefresher,
 	issuer jwt.KeydeskTokenIssuer,
 	goSwaggerAuth goSwagger.Service,
 	routerPublicKey, shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte,
@@ -52,9 +53,30 @@ func NewServer(
 	api.GetUserHandler = operations.GetUserHandlerFunc(func(params operations.GetUserParams, principal interface{}) middleware.Responder {
 		return keydesk.GetUsers(db, params, principal)
 	})
+	api.PatchUserUserIDQuotaHandler = operations.PatchUserUserIDQuotaHandlerFunc(func(params operations.PatchUserUserIDQuotaParams, principal interface{}) middleware.Responder {
+		return keydesk.SetUserQuotaUserID(db, params, principal)
+	})
+	api.PatchUserUserIDThrottleHandler = operations.PatchUserUserIDThrottleHandlerFunc(func(params operations.PatchUserUserIDThrottleParams, principal interface{}) middleware.Responder {
+		return keydesk.ThrottleUserID(db, params, principal)
+	})
+	api.GetUserUserIDEventsHandler = operations.GetUserUserIDEventsHandlerFunc(func(params operations.GetUserUserIDEventsParams, principal interface{}) middleware.Responder {
+		return keydesk.GetUserEvents(db, params, principal)
+	})
+	api.GetUserUserIDTrafficHandler = operations.GetUserUserIDTrafficHandlerFunc(func(params operations.GetUserUserIDTrafficParams, principal interface{}) middleware.Responder {
+		return keydesk.GetUserTraffic(db, params, principal)
+	})
 	api.GetUsersStatsHandler = operations.GetUsersStatsHandlerFunc(func(params operations.GetUsersStatsParams, principal interface{}) middleware.Responder {
 		return keydesk.GetUsersStats(db, params, principal)
 	})
+	api.GetUsersStatsTrafficHandler = operations.GetUsersStatsTrafficHandlerFunc(func(params operations.GetUsersStatsTrafficParams, principal interface{}) middleware.Responder {
+		return keydesk.GetUsersStatsTraffic(db, params, principal)
+	})
+	api.GetUsersStatsGeoHandler = operations.GetUsersStatsGeoHandlerFunc(func(params operations.GetUsersStatsGeoParams, principal interface{}) middleware.Responder {
+		return keydesk.GetUsersStatsGeo(db, params, principal)
+	})
+	api.PostUsersStatsRefreshHandler = operations.PostUsersStatsRefreshHandlerFunc(func(params operations.PostUsersStatsRefreshParams, principal interface{}) middleware.Responder {
+		return keydesk.RefreshUsersStats(db, stats, params, principal)
+	})
 
 	api.PatchUserUserIDBlockHandler = operations.PatchUserUserIDBlockHandlerFunc(func(params operations.PatchUserUserIDBlockParams, principal interface{}) middleware.Responder {
 		return keydesk.BlockUserUserID(db, params, principal)
@@ -503,6 +503,7 @@ func serverTestMiddleware(db *storage.BrigadeStorage, mw utils.TestMainMiddlewar
 		api := NewServer(
 			db,
 			service.New(db),
+			nil,
 			jwt.NewKeydeskTokenIssuer(key, "id", opts),
 			goSwagger.NewService(jwt.NewKeydeskTokenAuthorizer(key, opts)),
 			rpk,
@@ -15,12 +15,13 @@ import (
 	"github.com/vpngen/keydesk/gen/shuffler"
 	authmw "github.com/vpngen/keydesk/internal/auth/swagger3"
 	"github.com/vpngen/keydesk/internal/user"
+	"github.com/vpngen/keydesk/keydesk"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/pkg/jwt"
 	"github.com/vpngen/vpngine/naclkey"
 )
 
-func SetupServer(db *storage.BrigadeStorage, authorizer jwt.MessagesJwtAuthorizer, routerPub, shufflerPub [naclkey.NaclBoxKeyLength]byte) (*echo.Echo, error) {
+func SetupServer(db *storage.BrigadeStorage, stats keydesk.StatsRefresher, authorizer jwt.MessagesJwtAuthorizer, routerPub, shufflerPub [naclkey.NaclBoxKeyLength]byte) (*echo.Echo, error) {
 	swagger, err := shuffler.GetSwagger()
 	if err != nil {
 		return nil, fmt.Errorf("get swagger: %s", err.Error())
@@ -52,7 +53,7 @@ func SetupServer(db *storage.BrigadeStorage, authorizer jwt.MessagesJwtAuthorize
 		return nil, fmt.Errorf("init user service: %w", err)
 	}
 
-	srv := server{service: userSvc}
+	srv := server{service: userSvc, stats: stats}
 
 	shuffler.RegisterHandlers(e, shuffler.NewStrictHandler(srv, nil))
 
@@ -61,6 +62,7 @@ func SetupServer(db *storage.BrigadeStorage, authorizer jwt.MessagesJwtAuthorize
 
 type server struct {
 	service user.Service
+	stats   keydesk.StatsRefresher
 }
 
 func (s server) GetActivity(ctx context.Context, request shuffler.GetActivityRequestObject) (shuffler.GetActivityResponseObject, error) {
@@ -74,6 +76,36 @@ func (s server) GetActivity(ctx context.Context, request shuffler.GetActivityReq
 	return shuffler.GetActivity200JSONResponse(lastSeen), nil
 }
 
+func (s server) PostStatsRefresh(ctx context.Context, request shuffler.PostStatsRefreshRequestObject) (shuffler.PostStatsRefreshResponseObject, error) {
+	if s.stats == nil {
+		return shuffler.PostStatsRefreshdefaultJSONResponse{
+			Body:       "stats refresh is not available",
+			StatusCode: http.StatusServiceUnavailable,
+		}, nil
+	}
+
+	if err := s.stats.Refresh(ctx); err != nil {
+		if errors.Is(err, keydesk.ErrStatsRefreshTooOften) {
+			return shuffler.PostStatsRefresh429Response{}, nil
+		}
+
+		return shuffler.PostStatsRefreshdefaultJSONResponse{
+			Body:       err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	lastSeen, err := s.service.GetLastConnections()
+	if err != nil {
+		return shuffler.PostStatsRefreshdefaultJSONResponse{
+			Body:       err.Error(),
+			StatusCode: http.StatusInternalServerError,
+		}, nil
+	}
+
+	return shuffler.PostStatsRefresh200JSONResponse(lastSeen), nil
+}
+
 func (s server) PostConfigs(ctx context.Context, request shuffler.PostConfigsRequestObject) (shuffler.PostConfigsResponseObject, error) {
 	//protocols := vpn.NewProtocolSet(request.Body.Protocols)
 	//if len(protocols.Protocols()) != 1 {
@@ -88,7 +120,7 @@ func (s server) PostConfigs(ctx context.Context, request shuffler.PostConfigsReq
 		domain = *request.Body.Domain
 	}
 
-	userCfg, err := s.service.CreateUser(request.Body.Configs, domain)
+	userCfg, err := s.service.CreateUser(ctx, request.Body.Configs, domain)
 	if err != nil {
 		if errors.Is(err, user.ErrNoFreeSlots) {
 			return shuffler.PostConfigs507Response{}, nil
@@ -102,8 +134,8 @@ func (s server) PostConfigs(ctx context.Context, request shuffler.PostConfigsReq
 		}
 
 		return shuffler.PostConfigsdefaultJSONResponse{
-			Body:       err.Error(),
-			StatusCode: http.StatusInternalServerError,
+			Body:       errorBody(err),
+			StatusCode: errorStatus(err),
 		}, nil
 	}
 
@@ -160,7 +192,7 @@ func (s server) PostConfigs(ctx context.Context, request shuffler.PostConfigsReq
 }
 
 func (s server) PatchConfigsIdBlock(ctx context.Context, request shuffler.PatchConfigsIdBlockRequestObject) (shuffler.PatchConfigsIdBlockResponseObject, error) {
-	free, err := s.service.DeleteUser(request.Id, true)
+	free, err := s.service.DeleteUser(ctx, request.Id, true)
 	if err != nil {
 		if errors.Is(err, user.ErrNotFound) {
 			return shuffler.PatchConfigsIdBlock404Response{}, nil
@@ -174,8 +206,8 @@ func (s server) PatchConfigsIdBlock(ctx context.Context, request shuffler.PatchC
 		}
 
 		return shuffler.PatchConfigsIdBlockdefaultJSONResponse{
-			Body:       err.Error(),
-			StatusCode: http.StatusInternalServerError,
+			Body:       errorBody(err),
+			StatusCode: errorStatus(err),
 		}, nil
 	}
 
@@ -183,7 +215,7 @@ func (s server) PatchConfigsIdBlock(ctx context.Context, request shuffler.PatchC
 }
 
 func (s server) PatchConfigsIdUnblock(ctx context.Context, request shuffler.PatchConfigsIdUnblockRequestObject) (shuffler.PatchConfigsIdUnblockResponseObject, error) {
-	free, err := s.service.UnblockUser(request.Id)
+	free, err := s.service.UnblockUser(ctx, request.Id)
 	if err != nil {
 		if errors.Is(err, user.ErrNotFound) {
 			return shuffler.PatchConfigsIdUnblock404Response{}, nil
@@ -197,8 +229,8 @@ func (s server) PatchConfigsIdUnblock(ctx context.Context, request shuffler.Patc
 		}
 
 		return shuffler.PatchConfigsIdUnblockdefaultJSONResponse{
-			Body:       err.Error(),
-			StatusCode: http.StatusInternalServerError,
+			Body:       errorBody(err),
+			StatusCode: errorStatus(err),
 		}, nil
 	}
 
@@ -206,7 +238,7 @@ func (s server) PatchConfigsIdUnblock(ctx context.Context, request shuffler.Patc
 }
 
 func (s server) DeleteConfigsId(ctx context.Context, request shuffler.DeleteConfigsIdRequestObject) (shuffler.DeleteConfigsIdResponseObject, error) {
-	free, err := s.service.DeleteUser(request.Id, false)
+	free, err := s.service.DeleteUser(ctx, request.Id, false)
 	if err != nil {
 		if errors.Is(err, user.ErrNotFound) {
 			return shuffler.DeleteConfigsId404Response{}, nil
@@ -220,8 +252,8 @@ func (s server) DeleteConfigsId(ctx context.Context, request shuffler.DeleteConf
 		}
 
 		return shuffler.DeleteConfigsIddefaultJSONResponse{
-			Body:       err.Error(),
-			StatusCode: http.StatusInternalServerError,
+			Body:       errorBody(err),
+			StatusCode: errorStatus(err),
 		}, nil
 	}
 
@@ -242,3 +274,21 @@ func (s server) GetSlots(ctx context.Context, request shuffler.GetSlotsRequestOb
 		TotalSlots: int(total),
 	}, nil
 }
+
+// errorBody - the user operation failure message, the endpoint failure is told by the code and the catalogue text.
+func errorBody(err error) string {
+	if msg := keydesk.EndpointMessage(err); msg != "" {
+		return msg
+	}
+
+	return err.Error()
+}
+
+// errorStatus - the HTTP status of the user operation failure, 500 if not an endpoint one.
+func errorStatus(err error) int {
+	if status := keydesk.EndpointStatus(err); status != 0 {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
@@ -1,38 +1,126 @@
 package stat
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
+	"github.com/vpngen/keydesk/kdlib"
 	"github.com/vpngen/keydesk/keydesk"
 	"github.com/vpngen/keydesk/keydesk/storage"
 )
 
-func CollectingData(db *storage.BrigadeStorage, kill <-chan struct{}, rdata bool, statsDir string) {
-	statsFilename := filepath.Join(statsDir, storage.StatsFilename)
-	statsSpinlock := filepath.Join(statsDir, storage.StatsSpinlockFilename)
+// Schedule - the stats collecting schedule.
+type Schedule struct {
+	Interval time.Duration // between the collections
+	Jitter   time.Duration // the random delay of the first collection, 0 - none
+}
+
+// DefaultSchedule - the schedule without the keydesk flags.
+var DefaultSchedule = Schedule{
+	Interval: DefaultStatisticsFetchingDuration,
+	Jitter:   DefaultJitterValue * time.Second,
+}
+
+// Collector - collect the stats by the schedule and on demand, metrics may be nil.
+type Collector struct {
+	db            *storage.BrigadeStorage
+	rdata         bool
+	statsFilename string
+	statsSpinlock string
+	schedule      Schedule
+	metrics       *Metrics
+
+	mu sync.Mutex // one collecting at a time
+
+	refreshMu   sync.Mutex
+	lastRefresh time.Time
+}
+
+// NewCollector - the collector of the brigade stats to the statsDir.
+func NewCollector(db *storage.BrigadeStorage, rdata bool, statsDir string, schedule Schedule, metrics *Metrics) *Collector {
+	return &Collector{
+		db:            db,
+		rdata:         rdata,
+		statsFilename: filepath.Join(statsDir, storage.StatsFilename),
+		statsSpinlock: filepath.Join(statsDir, storage.StatsSpinlockFilename),
+		schedule:      schedule,
+		metrics:       metrics,
+	}
+}
+
+func (c *Collector) collect(ctx context.Context, ts time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, _ = fmt.Fprintf(os.Stderr, "%s: Collecting data: %s: %s\n", ts.UTC().Format(time.RFC3339), c.db.BrigadeID, c.statsFilename)
+
+	wgStat, err := c.db.GetStats(ctx, c.rdata, c.statsFilename, c.statsSpinlock, keydesk.DefaultEndpointsTTL)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Error collecting stats: %s\n", err)
+	}
 
-	jit := rand.Int63n(DefaultJitterValue) + 1
-	timer := time.NewTimer(time.Duration(jit) * time.Second)
+	c.metrics.observe(time.Since(ts), wgStat, err)
+
+	return err
+}
+
+// Run - collect the stats periodically till kill, the collecting in progress is cancelled.
+func (c *Collector) Run(kill <-chan struct{}) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-kill:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	delay := time.Second
+	if c.schedule.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(c.schedule.Jitter)))
+	}
+
+	timer := time.NewTimer(delay)
 
 	defer timer.Stop()
 
 	for {
 		select {
 		case ts := <-timer.C:
-			_, _ = fmt.Fprintf(os.Stderr, "%s: Collecting data: %s: %s\n", ts.UTC().Format(time.RFC3339), db.BrigadeID, statsFilename)
-
-			if err := db.GetStats(rdata, statsFilename, statsSpinlock, keydesk.DefaultEndpointsTTL); err != nil {
-				_, _ = fmt.Fprintf(os.Stderr, "Error collecting stats: %s\n", err)
-			}
+			_ = c.collect(ctx, ts)
 
-			timer.Reset(DefaultStatisticsFetchingDuration)
-		case <-kill:
+			timer.Reset(c.schedule.Interval)
+		case <-ctx.Done():
 			_, _ = fmt.Fprintln(os.Stderr, "Shutting down stats...")
 			return
 		}
 	}
 }
+
+// Refresh - collect the stats now, the calls within MinRefreshInterval are refused.
+func (c *Collector) Refresh(ctx context.Context) error {
+	c.refreshMu.Lock()
+
+	now := kdlib.Now(c.db.Clock)
+	if since := now.Sub(c.lastRefresh); !c.lastRefresh.IsZero() && since < MinRefreshInterval {
+		c.refreshMu.Unlock()
+
+		return fmt.Errorf("%w: retry in %s", keydesk.ErrStatsRefreshTooOften, (MinRefreshInterval - since).Round(time.Second))
+	}
+
+	c.lastRefresh = now
+	c.refreshMu.Unlock()
+
+	if err := c.collect(ctx, time.Now()); err != nil {
+		return fmt.Errorf("collect: %w", err)
+	}
+
+	return nil
+}
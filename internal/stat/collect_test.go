@@ -0,0 +1,47 @@
+package stat
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vpngen/keydesk/keydesk"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+var db storage.BrigadeStorage
+
+func TestMain(m *testing.M) {
+	mw := storage.BrigadeTestMiddleware(&db, func(m *testing.M) int { return m.Run() })
+	os.Exit(mw(m))
+}
+
+func TestRefresh(t *testing.T) {
+	clock := storage.NewTestClock(time.Now())
+	db.Clock = clock
+
+	defer func() { db.Clock = nil }()
+
+	metrics := &Metrics{}
+	c := NewCollector(&db, true, t.TempDir(), DefaultSchedule, metrics)
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Fatalf("refresh: %s", err)
+	}
+
+	if err := c.Refresh(context.Background()); !errors.Is(err, keydesk.ErrStatsRefreshTooOften) {
+		t.Errorf("second refresh: %v", err)
+	}
+
+	clock.Set(clock.Now().Add(MinRefreshInterval))
+
+	if err := c.Refresh(context.Background()); err != nil {
+		t.Errorf("refresh after the interval: %s", err)
+	}
+
+	if s := metrics.Snapshot(); s.Collections != 2 || s.Errors != 0 {
+		t.Errorf("collections %d, errors %d", s.Collections, s.Errors)
+	}
+}
@@ -7,4 +7,6 @@ const (
 	DefaultStatisticsFetchingDuration = 5 * time.Minute // 5m
 	// DefaultJitterValue                = 1800        // sec
 	DefaultJitterValue = 150 // sec
+	// MinRefreshInterval - the on demand refreshes are refused more often.
+	MinRefreshInterval = 30 * time.Second
 )
@@ -0,0 +1,55 @@
+package stat
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+
+	"github.com/vpngen/keydesk/keydesk/storage"
+	"github.com/vpngen/keydesk/pkg/mmdb"
+)
+
+// GeoDB - the users networks locator by the local MaxMind format database,
+// the country (GeoIP2/GeoLite2 Country, City) and ASN (GeoLite2 ASN) fields are used.
+type GeoDB struct {
+	reader *mmdb.Reader
+}
+
+// OpenGeoDB - load the database file.
+func OpenGeoDB(filename string) (*GeoDB, error) {
+	reader, err := mmdb.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("mmdb: %w", err)
+	}
+
+	return &GeoDB{reader: reader}, nil
+}
+
+// Locate - the network location, zero if it isn't in the database.
+func (g *GeoDB) Locate(addr netip.Addr) (storage.GeoLocation, error) {
+	v, err := g.reader.Lookup(addr)
+	if err != nil {
+		return storage.GeoLocation{}, fmt.Errorf("lookup %s: %w", addr, err)
+	}
+
+	record, _ := v.(map[string]any)
+
+	loc := storage.GeoLocation{Country: isoCode(record["country"])}
+	if loc.Country == "" {
+		loc.Country = isoCode(record["registered_country"])
+	}
+
+	if asn, ok := record["autonomous_system_number"].(uint64); ok && asn <= math.MaxUint32 {
+		loc.ASN = uint32(asn)
+		loc.ASOrg, _ = record["autonomous_system_organization"].(string)
+	}
+
+	return loc, nil
+}
+
+func isoCode(v any) string {
+	country, _ := v.(map[string]any)
+	code, _ := country["iso_code"].(string)
+
+	return code
+}
@@ -0,0 +1,57 @@
+package stat
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics - the stats collection results for the exporter.
+type Metrics struct {
+	mu sync.Mutex
+
+	collections  uint64
+	errors       uint64
+	lastDuration time.Duration
+	lastWgStat   time.Time
+}
+
+// MetricsSnapshot - consistent copy of Metrics.
+type MetricsSnapshot struct {
+	Collections  uint64
+	Errors       uint64
+	LastDuration time.Duration
+	LastWgStat   time.Time // zero if there was no successful call
+}
+
+func (m *Metrics) observe(duration time.Duration, wgStat time.Time, err error) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.collections++
+	m.lastDuration = duration
+
+	if err != nil {
+		m.errors++
+	}
+
+	if !wgStat.IsZero() {
+		m.lastWgStat = wgStat
+	}
+}
+
+// Snapshot - copy the current values.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return MetricsSnapshot{
+		Collections:  m.collections,
+		Errors:       m.errors,
+		LastDuration: m.lastDuration,
+		LastWgStat:   m.lastWgStat,
+	}
+}
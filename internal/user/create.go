@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"time"
@@ -28,7 +29,7 @@ var (
 	ErrNotAllowed  = fmt.Errorf("not allowed")
 )
 
-func (s Service) CreateUser(configs []string, domain string) (res createUserResponse, err error) {
+func (s Service) CreateUser(ctx context.Context, configs []string, domain string) (res createUserResponse, err error) {
 	err = s.db.RunInTransaction(func(brigade *storage.Brigade) error {
 		//if brigade.Mode == storage.ModeBrigade {
 		//	return ErrNotAllowed
@@ -38,7 +39,7 @@ func (s Service) CreateUser(configs []string, domain string) (res createUserResp
 			return ErrNoFreeSlots
 		}
 
-		res.User, err = s.createUserWithConfigs(brigade, configs, domain)
+		res.User, err = s.createUserWithConfigs(ctx, brigade, configs, domain)
 		if err != nil {
 			return fmt.Errorf("create user with configs %s: %w", configs, err)
 		}
@@ -51,7 +52,7 @@ func (s Service) CreateUser(configs []string, domain string) (res createUserResp
 	return
 }
 
-func (s Service) createUserWithConfigs(brigade *storage.Brigade, configs []string, domain string) (User, error) {
+func (s Service) createUserWithConfigs(ctx context.Context, brigade *storage.Brigade, configs []string, domain string) (User, error) {
 	if domain == "" {
 		domain = brigade.EndpointDomain
 	}
@@ -61,7 +62,7 @@ func (s Service) createUserWithConfigs(brigade *storage.Brigade, configs []strin
 		return User{}, fmt.Errorf("new user: %w", err)
 	}
 
-	cfgs, name, err := s.generator.GenerateConfigs(brigade, &dbUser, configs)
+	cfgs, name, err := s.generator.GenerateConfigs(ctx, brigade, &dbUser, configs)
 	if err != nil {
 		return User{}, fmt.Errorf("generate configs: %w", err)
 	}
@@ -102,7 +103,7 @@ func newUser(brigade *storage.Brigade, domain string) (storage.User, error) {
 	return user, nil
 }
 
-func (s Service) UnblockUser(id uuid.UUID) (free int, err error) {
+func (s Service) UnblockUser(ctx context.Context, id uuid.UUID) (free int, err error) {
 	if err := s.db.RunInTransaction(func(brigade *storage.Brigade) error {
 		//if brigade.Mode == storage.ModeBrigade {
 		//	return ErrNotAllowed
@@ -113,7 +114,7 @@ func (s Service) UnblockUser(id uuid.UUID) (free int, err error) {
 		return 0, fmt.Errorf("run in transaction: %w", err)
 	}
 
-	if err := s.db.UnblockUser(id.String()); err != nil {
+	if err := s.db.UnblockUser(ctx, id.String(), nil); err != nil {
 		return 0, fmt.Errorf("unblock user %s: %w", id, err)
 	}
 
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,16 +9,17 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/vpngen/keydesk/keydesk/storage"
+	"github.com/vpngen/keydesk/vpnapi"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
-func (s Service) DeleteUser(id uuid.UUID, onlyBlock bool) (free int, err error) {
+func (s Service) DeleteUser(ctx context.Context, id uuid.UUID, onlyBlock bool) (free int, err error) {
 	err = s.db.RunInTransaction(func(brigade *storage.Brigade) error {
 		/*if brigade.Mode == storage.ModeBrigade {
 			return ErrNotAllowed
 		}*/ // TODO!!!!
 
-		err = s.deleteUser(brigade, id, onlyBlock)
+		err = s.deleteUser(ctx, brigade, id, onlyBlock)
 		if err != nil {
 			return fmt.Errorf("delete user %s: %w", id, err)
 		}
@@ -32,7 +34,7 @@ func (s Service) DeleteUser(id uuid.UUID, onlyBlock bool) (free int, err error)
 
 var ErrNotFound = errors.New("user not found")
 
-func (s Service) deleteUser(brigade *storage.Brigade, id uuid.UUID, onlyBlock bool) error {
+func (s Service) deleteUser(ctx context.Context, brigade *storage.Brigade, id uuid.UUID, onlyBlock bool) error {
 	var (
 		user *storage.User
 		idx  int
@@ -62,7 +64,7 @@ func (s Service) deleteUser(brigade *storage.Brigade, id uuid.UUID, onlyBlock bo
 	fmt.Fprintf(os.Stderr, "User status: blocked=%v\n", user.IsBlocked)
 
 	if !user.IsBlocked {
-		if err = s.epClient.PeerDel(usrPub, epPub); err != nil {
+		if err = s.epClient.PeerDel(ctx, vpnapi.PeerRequest{PeerPublicKey: usrPub[:], WgPublicKey: epPub[:]}); err != nil {
 			return fmt.Errorf("peer del: %w", err)
 		}
 
@@ -4,33 +4,25 @@ import (
 	"log"
 
 	"github.com/vpngen/keydesk/internal/vpn"
-	"github.com/vpngen/keydesk/internal/vpn/endpoint"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 	"github.com/vpngen/vpngine/naclkey"
 )
 
 type Service struct {
 	db        *storage.BrigadeStorage
-	epClient  endpoint.RealClient
+	epClient  vpnapi.EndpointClient
 	generator vpn.Generator
 }
 
+// New - the user service, the endpoint calls are logged by the logger if it's the API client.
 func New(db *storage.BrigadeStorage, routerPub, shufflerPub [naclkey.NaclBoxKeyLength]byte, logger *log.Logger) (Service, error) {
-	// TODO: mock client
-	//var epClient endpoint.Client
-	//if db.GetActualAddrPort().IsValid() {
-	//	epClient = endpoint.NewClient(db.GetActualAddrPort(), nil)
-	//} else {
-	//	epClient = endpoint.MockClient{
-	//		RealClient: endpoint.NewClient(db.GetCalculatedAddrPort(), nil),
-	//	}
-	//}
-	//if !db.GetActualAddrPort().IsValid() {
-	//	return Service{}, fmt.Errorf("invalid address: %s", db.GetActualAddrPort())
-	//}
+	client := db.EndpointClient()
+	if c, ok := client.(*vpnapi.Client); ok {
+		c.Logger = logger
+	}
 
-	client := endpoint.NewClient(db.GetActualAddrPort(), logger)
 	return Service{
 		db:       db,
 		epClient: client,
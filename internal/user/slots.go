@@ -3,7 +3,7 @@ package user
 import "github.com/vpngen/keydesk/keydesk/storage"
 
 func (s Service) GetSlotsInfo() (free int, total uint, err error) {
-	err = s.db.RunInTransaction(func(brigade *storage.Brigade) error {
+	err = s.db.ViewTransaction(func(brigade *storage.Brigade) error {
 		free, total = s.getSlotsInfo(brigade)
 		return nil
 	})
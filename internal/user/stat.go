@@ -24,7 +24,7 @@ type (
 )
 
 func (s Service) GetLastConnections() (Activities, error) {
-	users, err := s.db.ListUsers()
+	users, _, err := s.db.ListUsers()
 	if err != nil {
 		return nil, err
 	}
@@ -6,10 +6,11 @@ import (
 	"github.com/google/uuid"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
-func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData map[string]string) (Config, error) {
+func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData *vpnapi.PeerAddRequest) (Config, error) {
 	epPub, err := wgtypes.NewKey(brigade.WgPublicKey)
 	if err != nil {
 		return Config{}, fmt.Errorf("endpoint pub: %w", err)
@@ -22,7 +23,7 @@ func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epD
 		return Config{}, fmt.Errorf("encrypt: %w", err)
 	}
 
-	epData["cloak-uid"] = bypassenc.Router.Base64()
+	epData.CloakUID = bypassenc.Router.Base64()
 	user.CloakByPassUIDRouterEnc = bypassenc.Router.Base64()
 	user.CloakByPassUIDShufflerEnc = bypassenc.Shuffler.Base64()
 
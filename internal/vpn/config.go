@@ -1,8 +1,8 @@
 package vpn
 
 import (
-	"github.com/vpngen/keydesk/internal/vpn/endpoint"
 	"github.com/vpngen/keydesk/keydesk/storage"
+	"github.com/vpngen/keydesk/vpnapi"
 )
 
 type Config interface {
@@ -16,5 +16,5 @@ type Config interface {
 	Store(user *storage.User) error
 
 	// GetClientConfig returns the config for client connection
-	GetClientConfig(data endpoint.APIResponse) (any, error)
+	GetClientConfig(data vpnapi.PeerAddResponse) (any, error)
 }
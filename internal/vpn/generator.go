@@ -1,6 +1,7 @@
 package vpn
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -8,7 +9,6 @@ import (
 
 	"github.com/vpngen/keydesk/internal/vpn/amnezia"
 	"github.com/vpngen/keydesk/internal/vpn/cloak"
-	"github.com/vpngen/keydesk/internal/vpn/endpoint"
 	"github.com/vpngen/keydesk/internal/vpn/ipsec"
 	"github.com/vpngen/keydesk/internal/vpn/openvpn"
 	"github.com/vpngen/keydesk/internal/vpn/outline"
@@ -19,6 +19,7 @@ import (
 	"github.com/vpngen/keydesk/kdlib"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 )
 
 const (
@@ -41,7 +42,7 @@ var cfgProtocols = map[string][]string{
 
 type Generator struct {
 	NaCl   utils.NaCl
-	Client endpoint.RealClient
+	Client vpnapi.EndpointClient
 }
 
 type Configs struct {
@@ -64,7 +65,7 @@ type Protocols struct {
 
 const defaultInternalDNS = "100.126.0.1"
 
-func (g Generator) GenerateConfigs(brigade *storage.Brigade, user *storage.User, configs []string) (Configs, string, error) {
+func (g Generator) GenerateConfigs(ctx context.Context, brigade *storage.Brigade, user *storage.User, configs []string) (Configs, string, error) {
 	log.Println("generating configs:", configs)
 	protos2gen := make(utils.StringSet)
 	protos2gen.Add(ProtocolWireguard)
@@ -87,7 +88,7 @@ func (g Generator) GenerateConfigs(brigade *storage.Brigade, user *storage.User,
 		}
 	}
 
-	epData := make(map[string]string)
+	epData := &vpnapi.PeerAddRequest{}
 
 	protocolsObj := Protocols{}
 	for p := range protos2gen {
@@ -146,7 +147,7 @@ func (g Generator) GenerateConfigs(brigade *storage.Brigade, user *storage.User,
 	//	return Configs{}, fmt.Errorf("endpoint pub: %w", err)
 	//}
 
-	resp, err := g.Client.PeerAdd(protocolsObj.WireGuard.Key.PublicKey(), epData)
+	resp, err := g.Client.PeerAdd(ctx, *epData)
 	if err != nil {
 		return Configs{}, "", fmt.Errorf("peer add: %w", err)
 	}
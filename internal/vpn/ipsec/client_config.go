@@ -1,6 +1,6 @@
 package ipsec
 
-import "github.com/vpngen/keydesk/internal/vpn/endpoint"
+import "github.com/vpngen/keydesk/vpnapi"
 
 type ClientConfig struct {
 	Username string
@@ -9,7 +9,7 @@ type ClientConfig struct {
 	PSK      string
 }
 
-func (c Config) GetClientConfig(_ endpoint.APIResponse) (any, error) {
+func (c Config) GetClientConfig(_ vpnapi.PeerAddResponse) (any, error) {
 	return ClientConfig{
 		Username: c.username,
 		Password: c.password,
@@ -6,9 +6,10 @@ import (
 	"github.com/btcsuite/btcd/btcutil/base58"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 )
 
-func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData map[string]string) (ClientConfig, error) {
+func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData *vpnapi.PeerAddRequest) (ClientConfig, error) {
 	usernameRand := make([]byte, UsernameLen)
 	if _, err := rand.Read(usernameRand); err != nil {
 		return ClientConfig{}, fmt.Errorf("username rand: %w", err)
@@ -31,8 +32,8 @@ func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epD
 		return ClientConfig{}, fmt.Errorf("password seal: %w", err)
 	}
 
-	epData["l2tp-username"] = encUser.Router.Base64()
-	epData["l2tp-password"] = encPass.Router.Base64()
+	epData.L2TPUsername = encUser.Router.Base64()
+	epData.L2TPPassword = encPass.Router.Base64()
 
 	user.IPSecUsernameRouterEnc = encUser.Router.Base64()
 	user.IPSecUsernameShufflerEnc = encUser.Shuffler.Base64()
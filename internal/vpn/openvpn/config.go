@@ -9,13 +9,14 @@ import (
 	"github.com/vpngen/keydesk/kdlib"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 )
 
 func csrPemGzBase64(csr []byte) ([]byte, error) {
 	return kdlib.PemGzipBase64(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csr})
 }
 
-func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData map[string]string) (Config, error) {
+func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData *vpnapi.PeerAddRequest) (Config, error) {
 	cn := uuid.New()
 	csr, key, err := kdlib.NewOvClientCertRequest(cn.String())
 	if err != nil {
@@ -27,7 +28,7 @@ func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epD
 		return Config{}, fmt.Errorf("csr encode: %w", err)
 	}
 	user.OvCSRGzipBase64 = string(csrEnc)
-	epData["openvpn-client-csr"] = string(csrEnc)
+	epData.OpenVPNCSR = string(csrEnc)
 
 	keyPem, err := keyPEM(key)
 	if err != nil {
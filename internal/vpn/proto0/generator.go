@@ -7,9 +7,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 )
 
-func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData map[string]string) (*Config, error) {
+func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData *vpnapi.PeerAddRequest) (*Config, error) {
 	longID := uuid.New().String()
 	shortID := strings.ReplaceAll(uuid.New().String(), "-", "")[:12]
 
@@ -20,7 +21,7 @@ func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epD
 		return nil, fmt.Errorf("encrypt: %w", err)
 	}
 
-	epData["p0-id"] = secretenc.Router.Base64()
+	epData.Proto0Secret = secretenc.Router.Base64()
 	user.Proto0SecretRouterEnc = secretenc.Router.Base64()
 	user.Proto0SecretShufflerEnc = secretenc.Shuffler.Base64()
 
@@ -6,9 +6,10 @@ import (
 	"github.com/btcsuite/btcd/btcutil/base58"
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 )
 
-func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData map[string]string) (Config, error) {
+func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData *vpnapi.PeerAddRequest) (Config, error) {
 	secretRand := make([]byte, SecretLen)
 	if _, err := rand.Read(secretRand); err != nil {
 		return Config{}, fmt.Errorf("secret rand: %w", err)
@@ -20,7 +21,7 @@ func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epD
 		return Config{}, fmt.Errorf("encrypt: %w", err)
 	}
 
-	epData["outline-ss-password"] = secretenc.Router.Base64()
+	epData.OutlineSecret = secretenc.Router.Base64()
 	user.OutlineSecretRouterEnc = secretenc.Router.Base64()
 	user.OutlineSecretShufflerEnc = secretenc.Shuffler.Base64()
 
@@ -7,7 +7,7 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/vpngen/keydesk/internal/vpn/endpoint"
+	"github.com/vpngen/keydesk/vpnapi"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
@@ -39,7 +39,7 @@ type (
 	}
 )
 
-func (c RawConfig) HandleEndpointAPIResponse(resp endpoint.APIResponse) error {
+func (c RawConfig) HandleEndpointAPIResponse(resp vpnapi.PeerAddResponse) error {
 	return nil
 }
 
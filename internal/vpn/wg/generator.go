@@ -6,10 +6,11 @@ import (
 
 	"github.com/vpngen/keydesk/keydesk/storage"
 	"github.com/vpngen/keydesk/utils"
+	"github.com/vpngen/keydesk/vpnapi"
 	"golang.zx2c4.com/wireguard/wgctrl/wgtypes"
 )
 
-func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData map[string]string) (RawConfig, error) {
+func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epData *vpnapi.PeerAddRequest) (RawConfig, error) {
 	// generate keys
 	epPub, err := wgtypes.NewKey(brigade.WgPublicKey)
 	if err != nil {
@@ -51,12 +52,14 @@ func Generate(brigade *storage.Brigade, user *storage.User, nacl utils.NaCl, epD
 	}
 
 	// add endpoint data
-	epData["wg-public-key"] = epPub.String()
-	epData["wg-psk-key"] = pskenc.Router.Base64()
-	epData["allowed-ips"] = wgcfg.GetAddresses()
+	pkey := key.PublicKey()
+
+	epData.PeerPublicKey = pkey[:]
+	epData.WgPublicKey = epPub[:]
+	epData.WgPSK = pskenc.Router
+	epData.AllowedIPs = wgcfg.Address
 
 	// add user data
-	pkey := key.PublicKey()
 	user.WgPublicKey = pkey[:]
 	user.WgPSKRouterEnc = pskenc.Router
 	user.WgPSKShufflerEnc = pskenc.Shuffler
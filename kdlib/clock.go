@@ -0,0 +1,28 @@
+package kdlib
+
+import "time"
+
+// Clock - the time source, replaced by the fixed one in the tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc - the function as a clock.
+type ClockFunc func() time.Time
+
+// Now - the clock time.
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+// SystemClock - the wall clock.
+var SystemClock Clock = ClockFunc(time.Now)
+
+// Now - the clock time, nil means the system clock.
+func Now(c Clock) time.Time {
+	if c == nil {
+		return time.Now()
+	}
+
+	return c.Now()
+}
@@ -4,5 +4,29 @@ import "time"
 
 // NextMonthlyResetOn - returns the time of the next monthly reset LimitMonthlyResetOn value.
 func NextMonthlyResetOn(now time.Time) time.Time {
-	return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+	return NextMonthlyResetOnDay(now, 1)
+}
+
+// NextMonthlyResetOnDay - returns the time of the next monthly reset on the day of month.
+// The day is clamped to the last day of the shorter months.
+func NextMonthlyResetOnDay(now time.Time, day int) time.Time {
+	if day < 1 {
+		day = 1
+	}
+
+	resetOn := monthDay(now.Year(), now.Month(), day)
+	if !resetOn.After(now) {
+		resetOn = monthDay(now.Year(), now.Month()+1, day)
+	}
+
+	return resetOn
+}
+
+func monthDay(year int, month time.Month, day int) time.Time {
+	// the zero day of the next month is the last day of this one.
+	if last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); day > last {
+		day = last
+	}
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
 }
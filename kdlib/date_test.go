@@ -0,0 +1,38 @@
+package kdlib
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextMonthlyResetOnDay(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	for _, tc := range []struct {
+		now  time.Time
+		day  int
+		want time.Time
+	}{
+		{date(2024, 3, 15).Add(time.Hour), 1, date(2024, 4, 1)},
+		{date(2024, 3, 1), 1, date(2024, 4, 1)},
+		{date(2024, 3, 10), 20, date(2024, 3, 20)},
+		{date(2024, 3, 20), 20, date(2024, 4, 20)},
+		{date(2024, 1, 31), 31, date(2024, 2, 29)},
+		{date(2023, 2, 10), 31, date(2023, 2, 28)},
+		{date(2024, 4, 30), 31, date(2024, 5, 31)},
+		{date(2024, 12, 5), 5, date(2025, 1, 5)},
+		{date(2024, 3, 15), 0, date(2024, 4, 1)},
+	} {
+		if got := NextMonthlyResetOnDay(tc.now, tc.day); !got.Equal(tc.want) {
+			t.Errorf("%s day %d: got %s, want %s", tc.now, tc.day, got, tc.want)
+		}
+
+		if tc.day == 1 {
+			if got := NextMonthlyResetOn(tc.now); !got.Equal(tc.want) {
+				t.Errorf("%s: got %s, want %s", tc.now, got, tc.want)
+			}
+		}
+	}
+}
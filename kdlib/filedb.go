@@ -2,9 +2,11 @@ package kdlib
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
+	"time"
 
 	"github.com/vpngen/keydesk/kdlib/lockedfile"
 )
@@ -14,6 +16,13 @@ const (
 	fileDbBackupSuffix = ".bak"
 )
 
+// Commit replaces the main file with remove+link,
+// a reader can meet the missing main file between them.
+const (
+	fileDbReadRetries = 50
+	fileDbReadDelay   = 10 * time.Millisecond
+)
+
 // FileDb - file pair as Db.
 type FileDb struct {
 	name   string
@@ -106,3 +115,37 @@ func OpenFileDb(name, spinlock string, perm fs.FileMode) (*FileDb, error) {
 		unlock: unlock,
 	}, nil
 }
+
+// FileDbReader - main file of the file pair opened to read.
+type FileDbReader struct {
+	r *lockedfile.File
+}
+
+// Decoder - get json.Decoder (main file).
+func (f *FileDbReader) Decoder() *json.Decoder {
+	return json.NewDecoder(f.r)
+}
+
+// Close - close the main file and release the READ-LOCK.
+func (f *FileDbReader) Close() error {
+	return f.r.Close()
+}
+
+// OpenFileDbReadOnly - open the main file with the READ-LOCK only.
+// The spinlock and the temporary file are not touched, so readers
+// don't block each other and the editing process, which holds
+// the READ-LOCK on the main file too. The reader sees the main file
+// as it was before or after the commit, never a partial one.
+func OpenFileDbReadOnly(name string) (*FileDbReader, error) {
+	for i := 0; ; i++ {
+		r, err := lockedfile.OpenFile(name, os.O_RDONLY, 0)
+		switch {
+		case err == nil:
+			return &FileDbReader{r: r}, nil
+		case errors.Is(err, fs.ErrNotExist) && i < fileDbReadRetries:
+			time.Sleep(fileDbReadDelay)
+		default:
+			return nil, fmt.Errorf("read main: %w", err)
+		}
+	}
+}
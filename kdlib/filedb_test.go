@@ -0,0 +1,128 @@
+package kdlib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestOpenFileDbReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "brigade.json")
+	spinlock := filepath.Join(dir, "brigade.lock")
+
+	if err := os.WriteFile(name, []byte(`{"v":1}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := OpenFileDbReadOnly(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the second reader and the editor don't wait for the first reader.
+	done := make(chan error, 1)
+	go func() {
+		second, err := OpenFileDbReadOnly(name)
+		if err != nil {
+			done <- err
+
+			return
+		}
+
+		v := map[string]int{}
+		err = second.Decoder().Decode(&v)
+		second.Close()
+
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("second reader: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("second reader is blocked")
+	}
+
+	go func() {
+		f, err := OpenFileDb(name, spinlock, 0o600)
+		if err != nil {
+			done <- err
+
+			return
+		}
+
+		if err := f.Encoder("", "").Encode(map[string]int{"v": 2}); err != nil {
+			f.Close()
+			done <- err
+
+			return
+		}
+
+		err = f.Commit()
+		f.Close()
+
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("editor: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("editor is blocked by the reader")
+	}
+
+	// the first reader still sees the file as it was opened.
+	v := map[string]int{}
+	if err := first.Decoder().Decode(&v); err != nil || v["v"] != 1 {
+		t.Errorf("first reader: %v %v", v, err)
+	}
+
+	first.Close()
+
+	committed, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if os.SameFile(before, committed) {
+		t.Error("editor commit is not visible")
+	}
+
+	// readers never rewrite the file.
+	for i := 0; i < 3; i++ {
+		r, err := OpenFileDbReadOnly(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r.Close()
+	}
+
+	after, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !os.SameFile(committed, after) || !committed.ModTime().Equal(after.ModTime()) || committed.Size() != after.Size() {
+		t.Error("readers touched the file")
+	}
+
+	if _, err := os.Stat(name + fileDbTempSuffix); !os.IsNotExist(err) {
+		t.Errorf("temp file left: %v", err)
+	}
+
+	if _, err := OpenFileDbReadOnly(filepath.Join(dir, "absent.json")); err == nil {
+		t.Error("absent file is opened")
+	}
+}
@@ -0,0 +1,152 @@
+package kdlib
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	snapshotSuffix     = ".gz"
+	snapshotTimeLayout = "20060102T150405Z"
+)
+
+// SnapshotRetention - snapshots retention policy.
+// Zero Last and Daily turn snapshots off.
+type SnapshotRetention struct {
+	Last     int           // keep N last snapshots
+	Daily    int           // keep the newest snapshot of each of D last days
+	Interval time.Duration // minimal interval between snapshots
+}
+
+// Enabled - snapshots are on.
+func (r SnapshotRetention) Enabled() bool {
+	return r.Last > 0 || r.Daily > 0
+}
+
+// Snapshot - timestamped gzip-compressed copy of a file db.
+type Snapshot struct {
+	Filename string    // i.e. /home/<BrigadeID>/brigade.json.20240101T000000Z.gz
+	Time     time.Time // UTC
+}
+
+// ListSnapshots - snapshots of the named file, the newest first.
+func ListSnapshots(name string) ([]Snapshot, error) {
+	dir, base := filepath.Dir(name), filepath.Base(name)+"."
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read dir: %w", err)
+	}
+
+	var list []Snapshot
+
+	for _, e := range entries {
+		fn := e.Name()
+		if e.IsDir() || !strings.HasPrefix(fn, base) || !strings.HasSuffix(fn, snapshotSuffix) {
+			continue
+		}
+
+		ts, err := time.Parse(snapshotTimeLayout, strings.TrimSuffix(strings.TrimPrefix(fn, base), snapshotSuffix))
+		if err != nil {
+			continue
+		}
+
+		list = append(list, Snapshot{Filename: filepath.Join(dir, fn), Time: ts})
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Time.After(list[j].Time) })
+
+	return list, nil
+}
+
+// WriteSnapshot - write gzip-compressed data to <name>.<timestamp>.gz.
+func WriteSnapshot(name string, now time.Time, data []byte, perm fs.FileMode) (string, error) {
+	filename := name + "." + now.UTC().Format(snapshotTimeLayout) + snapshotSuffix
+
+	buf, err := Gzip(data)
+	if err != nil {
+		return "", fmt.Errorf("gzip: %w", err)
+	}
+
+	tmp := filename + fileDbTempSuffix
+	if err := os.WriteFile(tmp, buf, perm); err != nil {
+		return "", fmt.Errorf("write: %w", err)
+	}
+
+	if err := os.Rename(tmp, filename); err != nil {
+		os.Remove(tmp)
+
+		return "", fmt.Errorf("rename: %w", err)
+	}
+
+	return filename, nil
+}
+
+// ReadSnapshot - read and ungzip the snapshot.
+func ReadSnapshot(filename string) ([]byte, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, r); err != nil {
+		return nil, fmt.Errorf("ungzip: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PruneSnapshots - remove the snapshots out of the retention, returns removed files.
+func PruneSnapshots(name string, now time.Time, keep SnapshotRetention) ([]string, error) {
+	list, err := ListSnapshots(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		removed []string
+		days    = make(map[string]struct{})
+		since   = now.UTC().AddDate(0, 0, -keep.Daily)
+	)
+
+	for i, s := range list {
+		// the list is the newest first, so the first one of the day is kept.
+		day := s.Time.Format(time.DateOnly)
+
+		if i < keep.Last {
+			days[day] = struct{}{}
+
+			continue
+		}
+
+		if _, ok := days[day]; !ok && keep.Daily > 0 && s.Time.After(since) {
+			days[day] = struct{}{}
+
+			continue
+		}
+
+		if err := os.Remove(s.Filename); err != nil {
+			return removed, fmt.Errorf("remove: %w", err)
+		}
+
+		removed = append(removed, s.Filename)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,55 @@
+package kdlib
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPruneSnapshots(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "brigade.json")
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	// 3 snapshots a day for 5 days.
+	for d := range 5 {
+		for h := range 3 {
+			ts := now.AddDate(0, 0, -d).Add(-time.Duration(h) * time.Hour)
+			if _, err := WriteSnapshot(name, ts, []byte(`{}`), 0o644); err != nil {
+				t.Fatalf("write: %s", err)
+			}
+		}
+	}
+
+	removed, err := PruneSnapshots(name, now, SnapshotRetention{Last: 2, Daily: 3})
+	if err != nil {
+		t.Fatalf("prune: %s", err)
+	}
+
+	list, err := ListSnapshots(name)
+	if err != nil {
+		t.Fatalf("list: %s", err)
+	}
+
+	// 2 last of today, the newest of 2 previous days (3 days back is out).
+	want := []time.Time{
+		now,
+		now.Add(-time.Hour),
+		now.AddDate(0, 0, -1),
+		now.AddDate(0, 0, -2),
+	}
+
+	if len(list) != len(want) || len(removed) != 15-len(want) {
+		t.Fatalf("kept %d, removed %d", len(list), len(removed))
+	}
+
+	for i, s := range list {
+		if !s.Time.Equal(want[i]) {
+			t.Errorf("snapshot %d: %s, want %s", i, s.Time, want[i])
+		}
+	}
+
+	buf, err := ReadSnapshot(list[0].Filename)
+	if err != nil || string(buf) != `{}` {
+		t.Errorf("read: %q, %v", buf, err)
+	}
+}
@@ -1,6 +1,7 @@
 package keydesk
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/base64"
@@ -19,6 +20,7 @@ const DefaultProto0Port = 443
 
 // CreateBrigade - create brigadier user.
 func CreateBrigade(
+	ctx context.Context,
 	db *storage.BrigadeStorage,
 	vpnCfgs *storage.ConfigsImplemented,
 	config *storage.BrigadeConfig,
@@ -72,7 +74,7 @@ func CreateBrigade(
 		proto0Conf = GenEndpointProto0Creds(proto0FakeDomain, 0)
 	}
 
-	err = db.CreateBrigade(config, wgConf, ovcConf, cloakConf, ipsecConf, outlineConf, proto0Conf, mode, maxUsers, vip)
+	err = db.CreateBrigade(ctx, config, wgConf, ovcConf, cloakConf, ipsecConf, outlineConf, proto0Conf, mode, maxUsers, vip)
 	if err != nil {
 		return fmt.Errorf("put: %w", err)
 	}
@@ -83,12 +85,12 @@ func CreateBrigade(
 var ErrDestroyVIP = fmt.Errorf("destroy VIP brigade")
 
 // DestroyBrigade - destroy brigadier user.
-func DestroyBrigade(db *storage.BrigadeStorage, force bool) error {
+func DestroyBrigade(ctx context.Context, db *storage.BrigadeStorage, force bool) error {
 	if db.IsVIP() {
 		return fmt.Errorf("%w: %s", ErrDestroyVIP, db.BrigadeID)
 	}
 
-	if err := db.DestroyBrigade(); err != nil {
+	if err := db.DestroyBrigade(ctx); err != nil {
 		return fmt.Errorf("remove: %w", err)
 	}
 
@@ -0,0 +1,239 @@
+package keydesk
+
+import (
+	"crypto"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/vpngen/keydesk/kdlib"
+	"github.com/vpngen/keydesk/keydesk/storage"
+	"github.com/vpngen/vpngine/naclkey"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// BundleVersion - bundle json version.
+const BundleVersion = 1
+
+var (
+	// ErrBundleVersion - unknown bundle version.
+	ErrBundleVersion = errors.New("unknown bundle version")
+	// ErrBundleSignature - signature is not valid.
+	ErrBundleSignature = errors.New("invalid bundle signature")
+	// ErrBundleSecret - the secret can't be opened with the old router key.
+	ErrBundleSecret = errors.New("can't open secret")
+)
+
+// Bundle - signed self-contained brigade export:
+// the brigade with users, messages and counters.
+type Bundle struct {
+	Ver        int       `json:"version"`
+	BrigadeID  string    `json:"brigade_id"`
+	ExportedAt time.Time `json:"exported_at"`
+	Alg        string    `json:"alg"`
+	KeyID      string    `json:"key_id"`
+	Payload    string    `json:"payload"` // base64 gzipped brigade json
+	Signature  string    `json:"signature"`
+}
+
+func (b *Bundle) signingString() string {
+	return fmt.Sprintf("%d.%s.%s.%s", b.Ver, b.BrigadeID, b.ExportedAt.UTC().Format(time.RFC3339Nano), b.Payload)
+}
+
+// NewBundle - pack and sign the brigade.
+func NewBundle(data *storage.Brigade, method gojwt.SigningMethod, key crypto.PrivateKey, keyID string) (*Bundle, error) {
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+
+	payload, err := kdlib.GzipBase64(buf)
+	if err != nil {
+		return nil, fmt.Errorf("gzip: %w", err)
+	}
+
+	b := &Bundle{
+		Ver:        BundleVersion,
+		BrigadeID:  data.BrigadeID,
+		ExportedAt: time.Now().UTC(),
+		Alg:        method.Alg(),
+		KeyID:      keyID,
+		Payload:    string(payload),
+	}
+
+	sig, err := method.Sign(b.signingString(), key)
+	if err != nil {
+		return nil, fmt.Errorf("sign: %w", err)
+	}
+
+	b.Signature = base64.RawURLEncoding.EncodeToString(sig)
+
+	return b, nil
+}
+
+// Open - verify the signature and unpack the brigade.
+func (b *Bundle) Open(method gojwt.SigningMethod, key crypto.PublicKey) (*storage.Brigade, error) {
+	if b.Ver != BundleVersion {
+		return nil, fmt.Errorf("%d: %w", b.Ver, ErrBundleVersion)
+	}
+
+	if b.Alg != method.Alg() {
+		return nil, fmt.Errorf("alg %s: %w", b.Alg, ErrBundleSignature)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(b.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+
+	if err := method.Verify(b.signingString(), sig, key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBundleSignature, err)
+	}
+
+	buf, err := kdlib.Unbase64Ungzip(b.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+
+	data := &storage.Brigade{}
+	if err := json.Unmarshal(buf, data); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if data.BrigadeID != b.BrigadeID {
+		return nil, fmt.Errorf("check: %w", storage.ErrUnknownBrigade)
+	}
+
+	return data, nil
+}
+
+// RelocateBrigade - rewrite the node specific addresses.
+func RelocateBrigade(data *storage.Brigade, endpointIPv4, keydeskIPv6 netip.Addr) {
+	if endpointIPv4.IsValid() {
+		data.EndpointIPv4 = endpointIPv4
+	}
+
+	if keydeskIPv6.IsValid() {
+		data.KeydeskIPv6 = keydeskIPv6
+	}
+
+	// they are from the old node.
+	data.Endpoints = nil
+	data.Geo = nil
+}
+
+// resealer - opens secrets with the old router key and seals them for the new keys.
+type resealer struct {
+	oldPriv, oldPub, routerPub, shufflerPub *[naclkey.NaclBoxKeyLength]byte
+}
+
+func (r *resealer) reseal(routerEnc []byte) ([]byte, []byte, error) {
+	secret, ok := box.OpenAnonymous(nil, routerEnc, r.oldPub, r.oldPriv)
+	if !ok {
+		return nil, nil, ErrBundleSecret
+	}
+
+	router, err := box.SealAnonymous(nil, secret, r.routerPub, rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("router seal: %w", err)
+	}
+
+	shuffler, err := box.SealAnonymous(nil, secret, r.shufflerPub, rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("shuffler seal: %w", err)
+	}
+
+	return router, shuffler, nil
+}
+
+func (r *resealer) bytes(routerEnc, shufflerEnc *[]byte) error {
+	if len(*routerEnc) == 0 {
+		return nil
+	}
+
+	router, shuffler, err := r.reseal(*routerEnc)
+	if err != nil {
+		return err
+	}
+
+	*routerEnc, *shufflerEnc = router, shuffler
+
+	return nil
+}
+
+func (r *resealer) base64(routerEnc, shufflerEnc *string) error {
+	if *routerEnc == "" {
+		return nil
+	}
+
+	buf, err := base64.StdEncoding.WithPadding(base64.StdPadding).DecodeString(*routerEnc)
+	if err != nil {
+		return fmt.Errorf("base64: %w", err)
+	}
+
+	router, shuffler, err := r.reseal(buf)
+	if err != nil {
+		return err
+	}
+
+	*routerEnc = base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(router)
+	*shufflerEnc = base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(shuffler)
+
+	return nil
+}
+
+// ResealBrigade - re-encrypt all the brigade and users secrets
+// for the new router and shuffler keys, the old router key opens them.
+func ResealBrigade(data *storage.Brigade, oldRouterPrivateKey, oldRouterPublicKey, routerPublicKey, shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte) error {
+	r := &resealer{
+		oldPriv:     oldRouterPrivateKey,
+		oldPub:      oldRouterPublicKey,
+		routerPub:   routerPublicKey,
+		shufflerPub: shufflerPublicKey,
+	}
+
+	if err := r.bytes(&data.WgPrivateRouterEnc, &data.WgPrivateShufflerEnc); err != nil {
+		return fmt.Errorf("wg private: %w", err)
+	}
+
+	if err := r.base64(&data.OvCAKeyRouterEnc, &data.OvCAKeyShufflerEnc); err != nil {
+		return fmt.Errorf("openvpn ca key: %w", err)
+	}
+
+	if err := r.base64(&data.IPSecPSKRouterEnc, &data.IPSecPSKShufflerEnc); err != nil {
+		return fmt.Errorf("ipsec psk: %w", err)
+	}
+
+	for _, user := range data.Users {
+		if err := r.bytes(&user.WgPSKRouterEnc, &user.WgPSKShufflerEnc); err != nil {
+			return fmt.Errorf("user %s: wg psk: %w", user.UserID, err)
+		}
+
+		if err := r.base64(&user.CloakByPassUIDRouterEnc, &user.CloakByPassUIDShufflerEnc); err != nil {
+			return fmt.Errorf("user %s: cloak uid: %w", user.UserID, err)
+		}
+
+		if err := r.base64(&user.IPSecUsernameRouterEnc, &user.IPSecUsernameShufflerEnc); err != nil {
+			return fmt.Errorf("user %s: ipsec username: %w", user.UserID, err)
+		}
+
+		if err := r.base64(&user.IPSecPasswordRouterEnc, &user.IPSecPasswordShufflerEnc); err != nil {
+			return fmt.Errorf("user %s: ipsec password: %w", user.UserID, err)
+		}
+
+		if err := r.base64(&user.OutlineSecretRouterEnc, &user.OutlineSecretShufflerEnc); err != nil {
+			return fmt.Errorf("user %s: outline secret: %w", user.UserID, err)
+		}
+
+		if err := r.base64(&user.Proto0SecretRouterEnc, &user.Proto0SecretShufflerEnc); err != nil {
+			return fmt.Errorf("user %s: proto0 secret: %w", user.UserID, err)
+		}
+	}
+
+	return nil
+}
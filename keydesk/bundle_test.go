@@ -0,0 +1,78 @@
+package keydesk
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/netip"
+	"testing"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/vpngen/keydesk/keydesk/storage"
+	"golang.org/x/crypto/nacl/box"
+)
+
+func TestBundleReseal(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldPub, oldPriv, _ := box.GenerateKey(rand.Reader)
+	newPub, newPriv, _ := box.GenerateKey(rand.Reader)
+	shufflerPub, shufflerPriv, _ := box.GenerateKey(rand.Reader)
+
+	secret := []byte("secret")
+
+	wgEnc, _ := box.SealAnonymous(nil, secret, oldPub, rand.Reader)
+	pskEnc, _ := box.SealAnonymous(nil, secret, oldPub, rand.Reader)
+
+	data := &storage.Brigade{
+		BrigadeID:          "TESTBRIGADE",
+		EndpointIPv4:       netip.MustParseAddr("192.0.2.1"),
+		WgPrivateRouterEnc: wgEnc,
+		IPSecPSKRouterEnc:  base64.StdEncoding.EncodeToString(pskEnc),
+		Endpoints:          storage.UsersNetworks{"192.0.2.0/24": time.Now()},
+	}
+
+	bundle, err := NewBundle(data, gojwt.SigningMethodEdDSA, priv, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := bundle.Open(gojwt.SigningMethodEdDSA, pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ResealBrigade(got, oldPriv, oldPub, newPub, shufflerPub); err != nil {
+		t.Fatal(err)
+	}
+
+	RelocateBrigade(got, netip.MustParseAddr("198.51.100.1"), netip.Addr{})
+
+	if got.EndpointIPv4.String() != "198.51.100.1" || got.Endpoints != nil {
+		t.Errorf("relocate: %s %v", got.EndpointIPv4, got.Endpoints)
+	}
+
+	if s, ok := box.OpenAnonymous(nil, got.WgPrivateRouterEnc, newPub, newPriv); !ok || string(s) != string(secret) {
+		t.Errorf("wg private router: %q %v", s, ok)
+	}
+
+	if s, ok := box.OpenAnonymous(nil, got.WgPrivateShufflerEnc, shufflerPub, shufflerPriv); !ok || string(s) != string(secret) {
+		t.Errorf("wg private shuffler: %q %v", s, ok)
+	}
+
+	buf, _ := base64.StdEncoding.DecodeString(got.IPSecPSKShufflerEnc)
+	if s, ok := box.OpenAnonymous(nil, buf, shufflerPub, shufflerPriv); !ok || string(s) != string(secret) {
+		t.Errorf("ipsec psk shuffler: %q %v", s, ok)
+	}
+
+	// tampered payload.
+	bundle.BrigadeID = "OTHERBRIGADE"
+	if _, err := bundle.Open(gojwt.SigningMethodEdDSA, pub); !errors.Is(err, ErrBundleSignature) {
+		t.Errorf("tampered: %v", err)
+	}
+}
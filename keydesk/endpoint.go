@@ -0,0 +1,53 @@
+package keydesk
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-openapi/swag"
+	"github.com/vpngen/keydesk/gen/models"
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+// EndpointStatus - the HTTP status of the endpoint API failure, 0 - not an endpoint failure.
+// The existing peer is not a failure, peer_add takes it as done.
+func EndpointStatus(err error) int {
+	switch {
+	case errors.Is(err, vpnapi.ErrBadKey):
+		return http.StatusInternalServerError
+	case errors.Is(err, vpnapi.ErrRetryable):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, vpnapi.ErrPermanent):
+		return http.StatusBadGateway
+	}
+
+	return 0
+}
+
+// EndpointMessage - the endpoint API failure message, the catalogue text and the API code
+// only, the endpoint error details are not passed on. Empty - not an endpoint failure.
+func EndpointMessage(err error) string {
+	codeErr := &vpnapi.CodeError{}
+	if !errors.As(err, &codeErr) {
+		return ""
+	}
+
+	msg := "endpoint: " + codeErr.Text
+
+	apiErr := &vpnapi.APIResponse{}
+	if errors.As(err, &apiErr) {
+		msg += ", code " + apiErr.Code
+	}
+
+	return msg
+}
+
+// endpointError - the error payload of the endpoint API failure.
+func endpointError(status int, err error) *models.Error {
+	msg := EndpointMessage(err)
+	if msg == "" {
+		msg = http.StatusText(status)
+	}
+
+	return &models.Error{Code: int64(status), Message: swag.String(msg)}
+}
@@ -0,0 +1,35 @@
+package keydesk
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+func TestEndpointStatus(t *testing.T) {
+	for _, tc := range []struct {
+		err    error
+		status int
+	}{
+		{fmt.Errorf("peer del: api: %w", &vpnapi.APIResponse{Code: "128"}), http.StatusBadGateway},
+		{fmt.Errorf("peer del: peer key: %w: 0 bytes", vpnapi.ErrBadKey), http.StatusInternalServerError},
+		{fmt.Errorf("peer del: api: %w", &vpnapi.APIResponse{Code: "999"}), http.StatusServiceUnavailable},
+		{fmt.Errorf("peer del: api: %w: resp code: 500", vpnapi.ErrUnavailable), http.StatusServiceUnavailable},
+		{errors.New("db: locked"), 0},
+	} {
+		if status := EndpointStatus(tc.err); status != tc.status {
+			t.Errorf("%s: %d, want %d", tc.err, status, tc.status)
+		}
+	}
+
+	if msg := *endpointError(http.StatusBadGateway, &vpnapi.APIResponse{Code: "999", Message: "secret detail"}).Message; msg != "endpoint: unknown code, code 999" {
+		t.Errorf("message: %s", msg)
+	}
+
+	if msg := EndpointMessage(fmt.Errorf("wg del: api: %w", &vpnapi.APIResponse{Code: "146"})); msg != "endpoint: deletion in progress, code 146" {
+		t.Errorf("catalogue message: %s", msg)
+	}
+}
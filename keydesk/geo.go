@@ -0,0 +1,52 @@
+package keydesk
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/strfmt/conv"
+	"github.com/go-openapi/swag"
+	"github.com/vpngen/keydesk/gen/models"
+	"github.com/vpngen/keydesk/gen/restapi/operations"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// GetUsersStatsGeo - the active users by countries and autonomous systems of the last stats round.
+func GetUsersStatsGeo(db *storage.BrigadeStorage, params operations.GetUsersStatsGeoParams, principal interface{}) middleware.Responder {
+	geo, err := db.GetGeoSummary()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Geo summary: %s\n", err)
+
+		return operations.NewGetUsersStatsGeoDefault(500)
+	}
+
+	if geo == nil {
+		return operations.NewGetUsersStatsGeoNotFound()
+	}
+
+	stats := &models.GeoStats{
+		UpdateTime: conv.DateTime(strfmt.DateTime(geo.Update)),
+		Countries:  make([]*models.GeoCountry, len(geo.Countries)),
+		ASNs:       make([]*models.GeoAsn, len(geo.ASNs)),
+		Unknown:    swag.Int64(int64(geo.Unknown)),
+	}
+
+	for i, c := range geo.Countries {
+		stats.Countries[i] = &models.GeoCountry{
+			Country: swag.String(c.Country),
+			Users:   swag.Int64(int64(c.Users)),
+		}
+	}
+
+	for i, as := range geo.ASNs {
+		stats.ASNs[i] = &models.GeoAsn{
+			ASN:   swag.Int64(int64(as.ASN)),
+			Org:   as.Org,
+			Users: swag.Int64(int64(as.Users)),
+		}
+	}
+
+	return operations.NewGetUsersStatsGeoOK().WithPayload(stats)
+}
@@ -0,0 +1,24 @@
+package keydesk
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/swag"
+)
+
+func TestQuotaLimits(t *testing.T) {
+	limits, err := quotaLimits(swag.Int64(MaxMonthlyQuotaGB), swag.Int64(0))
+	if err != nil || *limits.Monthly != MaxMonthlyQuotaGB<<30 || *limits.ResetDay != 0 {
+		t.Errorf("max: %+v, %v", limits, err)
+	}
+
+	// the bytes wrap to zero, the user would be throttled at once.
+	if _, err := quotaLimits(swag.Int64(MaxMonthlyQuotaGB+1), nil); !errors.Is(err, ErrQuotaTooLarge) {
+		t.Errorf("over max: %v", err)
+	}
+
+	if limits, err := quotaLimits(nil, nil); err != nil || limits.Monthly != nil || limits.ResetDay != nil {
+		t.Errorf("empty: %+v, %v", limits, err)
+	}
+}
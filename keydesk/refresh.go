@@ -0,0 +1,47 @@
+package keydesk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/vpngen/keydesk/gen/restapi/operations"
+	"github.com/vpngen/keydesk/keydesk/storage"
+)
+
+// ErrStatsRefreshTooOften - the on demand stats refresh is rate-limited.
+var ErrStatsRefreshTooOften = errors.New("stats refresh too often")
+
+// StatsRefresher - collects the stats on demand.
+type StatsRefresher interface {
+	Refresh(ctx context.Context) error
+}
+
+// RefreshUsersStats - collect the stats now and return the refreshed ones.
+// The nil refresher means the stats are not collected by this keydesk.
+func RefreshUsersStats(db *storage.BrigadeStorage, refresher StatsRefresher, params operations.PostUsersStatsRefreshParams, principal interface{}) middleware.Responder {
+	if refresher == nil {
+		return operations.NewPostUsersStatsRefreshDefault(503)
+	}
+
+	if err := refresher.Refresh(params.HTTPRequest.Context()); err != nil {
+		fmt.Fprintf(os.Stderr, "Stats refresh: %s\n", err)
+
+		if errors.Is(err, ErrStatsRefreshTooOften) {
+			return operations.NewPostUsersStatsRefreshTooManyRequests()
+		}
+
+		return operations.NewPostUsersStatsRefreshDefault(500)
+	}
+
+	stats, err := usersStats(db)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Stats error: %s\n", err)
+
+		return operations.NewPostUsersStatsRefreshDefault(500)
+	}
+
+	return operations.NewPostUsersStatsRefreshOK().WithPayload(stats)
+}
@@ -0,0 +1,50 @@
+package keydesk
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/swag"
+	"github.com/vpngen/keydesk/gen/models"
+)
+
+// ErrInvalidETag - If-Match is not a brigade revision.
+var ErrInvalidETag = errors.New("invalid etag")
+
+// revisionETag - the brigade revision as a strong ETag.
+func revisionETag(revision uint64) string {
+	return strconv.Quote(strconv.FormatUint(revision, 10))
+}
+
+// ifMatchRevision - the brigade revision from If-Match, nil means any.
+func ifMatchRevision(ifMatch *string) (*uint64, error) {
+	if ifMatch == nil {
+		return nil, nil
+	}
+
+	tag := strings.TrimSpace(*ifMatch)
+	if tag == "*" {
+		return nil, nil
+	}
+
+	tag = strings.TrimPrefix(tag, "W/")
+
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		return nil, ErrInvalidETag
+	}
+
+	revision, err := strconv.ParseUint(unquoted, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidETag
+	}
+
+	return &revision, nil
+}
+
+// invalidETagError - the payload of the malformed If-Match, it is the client error.
+func invalidETagError() *models.Error {
+	return &models.Error{Code: http.StatusBadRequest, Message: swag.String(ErrInvalidETag.Error())}
+}
@@ -0,0 +1,33 @@
+package keydesk
+
+import (
+	"testing"
+
+	"github.com/go-openapi/swag"
+)
+
+func TestIfMatchRevision(t *testing.T) {
+	for _, tc := range []struct {
+		ifMatch *string
+		want    *uint64
+		err     bool
+	}{
+		{ifMatch: nil},
+		{ifMatch: swag.String("*")},
+		{ifMatch: swag.String(revisionETag(42)), want: swag.Uint64(42)},
+		{ifMatch: swag.String(`W/"7"`), want: swag.Uint64(7)},
+		{ifMatch: swag.String("42"), err: true},
+		{ifMatch: swag.String(`"x"`), err: true},
+	} {
+		got, err := ifMatchRevision(tc.ifMatch)
+		if (err != nil) != tc.err {
+			t.Errorf("%v: error %v", swag.StringValue(tc.ifMatch), err)
+
+			continue
+		}
+
+		if swag.Uint64Value(got) != swag.Uint64Value(tc.want) || (got == nil) != (tc.want == nil) {
+			t.Errorf("%v: got %v", swag.StringValue(tc.ifMatch), swag.Uint64Value(got))
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Alert kinds.
+const (
+	AlertTrafficSpike   = "traffic_spike"
+	AlertQuotaExhausted = "quota_exhausted"
+	AlertThrottled      = "throttled"
+)
+
+// Alert message priorities, the higher the more important.
+const (
+	AlertPriorityTrafficSpike   = 1
+	AlertPriorityThrottled      = 2
+	AlertPriorityQuotaExhausted = 3
+)
+
+const (
+	// AlertSpikeDays - the trailing average depth of the spike alert.
+	AlertSpikeDays = 7
+	// AlertMessageTTL - the alert messages lifetime.
+	AlertMessageTTL = 3 * 24 * time.Hour
+)
+
+// AlertPolicy - when to alert the brigadier by the messages, zero disables the alerts.
+type AlertPolicy struct {
+	SpikeFactor float64       // the day traffic over SpikeFactor times the trailing average, 0 - disabled
+	SpikeMin    uint64        // bytes (in+out), the smaller day traffic is not a spike
+	Throttling  bool          // alert on the quota exhausting and the burst throttling
+	Cooldown    time.Duration // the minimal interval between the alerts of a kind per user
+}
+
+// notify - post the alert messages after the stats merge.
+// The throttling alerts are posted on the throttled event of the round.
+func (p AlertPolicy) notify(data *Brigade, now time.Time) {
+	for _, user := range data.Users {
+		if user.IsBlocked {
+			continue
+		}
+
+		if p.Throttling {
+			if last, ok := user.lastEvent(UserEventThrottled); ok && last.Time.Equal(now) {
+				switch user.Quotas.ThrottlingReason {
+				case ThrottleReasonQuota:
+					p.post(data, user, now, AlertQuotaExhausted, AlertPriorityQuotaExhausted,
+						fmt.Sprintf("%s has used up the monthly traffic quota, the speed is limited till %s.", user.Name, user.Quotas.ThrottlingTill.Format(time.DateOnly)))
+				case ThrottleReasonBurst:
+					p.post(data, user, now, AlertThrottled, AlertPriorityThrottled,
+						fmt.Sprintf("%s is over the daily traffic limit, the speed is limited till the end of the day.", user.Name))
+				}
+			}
+		}
+
+		if p.SpikeFactor > 0 {
+			if today, avg, ok := p.spike(&user.Quotas.CountersTotal, now); ok {
+				p.post(data, user, now, AlertTrafficSpike, AlertPriorityTrafficSpike,
+					fmt.Sprintf("%s has used %s today, %.1f times more than the %d days average of %s.", user.Name, formatBytes(today), float64(today)/float64(max(avg, 1)), AlertSpikeDays, formatBytes(avg)))
+			}
+		}
+	}
+}
+
+// spike - the day traffic and the trailing average if it is a spike.
+// The users with the shorter history are skipped.
+func (p AlertPolicy) spike(counters *DateSummaryNetCounters, now time.Time) (uint64, uint64, bool) {
+	if len(counters.History.Rx) <= AlertSpikeDays {
+		return 0, 0, false
+	}
+
+	days := counters.History.days(AlertSpikeDays+1, now)
+
+	var sum uint64
+	for _, day := range days[:AlertSpikeDays] {
+		sum += day.Rx + day.Tx
+	}
+
+	today := days[AlertSpikeDays].Rx + days[AlertSpikeDays].Tx
+	avg := sum / AlertSpikeDays
+
+	return today, avg, today >= p.SpikeMin && float64(today) > p.SpikeFactor*float64(avg)
+}
+
+// post - append the alert message unless the kind is cooling down for the user.
+func (p AlertPolicy) post(data *Brigade, user *User, now time.Time, kind string, priority int, text string) {
+	if last, ok := user.Alerts[kind]; ok && now.Sub(last) < p.Cooldown {
+		return
+	}
+
+	if user.Alerts == nil {
+		user.Alerts = map[string]time.Time{}
+	}
+
+	user.Alerts[kind] = now
+
+	data.Messages = append(data.Messages, Message{
+		ID:        uuid.New(),
+		Title:     alertTitle(kind),
+		Text:      text,
+		Priority:  priority,
+		CreatedAt: now,
+		TTL:       AlertMessageTTL,
+	})
+}
+
+func alertTitle(kind string) string {
+	switch kind {
+	case AlertTrafficSpike:
+		return "Traffic spike"
+	case AlertQuotaExhausted:
+		return "Traffic quota is used up"
+	default:
+		return "Speed is limited"
+	}
+}
+
+func formatBytes(n uint64) string {
+	const unit = 1024
+
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := uint64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAlertPolicy(t *testing.T) {
+	const mb = 1024 * 1024
+
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := AlertPolicy{SpikeFactor: 5, SpikeMin: 1024 * mb, Throttling: true, Cooldown: 24 * time.Hour}
+
+	spiky, calm, young, throttled := &User{Name: "spiky"}, &User{Name: "calm"}, &User{Name: "young"}, &User{Name: "throttled"}
+	data := &Brigade{Users: []*User{spiky, calm, young, throttled}}
+
+	// the first merge only marks the counters.
+	for _, user := range data.Users {
+		incDateSwitchRelated(start.AddDate(0, 0, -1), 0, 0, &user.Quotas.CountersTotal)
+	}
+
+	for day := range AlertSpikeDays {
+		ts := start.AddDate(0, 0, day)
+		incDateSwitchRelated(ts, 100*mb, 0, &spiky.Quotas.CountersTotal)
+		incDateSwitchRelated(ts, 100*mb, 0, &calm.Quotas.CountersTotal)
+	}
+
+	now := start.AddDate(0, 0, AlertSpikeDays)
+	incDateSwitchRelated(now, 2048*mb, 0, &spiky.Quotas.CountersTotal)
+	incDateSwitchRelated(now, 600*mb, 0, &calm.Quotas.CountersTotal)
+	incDateSwitchRelated(now, 4096*mb, 0, &young.Quotas.CountersTotal)
+
+	throttled.Quotas.ThrottlingReason = ThrottleReasonQuota
+	throttled.Quotas.ThrottlingTill = now.AddDate(0, 0, 10)
+	throttled.throttleEvents(now)
+
+	policy.notify(data, now)
+
+	if len(data.Messages) != 2 {
+		t.Fatalf("messages: %+v", data.Messages)
+	}
+
+	for i, want := range []struct {
+		priority int
+		title    string
+	}{
+		{AlertPriorityTrafficSpike, alertTitle(AlertTrafficSpike)},
+		{AlertPriorityQuotaExhausted, alertTitle(AlertQuotaExhausted)},
+	} {
+		if m := data.Messages[i]; m.Priority != want.priority || m.Title != want.title || m.TTL != AlertMessageTTL || !m.CreatedAt.Equal(now) {
+			t.Errorf("message %d: %+v", i, m)
+		}
+	}
+
+	// the spike goes on, the kind is cooling down.
+	now = now.Add(time.Hour)
+	incDateSwitchRelated(now, 1024*mb, 0, &spiky.Quotas.CountersTotal)
+	policy.notify(data, now)
+
+	if len(data.Messages) != 2 {
+		t.Errorf("cooldown: %+v", data.Messages[2:])
+	}
+
+	now = now.Add(policy.Cooldown)
+	incDateSwitchRelated(now, 4096*mb, 0, &spiky.Quotas.CountersTotal)
+	policy.notify(data, now)
+
+	if len(data.Messages) != 3 || data.Messages[2].Priority != AlertPriorityTrafficSpike {
+		t.Errorf("after the cooldown: %+v", data.Messages[2:])
+	}
+
+	AlertPolicy{}.notify(data, now)
+
+	if len(data.Messages) != 3 {
+		t.Errorf("disabled: %+v", data.Messages[3:])
+	}
+}
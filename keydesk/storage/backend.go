@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/vpngen/keydesk/kdlib"
+)
+
+// Backend - brigade persistence backend.
+// Every BrigadeStorage operation locks the backend with Open,
+// works with the decoded Brigade and optionally stores it back.
+type Backend interface {
+	// Open - lock the brigade storage and start a transaction.
+	Open() (BackendTx, error)
+}
+
+// BackendTx - locked brigade storage.
+type BackendTx interface {
+	// Load - read the brigade, io.EOF if the storage is empty.
+	Load(data *Brigade) error
+	// Store - save the brigade.
+	Store(data *Brigade) error
+	// Backup - make a backup copy after a successful reading.
+	Backup() error
+	// Close - release the lock.
+	Close() error
+}
+
+// ReadBackend - backend which can be opened only to read.
+// Readers don't take the spinlock and don't block each other.
+type ReadBackend interface {
+	// OpenRead - shared lock the brigade storage, Store and Backup are not allowed.
+	OpenRead() (BackendTx, error)
+}
+
+// ErrReadOnlyTx - the transaction is opened to read.
+var ErrReadOnlyTx = errors.New("read only transaction")
+
+// JSONBackend - brigade.json file backend, the default one.
+type JSONBackend struct {
+	Filename string // i.e. /home/<BrigadeID>/brigade.json
+	Spinlock string // i.e. /home/<BrigadeID>/brigade.lock
+}
+
+type jsonTx struct {
+	f *kdlib.FileDb
+}
+
+// Open - lock brigade.json.
+func (b *JSONBackend) Open() (BackendTx, error) {
+	f, err := kdlib.OpenFileDb(b.Filename, b.Spinlock, FileDbMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonTx{f: f}, nil
+}
+
+// OpenRead - shared lock brigade.json.
+func (b *JSONBackend) OpenRead() (BackendTx, error) {
+	f, err := kdlib.OpenFileDbReadOnly(b.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return &jsonReadTx{f: f}, nil
+}
+
+type jsonReadTx struct {
+	f *kdlib.FileDbReader
+}
+
+func (tx *jsonReadTx) Load(data *Brigade) error {
+	return tx.f.Decoder().Decode(data)
+}
+
+func (tx *jsonReadTx) Store(*Brigade) error {
+	return ErrReadOnlyTx
+}
+
+func (tx *jsonReadTx) Backup() error {
+	return ErrReadOnlyTx
+}
+
+func (tx *jsonReadTx) Close() error {
+	return tx.f.Close()
+}
+
+func (tx *jsonTx) Load(data *Brigade) error {
+	return tx.f.Decoder().Decode(data)
+}
+
+func (tx *jsonTx) Store(data *Brigade) error {
+	return commitJSON(tx.f, data)
+}
+
+func (tx *jsonTx) Backup() error {
+	return tx.f.Backup()
+}
+
+func (tx *jsonTx) Close() error {
+	return tx.f.Close()
+}
+
+// backend - configured backend or the detected one behind the journal.
+// The bolt database is used only if it exists next to brigade.json.
+func (db *BrigadeStorage) backend() Backend {
+	dir := filepath.Dir(db.BrigadeFilename)
+
+	b := db.Backend
+	if b == nil {
+		b = &JSONBackend{
+			Filename: db.BrigadeFilename,
+			Spinlock: db.BrigadeSpinlock,
+		}
+
+		boltFilename := filepath.Join(dir, BrigadeBoltFilename)
+		if _, err := os.Stat(boltFilename); err == nil {
+			b = &BoltBackend{
+				Filename: boltFilename,
+				Spinlock: db.BrigadeSpinlock,
+			}
+		}
+	}
+
+	return &sealBackend{
+		Backend: &journalBackend{
+			Backend:  b,
+			Filename: filepath.Join(dir, BrigadeJournalFilename),
+			Clock:    db.Clock,
+		},
+		db: db,
+	}
+}
+
+// CopyBackend - copy the brigade from one backend to another one.
+// The destination must be empty. Backends must not share a spinlock,
+// so the caller holds the source lock during the whole copy.
+func CopyBackend(brigadeID string, from, to Backend) error {
+	src, err := from.Open()
+	if err != nil {
+		return fmt.Errorf("open source: %w", err)
+	}
+
+	defer src.Close()
+
+	data := &Brigade{}
+	if err := src.Load(data); err != nil {
+		return fmt.Errorf("load source: %w", err)
+	}
+
+	if data.BrigadeID != brigadeID {
+		return fmt.Errorf("check: %w", ErrUnknownBrigade)
+	}
+
+	dst, err := to.Open()
+	if err != nil {
+		return fmt.Errorf("open destination: %w", err)
+	}
+
+	defer dst.Close()
+
+	switch err := dst.Load(&Brigade{}); err {
+	case nil:
+		return fmt.Errorf("destination: %w", ErrBrigadeAlreadyExists)
+	case io.EOF:
+	default:
+		return fmt.Errorf("load destination: %w", err)
+	}
+
+	if err := dst.Store(data); err != nil {
+		return fmt.Errorf("store destination: %w", err)
+	}
+
+	return nil
+}
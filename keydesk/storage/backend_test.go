@@ -0,0 +1,191 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestCopyBackend(t *testing.T) {
+	dir := t.TempDir()
+
+	brigade := &Brigade{
+		Ver:       BrigadeVersion,
+		BrigadeID: "test",
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Users: []*User{
+			{UserID: uuid.New(), Name: "001 first", IsBrigadier: true},
+			{UserID: uuid.New(), Name: "002 second"},
+		},
+		Messages: []Message{
+			{ID: uuid.New(), Text: "hello"},
+		},
+	}
+
+	src := &JSONBackend{Filename: filepath.Join(dir, BrigadeFilename), Spinlock: filepath.Join(dir, "json.lock")}
+	dst := &BoltBackend{Filename: filepath.Join(dir, BrigadeBoltFilename), Spinlock: filepath.Join(dir, "bolt.lock")}
+
+	f, err := src.Open()
+	if err != nil {
+		t.Fatalf("open json: %s", err)
+	}
+
+	if err := f.Store(brigade); err != nil {
+		t.Fatalf("store json: %s", err)
+	}
+
+	f.Close()
+
+	if err := CopyBackend("test", src, dst); err != nil {
+		t.Fatalf("copy: %s", err)
+	}
+
+	if err := CopyBackend("test", src, dst); err == nil {
+		t.Fatal("copy to non-empty destination: expected error")
+	}
+
+	// drop a user, the tail record must disappear.
+	f, err = dst.Open()
+	if err != nil {
+		t.Fatalf("open bolt: %s", err)
+	}
+
+	got := &Brigade{}
+	if err := f.Load(got); err != nil {
+		t.Fatalf("load bolt: %s", err)
+	}
+
+	if !reflect.DeepEqual(got.Users, brigade.Users) || !reflect.DeepEqual(got.Messages, brigade.Messages) {
+		t.Fatalf("records mismatch after copy")
+	}
+
+	got.Users = got.Users[:1]
+	if err := f.Store(got); err != nil {
+		t.Fatalf("store bolt: %s", err)
+	}
+
+	got = &Brigade{}
+	if err := f.Load(got); err != nil {
+		t.Fatalf("reload bolt: %s", err)
+	}
+
+	f.Close()
+
+	if len(got.Users) != 1 || got.Users[0].UserID != brigade.Users[0].UserID {
+		t.Fatalf("users mismatch after delete: %d", len(got.Users))
+	}
+}
+
+func TestBoltRecords(t *testing.T) {
+	dir := t.TempDir()
+	b := &BoltBackend{Filename: filepath.Join(dir, BrigadeBoltFilename), Spinlock: filepath.Join(dir, "bolt.lock")}
+
+	users := []*User{
+		{UserID: uuid.New(), Name: "001 first", IsBrigadier: true},
+		{UserID: uuid.New(), Name: "002 second"},
+		{UserID: uuid.New(), Name: "003 third"},
+	}
+
+	// the old records by the list position.
+	db, err := bolt.Open(b.Filename, FileDbMode, nil)
+	if err != nil {
+		t.Fatalf("bolt: %s", err)
+	}
+
+	if err := db.Update(func(btx *bolt.Tx) error {
+		bb, _ := btx.CreateBucket(boltBrigadeBucket)
+		ub, _ := btx.CreateBucket(boltUsersBucket)
+
+		buf, _ := json.Marshal(&Brigade{Ver: BrigadeVersion, BrigadeID: "test"})
+		bb.Put(boltBrigadeKey, buf)
+
+		for i, user := range users {
+			buf, _ := json.Marshal(user)
+			ub.Put(fmt.Appendf(nil, "%08d", i), buf)
+		}
+
+		return nil
+	}); err != nil {
+		t.Fatalf("old records: %s", err)
+	}
+
+	db.Close()
+
+	// records - the users bucket keys and values.
+	records := func() map[string]string {
+		tx, err := b.OpenRead()
+		if err != nil {
+			t.Fatalf("open: %s", err)
+		}
+
+		defer tx.Close()
+
+		got := map[string]string{}
+		tx.(*boltTx).db.View(func(btx *bolt.Tx) error {
+			return btx.Bucket(boltUsersBucket).ForEach(func(k, v []byte) error {
+				got[string(k)] = string(v)
+
+				return nil
+			})
+		})
+
+		return got
+	}
+
+	store := func(modify func(data *Brigade)) []*User {
+		tx, err := b.Open()
+		if err != nil {
+			t.Fatalf("open: %s", err)
+		}
+
+		defer tx.Close()
+
+		data := &Brigade{}
+		if err := tx.Load(data); err != nil {
+			t.Fatalf("load: %s", err)
+		}
+
+		modify(data)
+
+		if err := tx.Store(data); err != nil {
+			t.Fatalf("store: %s", err)
+		}
+
+		return data.Users
+	}
+
+	// the first commit moves the records to the ID keys.
+	store(func(*Brigade) {})
+
+	before := records()
+	if len(before) != 3 || before[string(users[1].UserID[:])] == "" {
+		t.Fatalf("records by ID: %d", len(before))
+	}
+
+	// the delete removes the record of the user only, the order is kept.
+	got := store(func(data *Brigade) { data.Users = append(data.Users[:0], data.Users[1:]...) })
+	if len(got) != 2 || got[0].UserID != users[1].UserID || got[1].UserID != users[2].UserID {
+		t.Fatalf("users after delete: %+v", got)
+	}
+
+	after := records()
+	if _, ok := after[string(users[0].UserID[:])]; ok || len(after) != 2 {
+		t.Errorf("records after delete: %d", len(after))
+	}
+
+	got = store(func(data *Brigade) { data.Users[0], data.Users[1] = data.Users[1], data.Users[0] })
+	if got[0].UserID != users[2].UserID {
+		t.Errorf("order: %s", got[0].Name)
+	}
+
+	got = store(func(*Brigade) {})
+	if got[0].UserID != users[2].UserID || got[1].UserID != users[1].UserID {
+		t.Errorf("order after reload: %s, %s", got[0].Name, got[1].Name)
+	}
+}
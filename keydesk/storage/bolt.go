@@ -0,0 +1,315 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib/lockedfile"
+	bolt "go.etcd.io/bbolt"
+)
+
+// BoltOpenTimeout - timeout to get the bolt file lock.
+const BoltOpenTimeout = 10 * time.Second
+
+// boltIDLen - the user and message record key, the UUID.
+const boltIDLen = 16
+
+var (
+	boltBrigadeBucket  = []byte("brigade")
+	boltUsersBucket    = []byte("users")
+	boltMessagesBucket = []byte("messages")
+	boltBrigadeKey     = []byte("brigade")
+	boltUsersOrderKey  = []byte("users_order")
+	boltMessagesOrder  = []byte("messages_order")
+)
+
+// BoltBackend - embedded key/value backend.
+// The brigade itself is one record, every user and every message are
+// separate records keyed by the ID, the list order is a record of the IDs.
+// Only the changed records are rewritten on commit.
+type BoltBackend struct {
+	Filename string // i.e. /home/<BrigadeID>/brigade.db
+	Spinlock string // i.e. /home/<BrigadeID>/brigade.lock
+}
+
+type boltTx struct {
+	name     string
+	db       *bolt.DB
+	unlock   func()
+	readOnly bool
+	loaded   map[string]map[string][]byte // the records as they are in the file, by the bucket
+}
+
+// boltRecord - the list item by the record key.
+type boltRecord struct {
+	key  []byte
+	item any
+}
+
+// Open - lock and open the bolt database.
+func (b *BoltBackend) Open() (BackendTx, error) {
+	mu := lockedfile.MutexAt(b.Spinlock)
+
+	unlock, err := mu.Lock()
+	if err != nil {
+		return nil, fmt.Errorf("spinlock: %w", err)
+	}
+
+	db, err := bolt.Open(b.Filename, FileDbMode, &bolt.Options{Timeout: BoltOpenTimeout})
+	if err != nil {
+		unlock()
+
+		return nil, fmt.Errorf("bolt: %w", err)
+	}
+
+	return &boltTx{name: b.Filename, db: db, unlock: unlock}, nil
+}
+
+// OpenRead - open the bolt database read only.
+// bolt takes the shared file lock itself, the spinlock is not used.
+func (b *BoltBackend) OpenRead() (BackendTx, error) {
+	db, err := bolt.Open(b.Filename, FileDbMode, &bolt.Options{Timeout: BoltOpenTimeout, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("bolt: %w", err)
+	}
+
+	return &boltTx{name: b.Filename, db: db, unlock: func() {}, readOnly: true}, nil
+}
+
+func (tx *boltTx) Load(data *Brigade) error {
+	tx.loaded = map[string]map[string][]byte{}
+
+	return tx.db.View(func(btx *bolt.Tx) error {
+		b := btx.Bucket(boltBrigadeBucket)
+		if b == nil {
+			return io.EOF
+		}
+
+		buf := b.Get(boltBrigadeKey)
+		if buf == nil {
+			return io.EOF
+		}
+
+		if err := json.Unmarshal(buf, data); err != nil {
+			return fmt.Errorf("brigade: %w", err)
+		}
+
+		data.Users = nil
+		if err := tx.loadList(btx, boltUsersBucket, b.Get(boltUsersOrderKey), func(v []byte) error {
+			user := &User{}
+			if err := json.Unmarshal(v, user); err != nil {
+				return err
+			}
+
+			data.Users = append(data.Users, user)
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("users: %w", err)
+		}
+
+		data.Messages = nil
+		if err := tx.loadList(btx, boltMessagesBucket, b.Get(boltMessagesOrder), func(v []byte) error {
+			msg := Message{}
+			if err := json.Unmarshal(v, &msg); err != nil {
+				return err
+			}
+
+			data.Messages = append(data.Messages, msg)
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("messages: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// loadList - decode the list records in the order, the records are kept to compare on Store.
+// No order record is the list of the old position keys, they are in the key order.
+func (tx *boltTx) loadList(btx *bolt.Tx, name, order []byte, decode func(v []byte) error) error {
+	loaded := map[string][]byte{}
+	tx.loaded[string(name)] = loaded
+
+	b := btx.Bucket(name)
+	if b == nil {
+		return nil
+	}
+
+	add := func(k, v []byte) error {
+		if err := decode(v); err != nil {
+			return fmt.Errorf("record %x: %w", k, err)
+		}
+
+		loaded[string(k)] = bytes.Clone(v)
+
+		return nil
+	}
+
+	if order == nil {
+		return b.ForEach(add)
+	}
+
+	if len(order)%boltIDLen != 0 {
+		return fmt.Errorf("order: %d bytes", len(order))
+	}
+
+	for i := 0; i < len(order); i += boltIDLen {
+		k := order[i : i+boltIDLen]
+
+		v := b.Get(k)
+		if v == nil {
+			return fmt.Errorf("record %x: missing", k)
+		}
+
+		if err := add(k, v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (tx *boltTx) Store(data *Brigade) error {
+	if tx.readOnly {
+		return ErrReadOnlyTx
+	}
+
+	header := *data
+	header.Users = nil
+	header.Messages = nil
+
+	users := make([]boltRecord, len(data.Users))
+	for i, user := range data.Users {
+		users[i] = boltRecord{key: user.UserID[:], item: user}
+	}
+
+	messages := make([]boltRecord, len(data.Messages))
+	for i := range data.Messages {
+		messages[i] = boltRecord{key: data.Messages[i].ID[:], item: &data.Messages[i]}
+	}
+
+	return tx.db.Update(func(btx *bolt.Tx) error {
+		b, err := btx.CreateBucketIfNotExists(boltBrigadeBucket)
+		if err != nil {
+			return fmt.Errorf("bucket: %w", err)
+		}
+
+		buf, err := json.Marshal(&header)
+		if err != nil {
+			return fmt.Errorf("encode brigade: %w", err)
+		}
+
+		if err := boltPutChanged(b, boltBrigadeKey, buf); err != nil {
+			return fmt.Errorf("brigade: %w", err)
+		}
+
+		if err := tx.putList(btx, b, boltUsersBucket, boltUsersOrderKey, users); err != nil {
+			return fmt.Errorf("users: %w", err)
+		}
+
+		if err := tx.putList(btx, b, boltMessagesBucket, boltMessagesOrder, messages); err != nil {
+			return fmt.Errorf("messages: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// putList - store the changed list records and the order, remove the gone ones.
+// The records are compared with the loaded ones, the bucket is read only without Load.
+func (tx *boltTx) putList(btx *bolt.Tx, brigade *bolt.Bucket, name, orderKey []byte, list []boltRecord) error {
+	b, err := btx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return fmt.Errorf("bucket: %w", err)
+	}
+
+	loaded, ok := tx.loaded[string(name)]
+	if !ok {
+		loaded = map[string][]byte{}
+		if err := b.ForEach(func(k, v []byte) error {
+			loaded[string(k)] = bytes.Clone(v)
+
+			return nil
+		}); err != nil {
+			return fmt.Errorf("read: %w", err)
+		}
+	}
+
+	stored := make(map[string][]byte, len(list))
+	order := make([]byte, 0, len(list)*boltIDLen)
+
+	for _, rec := range list {
+		key := string(rec.key)
+		if _, ok := stored[key]; ok {
+			return fmt.Errorf("record %x: duplicate", rec.key)
+		}
+
+		buf, err := json.Marshal(rec.item)
+		if err != nil {
+			return fmt.Errorf("encode %x: %w", rec.key, err)
+		}
+
+		stored[key] = buf
+		order = append(order, rec.key...)
+
+		if bytes.Equal(loaded[key], buf) {
+			continue
+		}
+
+		if err := b.Put(rec.key, buf); err != nil {
+			return fmt.Errorf("put %x: %w", rec.key, err)
+		}
+	}
+
+	for key := range loaded {
+		if _, ok := stored[key]; ok {
+			continue
+		}
+
+		if err := b.Delete([]byte(key)); err != nil {
+			return fmt.Errorf("delete %x: %w", key, err)
+		}
+	}
+
+	if err := boltPutChanged(brigade, orderKey, order); err != nil {
+		return fmt.Errorf("order: %w", err)
+	}
+
+	if tx.loaded == nil {
+		tx.loaded = map[string]map[string][]byte{}
+	}
+
+	tx.loaded[string(name)] = stored
+
+	return nil
+}
+
+// boltPutChanged - put the record if it differs.
+func boltPutChanged(b *bolt.Bucket, key, buf []byte) error {
+	if old := b.Get(key); old != nil && bytes.Equal(old, buf) {
+		return nil
+	}
+
+	return b.Put(key, buf)
+}
+
+func (tx *boltTx) Backup() error {
+	if tx.readOnly {
+		return ErrReadOnlyTx
+	}
+
+	return tx.db.View(func(btx *bolt.Tx) error {
+		return btx.CopyFile(tx.name+".bak", FileDbMode)
+	})
+}
+
+func (tx *boltTx) Close() error {
+	defer tx.unlock()
+
+	return tx.db.Close()
+}
@@ -1,9 +1,8 @@
 package storage
 
 import (
+	"context"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/vpngen/keydesk/vpnapi"
 )
@@ -40,8 +39,9 @@ type BrigadeProto0Config struct {
 	Proto0Port        uint16
 }
 
-// CreateBrigade - create brigade config.
+// CreateBrigade - create brigade config, ctx bounds the endpoint calls.
 func (db *BrigadeStorage) CreateBrigade(
+	ctx context.Context,
 	config *BrigadeConfig,
 	wgConf *BrigadeWgConfig,
 	ovcConf *BrigadeOvcConfig,
@@ -60,18 +60,7 @@ func (db *BrigadeStorage) CreateBrigade(
 
 	defer f.Close()
 
-	db.calculatedAddrPort = vpnapi.CalcAPIAddrPort(config.EndpointIPv4)
-	fmt.Fprintf(os.Stderr, "API endpoint calculated: %s\n", db.calculatedAddrPort)
-
-	switch {
-	case db.APIAddrPort.Addr().IsValid() && db.APIAddrPort.Addr().IsUnspecified():
-		db.actualAddrPort = db.calculatedAddrPort
-	default:
-		db.actualAddrPort = db.APIAddrPort
-		if db.actualAddrPort.IsValid() {
-			fmt.Fprintf(os.Stderr, "API endpoint: %s\n", db.actualAddrPort)
-		}
-	}
+	db.initEndpoint(config.EndpointIPv4)
 
 	data.Mode = mode
 	if mode == ModeVGSocket {
@@ -121,37 +110,13 @@ func (db *BrigadeStorage) CreateBrigade(
 		data.Proto0Port = proto0Conf.Proto0Port
 	}
 
-	proto0Decoy := []string{}
-	if data.Proto0FakeDomain != "" {
-		proto0Decoy = append(proto0Decoy, data.Proto0FakeDomain)
-	}
-
-	if len(data.Proto0FakeDomains) > 0 {
-		proto0Decoy = append(proto0Decoy, data.Proto0FakeDomains...)
-	}
-
 	// if we catch a slowdown problems we need organize queue
-	err = vpnapi.WgAdd(
-		data.BrigadeID,
-		db.actualAddrPort,
-		db.calculatedAddrPort,
-		data.WgPrivateRouterEnc,
-		config.EndpointIPv4,
-		config.EndpointPort,
-		config.IPv4CGNAT,
-		config.IPv6ULA,
-		data.CloakFakeDomain,
-		data.OvCACertPemGzipBase64,
-		data.OvCAKeyRouterEnc,
-		data.IPSecPSKRouterEnc,
-		data.OutlinePort,
-		strings.Join(proto0Decoy, ","),
-	)
+	err = db.EndpointClient().WgAdd(ctx, wgAddRequest(data))
 	if err != nil {
 		return fmt.Errorf("wg add: %w", err)
 	}
 
-	err = commitBrigade(f, data)
+	err = commitBrigade(f, "create_brigade", data)
 	if err != nil {
 		return fmt.Errorf("commit: %w", err)
 	}
@@ -159,8 +124,8 @@ func (db *BrigadeStorage) CreateBrigade(
 	return nil
 }
 
-// DestroyBrigade - remove brigade.
-func (db *BrigadeStorage) DestroyBrigade() error {
+// DestroyBrigade - remove brigade, ctx bounds the endpoint calls.
+func (db *BrigadeStorage) DestroyBrigade(ctx context.Context) error {
 	f, data, err := db.openWithReading()
 	if err != nil {
 		return fmt.Errorf("db: %w", err)
@@ -169,7 +134,7 @@ func (db *BrigadeStorage) DestroyBrigade() error {
 	defer f.Close()
 
 	// if we catch a slowdown problems we need organize queue
-	err = vpnapi.WgDel(data.BrigadeID, db.actualAddrPort, db.calculatedAddrPort, data.WgPrivateRouterEnc)
+	err = db.EndpointClient().WgDel(ctx, vpnapi.WgDelRequest{WgPrivateKey: data.WgPrivateRouterEnc})
 	if err != nil {
 		return fmt.Errorf("wg add: %w", err)
 	}
@@ -179,7 +144,7 @@ func (db *BrigadeStorage) DestroyBrigade() error {
 
 // GetVpnConfigs - get vpn configs.
 func (db *BrigadeStorage) GetVpnConfigs(req *ConfigsImplemented) (*ConfigsImplemented, error) {
-	f, data, err := db.openWithReading()
+	f, data, err := db.openReadOnly()
 	if err != nil {
 		return nil, fmt.Errorf("db: %w", err)
 	}
@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// ExportBrigade - read the whole brigade for the export bundle.
+func (db *BrigadeStorage) ExportBrigade() (*Brigade, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	return data, nil
+}
+
+// ImportBrigade - store the imported brigade, the storage must be empty.
+// Secrets and addresses must be already prepared for this node.
+func (db *BrigadeStorage) ImportBrigade(data *Brigade) error {
+	f, _, err := db.openWithoutReading(data.BrigadeID)
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	if err := commitBrigade(f, "import", data); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
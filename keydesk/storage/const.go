@@ -4,4 +4,5 @@ package storage
 const (
 	DefaultHomeDir  = "/home"
 	DefaultStatsDir = "/var/lib/vgstats"
+	DefaultEtcDir   = "/etc/vg-keydesk"
 )
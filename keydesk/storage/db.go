@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/netip"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/vpngen/keydesk/kdlib"
@@ -15,6 +16,8 @@ import (
 // Filenames.
 const (
 	BrigadeFilename         = "brigade.json"
+	BrigadeBoltFilename     = "brigade.db"
+	BrigadeJournalFilename  = "brigade.journal"
 	BrigadeSpinlockFilename = "brigade.lock"
 	StatsFilename           = "stats.json"
 	StatsSpinlockFilename   = "stats.lock"
@@ -34,6 +37,8 @@ var (
 	ErrUnknownBrigade = errors.New("unknown brigade")
 	// ErrBrigadeAlreadyExists - brigade file exists unexpectabily.
 	ErrBrigadeAlreadyExists = errors.New("already exists")
+	// ErrRevisionMismatch - the brigade is changed since the revision was read.
+	ErrRevisionMismatch = errors.New("revision mismatch")
 	// ErrWrongStorageConfiguration - somthing empty in db config.
 	ErrWrongStorageConfiguration = errors.New("wrong db config")
 )
@@ -43,20 +48,88 @@ type BrigadeStorageOpts struct {
 	MaxUsers               int
 	MonthlyQuotaRemaining  int
 	MaxUserInctivityPeriod time.Duration
+	Snapshots              kdlib.SnapshotRetention
+	Throttle               ThrottlePolicy
+	Geo                    GeoLocator
+	Alerts                 AlertPolicy
+	EndpointTimeouts       vpnapi.Timeouts
 }
 
 // BrigadeStorage - brigade file storage.
 type BrigadeStorage struct {
 	BrigadeID          string
-	BrigadeFilename    string // i.e. /home/<BrigadeID>/brigade.json
-	BrigadeSpinlock    string // i.e. /home/<BrigadeID>/brigade.lock
+	BrigadeFilename    string  // i.e. /home/<BrigadeID>/brigade.json
+	BrigadeSpinlock    string  // i.e. /home/<BrigadeID>/brigade.lock
+	Backend            Backend // nil means brigade.db if exists, otherwise brigade.json
+	SealKeyFilename    string  // i.e. /etc/vg-keydesk/seal.key, empty means the default one
+	Migration          MigrationEnv
+	Clock              kdlib.Clock           // nil means the system clock
+	Endpoint           vpnapi.EndpointClient // nil means the endpoint API at the actual address
 	APIAddrPort        netip.AddrPort
 	calculatedAddrPort netip.AddrPort
 	actualAddrPort     netip.AddrPort
+	client             *vpnapi.Client // built once the addresses are known
+	snapshotAt         time.Time      // the newest snapshot known, under the brigade lock
 	BrigadeStorageOpts
 }
 
-func commitBrigade(f *kdlib.FileDb, data *Brigade) error {
+// now - the storage clock time in UTC.
+func (db *BrigadeStorage) now() time.Time {
+	return kdlib.Now(db.Clock).UTC()
+}
+
+// opTx - the transaction which stores the brigade with the journal operation name.
+type opTx interface {
+	commit(op string, data *Brigade) error
+}
+
+// revisionOps - the commits the API clients see, they bump the brigade revision.
+// The raw tools commits bump it too, the housekeeping ones (stats, outbox,
+// first_visit, seal, replay, migrate) keep the revision and the ETag.
+var revisionOps = map[string]bool{
+	"create_brigade": true,
+	"create_user":    true,
+	"delete_user":    true,
+	"block_user":     true,
+	"unblock_user":   true,
+	"user_quota":     true,
+	"user_throttle":  true,
+	"domain_set":     true,
+	"port_set":       true,
+	"import":         true,
+	"restore":        true,
+	"fsck":           true,
+}
+
+// commitBrigade - store the brigade, op is the journal operation name.
+// The API visible commits bump the brigade revision.
+func commitBrigade(f BackendTx, op string, data *Brigade) error {
+	if revisionOps[op] || strings.HasPrefix(op, JournalOpRaw+":") {
+		data.Revision++
+	}
+
+	store := f.Store
+	if j, ok := f.(opTx); ok {
+		store = func(data *Brigade) error { return j.commit(op, data) }
+	}
+
+	if err := store(data); err != nil {
+		return fmt.Errorf("store: %w", err)
+	}
+
+	return nil
+}
+
+// checkRevision - the expected revision, nil means any.
+func checkRevision(data *Brigade, revision *uint64) error {
+	if revision != nil && *revision != data.Revision {
+		return fmt.Errorf("%w: %d, actual %d", ErrRevisionMismatch, *revision, data.Revision)
+	}
+
+	return nil
+}
+
+func commitJSON(f *kdlib.FileDb, data *Brigade) error {
 	if err := f.Encoder(" ", " ").Encode(data); err != nil {
 		return fmt.Errorf("encode: %w", err)
 	}
@@ -96,8 +169,6 @@ func (db *BrigadeStorage) SelfCheck() error {
 
 // SelfCheckAndInit - self check and init func.
 func (db *BrigadeStorage) SelfCheckAndInit() error {
-	addr := netip.AddrPort{}
-
 	if err := db.SelfCheck(); err != nil {
 		return fmt.Errorf("self check: %w", err)
 	}
@@ -109,19 +180,17 @@ func (db *BrigadeStorage) SelfCheckAndInit() error {
 
 	defer f.Close()
 
-	db.calculatedAddrPort = vpnapi.CalcAPIAddrPort(data.EndpointIPv4)
-	fmt.Fprintf(os.Stderr, "API endpoint calculated: %s\n", db.calculatedAddrPort)
+	results, err := db.migrate(f, data, false)
+	if err != nil {
+		return fmt.Errorf("migrate: %w", err)
+	}
 
-	switch {
-	case db.APIAddrPort.Addr().IsValid() && db.APIAddrPort.Addr().IsUnspecified():
-		db.actualAddrPort = db.calculatedAddrPort
-	default:
-		db.actualAddrPort = db.APIAddrPort
-		if addr.IsValid() {
-			fmt.Fprintf(os.Stderr, "API endpoint: %s\n", db.calculatedAddrPort)
-		}
+	for _, r := range results {
+		fmt.Fprintf(os.Stderr, "Migrated: %s %s v%d %s\n", r.Kind, r.Target, r.Version, r.Name)
 	}
 
+	db.initEndpoint(data.EndpointIPv4)
+
 	return nil
 }
 
@@ -129,15 +198,15 @@ func (db *BrigadeStorage) CalculatedAPIAddress() (netip.Addr, bool) {
 	return db.calculatedAddrPort.Addr(), db.APIAddrPort.Addr().IsValid() && db.APIAddrPort.Addr().IsUnspecified()
 }
 
-func (db *BrigadeStorage) openBrigadeWithReading() (*kdlib.FileDb, *Brigade, error) {
-	f, err := kdlib.OpenFileDb(db.BrigadeFilename, db.BrigadeSpinlock, FileDbMode)
+func (db *BrigadeStorage) openBrigadeWithReading() (BackendTx, *Brigade, error) {
+	f, err := db.backend().Open()
 	if err != nil {
 		return nil, nil, fmt.Errorf("open: %w", err)
 	}
 
 	data := &Brigade{}
 
-	if err := f.Decoder().Decode(data); err != nil {
+	if err := f.Load(data); err != nil {
 		f.Close()
 
 		return nil, nil, fmt.Errorf("decode: %w", err)
@@ -149,6 +218,25 @@ func (db *BrigadeStorage) openBrigadeWithReading() (*kdlib.FileDb, *Brigade, err
 		return nil, nil, fmt.Errorf("check: %w", ErrUnknownBrigade)
 	}
 
+	fixBrigadeDefaults(data)
+
+	// backup is read was succesfull.
+	if err := f.Backup(); err != nil {
+		f.Close()
+
+		return nil, nil, fmt.Errorf("backup: %w", err)
+	}
+
+	if err := db.snapshot(data); err != nil {
+		f.Close()
+
+		return nil, nil, fmt.Errorf("snapshot: %w", err)
+	}
+
+	return f, data, nil
+}
+
+func fixBrigadeDefaults(data *Brigade) {
 	if data.Mode == "" {
 		data.Mode = ModeBrigade
 	}
@@ -156,22 +244,43 @@ func (db *BrigadeStorage) openBrigadeWithReading() (*kdlib.FileDb, *Brigade, err
 	if data.CloakFakeDomain == "" && data.CloakFaekDomain != "" {
 		data.CloakFakeDomain = data.CloakFaekDomain
 	}
+}
 
-	// backup is read was succesfull.
-	if err := f.Backup(); err != nil {
+// openReadOnly - read the brigade with the shared lock.
+// Nothing is written: no backup, no snapshot, no commit.
+func (db *BrigadeStorage) openReadOnly() (BackendTx, *Brigade, error) {
+	b := db.backend()
+
+	rb, ok := b.(ReadBackend)
+	if !ok {
+		return db.openWithReading()
+	}
+
+	f, err := rb.OpenRead()
+	if err != nil {
+		return nil, nil, fmt.Errorf("open: %w", err)
+	}
+
+	data := &Brigade{}
+
+	if err := f.Load(data); err != nil {
 		f.Close()
 
-		return nil, nil, fmt.Errorf("backup: %w", err)
+		return nil, nil, fmt.Errorf("decode: %w", err)
 	}
 
-	if data.Ver < 7 && data.EndpointPort == 0 {
-		data.EndpointPort = 51820
+	if data.BrigadeID != db.BrigadeID {
+		f.Close()
+
+		return nil, nil, fmt.Errorf("check: %w", ErrUnknownBrigade)
 	}
 
+	fixBrigadeDefaults(data)
+
 	return f, data, nil
 }
 
-func (db *BrigadeStorage) openWithReading() (*kdlib.FileDb, *Brigade, error) {
+func (db *BrigadeStorage) openWithReading() (BackendTx, *Brigade, error) {
 	f, data, err := db.openBrigadeWithReading()
 	if err != nil {
 		return nil, nil, fmt.Errorf("brigade: %w", err)
@@ -180,15 +289,15 @@ func (db *BrigadeStorage) openWithReading() (*kdlib.FileDb, *Brigade, error) {
 	return f, data, nil
 }
 
-func (db *BrigadeStorage) openBrigadeWithoutReading() (*kdlib.FileDb, *Brigade, error) {
-	f, err := kdlib.OpenFileDb(db.BrigadeFilename, db.BrigadeSpinlock, FileDbMode)
+func (db *BrigadeStorage) openBrigadeWithoutReading() (BackendTx, *Brigade, error) {
+	f, err := db.backend().Open()
 	if err != nil {
 		return nil, nil, fmt.Errorf("open: %w", err)
 	}
 
 	data := &Brigade{}
 
-	err = f.Decoder().Decode(data)
+	err = f.Load(data)
 	switch err {
 	case nil:
 		f.Close()
@@ -205,7 +314,7 @@ func (db *BrigadeStorage) openBrigadeWithoutReading() (*kdlib.FileDb, *Brigade,
 	return f, data, nil
 }
 
-func (db *BrigadeStorage) openWithoutReading(brigadeID string) (*kdlib.FileDb, *Brigade, error) {
+func (db *BrigadeStorage) openWithoutReading(brigadeID string) (BackendTx, *Brigade, error) {
 	if brigadeID != db.BrigadeID {
 		return nil, nil, fmt.Errorf("check: %w", ErrUnknownBrigade)
 	}
@@ -215,7 +324,7 @@ func (db *BrigadeStorage) openWithoutReading(brigadeID string) (*kdlib.FileDb, *
 		return nil, nil, fmt.Errorf("brigade: %w", err)
 	}
 
-	ts := time.Now().UTC()
+	ts := db.now()
 	data.Ver = BrigadeVersion
 	data.BrigadeID = brigadeID
 	data.CreatedAt = ts
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"fmt"
+	"net/netip"
+	"os"
+	"strings"
+
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+// EndpointClient - the endpoint API client, the injected Endpoint or
+// the API at the actual address (the stand-in without it).
+func (db *BrigadeStorage) EndpointClient() vpnapi.EndpointClient {
+	if db.Endpoint != nil {
+		return db.Endpoint
+	}
+
+	if db.client != nil {
+		return db.client
+	}
+
+	return db.newEndpointClient()
+}
+
+// initEndpoint - set the endpoint API addresses and build the client once,
+// it is shared by all the calls.
+func (db *BrigadeStorage) initEndpoint(endpointIPv4 netip.Addr) {
+	db.calculatedAddrPort = vpnapi.CalcAPIAddrPort(endpointIPv4)
+	fmt.Fprintf(os.Stderr, "API endpoint calculated: %s\n", db.calculatedAddrPort)
+
+	switch {
+	case db.APIAddrPort.Addr().IsValid() && db.APIAddrPort.Addr().IsUnspecified():
+		db.actualAddrPort = db.calculatedAddrPort
+	default:
+		db.actualAddrPort = db.APIAddrPort
+		if db.actualAddrPort.IsValid() {
+			fmt.Fprintf(os.Stderr, "API endpoint: %s\n", db.actualAddrPort)
+		}
+	}
+
+	db.client = db.newEndpointClient()
+}
+
+func (db *BrigadeStorage) newEndpointClient() *vpnapi.Client {
+	client := vpnapi.NewClient(db.actualAddrPort, db.calculatedAddrPort)
+	client.Timeouts = db.EndpointTimeouts
+
+	return client
+}
+
+// wgAddRequest - the brigade interface.
+func wgAddRequest(data *Brigade) vpnapi.WgAddRequest {
+	proto0Decoy := []string{}
+	if data.Proto0FakeDomain != "" {
+		proto0Decoy = append(proto0Decoy, data.Proto0FakeDomain)
+	}
+
+	if len(data.Proto0FakeDomains) > 0 {
+		proto0Decoy = append(proto0Decoy, data.Proto0FakeDomains...)
+	}
+
+	return vpnapi.WgAddRequest{
+		WgPrivateKey:     data.WgPrivateRouterEnc,
+		ExternalIP:       data.EndpointIPv4,
+		WireguardPort:    data.EndpointPort,
+		InternalNets:     []netip.Prefix{data.IPv4CGNAT, data.IPv6ULA},
+		CloakDomain:      data.CloakFakeDomain,
+		OpenVPNCACert:    data.OvCACertPemGzipBase64,
+		OpenVPNCAKey:     data.OvCAKeyRouterEnc,
+		L2TPPresharedKey: data.IPSecPSKRouterEnc,
+		OutlinePort:      data.OutlinePort,
+		Proto0Domain:     strings.Join(proto0Decoy, ","),
+	}
+}
+
+// peerAddRequest - the user peer, the brigadier one controls the keydesk.
+func peerAddRequest(data *Brigade, user *User) vpnapi.PeerAddRequest {
+	req := vpnapi.PeerAddRequest{
+		PeerPublicKey: user.WgPublicKey,
+		WgPublicKey:   data.WgPublicKey,
+		WgPSK:         user.WgPSKRouterEnc,
+		AllowedIPs:    []netip.Prefix{netip.PrefixFrom(user.IPv4Addr, user.IPv4Addr.BitLen()), netip.PrefixFrom(user.IPv6Addr, user.IPv6Addr.BitLen())},
+		OpenVPNCSR:    user.OvCSRGzipBase64,
+		CloakUID:      user.CloakByPassUIDRouterEnc,
+		L2TPUsername:  user.IPSecUsernameRouterEnc,
+		L2TPPassword:  user.IPSecPasswordRouterEnc,
+		OutlineSecret: user.OutlineSecretRouterEnc,
+		Proto0Secret:  user.Proto0SecretRouterEnc,
+	}
+
+	if user.IsBrigadier {
+		req.ControlHost = data.KeydeskIPv6
+	}
+
+	return req
+}
+
+// peerRequest - the user peer by the public key.
+func peerRequest(data *Brigade, wgPub []byte) vpnapi.PeerRequest {
+	return vpnapi.PeerRequest{PeerPublicKey: wgPub, WgPublicKey: data.WgPublicKey}
+}
@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/netip"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/internal/fakeendpoint"
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+func TestFakeEndpoint(t *testing.T) {
+	fake := fakeendpoint.New()
+
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	addr := netip.MustParseAddrPort(srv.Listener.Addr().String())
+
+	db.Endpoint = vpnapi.NewClient(addr, addr)
+	defer func() { db.Endpoint = nil }()
+
+	id := uuid.New()
+	wgPub := []byte("fake-endpoint-user-wg-public-key")
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	brigadePub := data.WgPublicKey
+
+	data.Users = append(data.Users, &User{
+		UserID:      id,
+		WgPublicKey: wgPub,
+		IPv4Addr:    netip.MustParseAddr("100.64.0.10"),
+		IPv6Addr:    netip.MustParseAddr("fd00::10"),
+		IsBlocked:   true,
+	})
+
+	if err := commitBrigade(f, "test", data); err != nil {
+		f.Close()
+		t.Fatalf("commit: %s", err)
+	}
+
+	f.Close()
+
+	// the peer is added by the real peer_add.
+	if err := db.UnblockUser(context.Background(), id.String(), nil); err != nil {
+		t.Fatalf("unblock: %s", err)
+	}
+
+	if peers := fake.Peers(brigadePub); len(peers) != 1 {
+		t.Fatalf("peers: %+v", peers)
+	}
+
+	var rx uint64
+
+	for range 2 {
+		data, _, err := db.getStatsQuota(context.Background(), false, time.Hour)
+		if err != nil {
+			t.Fatalf("stats: %s", err)
+		}
+
+		for _, user := range data.Users {
+			if user.UserID == id {
+				if user.Quotas.CountersTotal.Total.Rx <= rx {
+					t.Errorf("traffic: %d after %d", user.Quotas.CountersTotal.Total.Rx, rx)
+				}
+
+				rx = user.Quotas.CountersTotal.Total.Rx
+			}
+		}
+	}
+
+	if rx == 0 {
+		t.Errorf("no traffic")
+	}
+
+	// the failed delete is queued and retried by the outbox.
+	fake.Inject(fakeendpoint.CallPeerDel, fakeendpoint.Fault{Status: 500, Times: 2})
+
+	if err := db.DeleteUser(context.Background(), id.String(), false, false, nil); err != nil {
+		t.Fatalf("delete: %s", err)
+	}
+
+	ops, err := db.Outbox()
+	if err != nil || len(ops) != 1 || ops[0].Kind != OutboxPeerDel {
+		t.Fatalf("outbox: %+v, %v", ops, err)
+	}
+
+	if done, failed, err := db.ProcessOutbox(context.Background()); err != nil || done != 0 || failed != 1 {
+		t.Fatalf("process: done %d, failed %d, %v", done, failed, err)
+	}
+
+	// not due till the backoff.
+	if done, failed, _ := db.ProcessOutbox(context.Background()); done+failed != 0 {
+		t.Errorf("process before the backoff: done %d, failed %d", done, failed)
+	}
+
+	if err := db.RetryOutboxOp(ops[0].ID); err != nil {
+		t.Fatalf("retry: %s", err)
+	}
+
+	if done, failed, err := db.ProcessOutbox(context.Background()); err != nil || done != 1 || failed != 0 {
+		t.Fatalf("process after the retry: done %d, failed %d, %v", done, failed, err)
+	}
+
+	if peers := fake.Peers(brigadePub); len(peers) != 0 {
+		t.Errorf("peers after delete: %+v", peers)
+	}
+
+	if ops, _ := db.Outbox(); len(ops) != 0 {
+		t.Errorf("outbox after delete: %+v", ops)
+	}
+}
+
+func TestEndpointClientOnce(t *testing.T) {
+	st := &BrigadeStorage{BrigadeStorageOpts: BrigadeStorageOpts{EndpointTimeouts: vpnapi.Timeouts{PeerAdd: time.Second}}}
+	st.initEndpoint(netip.MustParseAddr("192.0.2.1"))
+
+	client, ok := st.EndpointClient().(*vpnapi.Client)
+	if !ok || client != st.EndpointClient() {
+		t.Fatal("the client is built per call")
+	}
+
+	if client.Timeouts.PeerAdd != time.Second {
+		t.Errorf("timeouts: %+v", client.Timeouts)
+	}
+}
+
+// recordingEndpoint - the stand-in endpoint keeping the peer calls for the checks.
+type recordingEndpoint struct {
+	vpnapi.EndpointClient
+
+	mu    sync.Mutex
+	calls []string
+}
+
+func newRecordingEndpoint() *recordingEndpoint {
+	return &recordingEndpoint{EndpointClient: vpnapi.NewClient(netip.AddrPort{}, netip.AddrPort{})}
+}
+
+func (e *recordingEndpoint) record(call string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.calls = append(e.calls, call)
+}
+
+// count - the calls made since the last count, the log is forgotten.
+func (e *recordingEndpoint) count(call string) int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	n := 0
+	for _, c := range e.calls {
+		if c == call {
+			n++
+		}
+	}
+
+	e.calls = nil
+
+	return n
+}
+
+func (e *recordingEndpoint) PeerAdd(ctx context.Context, req vpnapi.PeerAddRequest) (*vpnapi.PeerAddResponse, error) {
+	e.record(OutboxPeerAdd)
+
+	return e.EndpointClient.PeerAdd(ctx, req)
+}
+
+func (e *recordingEndpoint) PeerDel(ctx context.Context, req vpnapi.PeerRequest) error {
+	e.record(OutboxPeerDel)
+
+	return e.EndpointClient.PeerDel(ctx, req)
+}
+
+func (e *recordingEndpoint) ThrottleOn(ctx context.Context, req vpnapi.PeerRequest) error {
+	e.record(OutboxThrottleOn)
+
+	return e.EndpointClient.ThrottleOn(ctx, req)
+}
+
+func (e *recordingEndpoint) ThrottleOff(ctx context.Context, req vpnapi.PeerRequest) error {
+	e.record(OutboxThrottleOff)
+
+	return e.EndpointClient.ThrottleOff(ctx, req)
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxUserEvents - per user event log size, the oldest events are dropped.
+const MaxUserEvents = 64
+
+// User event kinds.
+const (
+	UserEventCreated        = "created"
+	UserEventBlocked        = "blocked"
+	UserEventUnblocked      = "unblocked"
+	UserEventThrottled      = "throttled"
+	UserEventThrottleLifted = "throttle_lifted"
+	UserEventQuotaReset     = "quota_reset"
+	UserEventQuotaChanged   = "quota_changed"
+	UserEventConfigReissued = "config_reissued"
+	UserEventFirstConnect   = "first_connect"
+)
+
+// first_connect details.
+const (
+	userEventProtoWireguard = "wireguard"
+	userEventProtoIPSec     = "ipsec"
+	userEventProtoOpenVPN   = "openvpn"
+	userEventProtoOlc       = "openvpn_over_cloak"
+	userEventProtoOutline   = "outline"
+	userEventProtoProto0    = "proto0"
+)
+
+// UserEvent - user history record.
+type UserEvent struct {
+	Kind   string    `json:"kind"`
+	Time   time.Time `json:"time"`
+	Detail string    `json:"detail,omitempty"` // i.e. protocol for first_connect
+}
+
+// addEvent - append the event to the bounded log.
+func (u *User) addEvent(kind string, ts time.Time, detail string) {
+	u.Events = append(u.Events, UserEvent{Kind: kind, Time: ts, Detail: detail})
+
+	if len(u.Events) > MaxUserEvents {
+		u.Events = append(u.Events[:0:0], u.Events[len(u.Events)-MaxUserEvents:]...)
+	}
+}
+
+// lastEvent - the newest event of the kinds.
+func (u *User) lastEvent(kinds ...string) (UserEvent, bool) {
+	for i := len(u.Events) - 1; i >= 0; i-- {
+		for _, kind := range kinds {
+			if u.Events[i].Kind == kind {
+				return u.Events[i], true
+			}
+		}
+	}
+
+	return UserEvent{}, false
+}
+
+// throttleEvents - record the throttling state changes.
+func (u *User) throttleEvents(now time.Time) {
+	last, _ := u.lastEvent(UserEventThrottled, UserEventThrottleLifted)
+	throttled := u.Quotas.ThrottlingTill.After(now)
+
+	switch {
+	case throttled && last.Kind != UserEventThrottled:
+		u.addEvent(UserEventThrottled, now, "till "+u.Quotas.ThrottlingTill.UTC().Format(time.RFC3339))
+	case !throttled && last.Kind == UserEventThrottled:
+		u.addEvent(UserEventThrottleLifted, now, "")
+	}
+}
+
+// firstConnectEvents - record the first activity per protocol,
+// the points are compared before and after the stats merge.
+func (u *User) firstConnectEvents(now time.Time, before map[string]bool) {
+	for _, p := range u.protocolActivity() {
+		if before[p.proto] && !p.points.Total.IsZero() {
+			u.addEvent(UserEventFirstConnect, now, p.proto)
+		}
+	}
+}
+
+// neverConnected - protocols without any activity yet.
+func (u *User) neverConnected() map[string]bool {
+	never := make(map[string]bool)
+
+	for _, p := range u.protocolActivity() {
+		never[p.proto] = p.points.Total.IsZero() || p.points.Total.Equal(nullUnixTime)
+	}
+
+	return never
+}
+
+type protocolActivity struct {
+	proto  string
+	points *LastActivityPoints
+}
+
+func (u *User) protocolActivity() []protocolActivity {
+	return []protocolActivity{
+		{userEventProtoWireguard, &u.Quotas.LastWgActivity},
+		{userEventProtoIPSec, &u.Quotas.LastIPSecActivity},
+		{userEventProtoOpenVPN, &u.Quotas.LastOvcActivity},
+		{userEventProtoOlc, &u.Quotas.LastOlcActivity},
+		{userEventProtoOutline, &u.Quotas.LastOutlineActivity},
+		{userEventProtoProto0, &u.Quotas.LastProto0Activity},
+	}
+}
+
+// GetUserEvents - user event log, the oldest first.
+func (db *BrigadeStorage) GetUserEvents(id string) ([]UserEvent, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	for _, user := range data.Users {
+		if user.UserID.String() == id {
+			return user.Events, nil
+		}
+	}
+
+	return nil, ErrUserNotFound
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserEvents(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	user := &User{}
+
+	for i := 0; i < MaxUserEvents+10; i++ {
+		user.addEvent(UserEventBlocked, now.Add(time.Duration(i)*time.Second), "")
+	}
+
+	if len(user.Events) != MaxUserEvents {
+		t.Fatalf("bound: %d events", len(user.Events))
+	}
+
+	if !user.Events[0].Time.Equal(now.Add(10 * time.Second)) {
+		t.Errorf("oldest dropped: first is %s", user.Events[0].Time)
+	}
+
+	user.Events = nil
+
+	user.Quotas.ThrottlingTill = now.Add(time.Hour)
+	user.throttleEvents(now)
+	user.throttleEvents(now.Add(time.Minute))
+	user.throttleEvents(now.Add(2 * time.Hour))
+	user.throttleEvents(now.Add(3 * time.Hour))
+
+	if len(user.Events) != 2 || user.Events[0].Kind != UserEventThrottled || user.Events[1].Kind != UserEventThrottleLifted {
+		t.Errorf("throttle: %+v", user.Events)
+	}
+
+	user.Events = nil
+
+	never := user.neverConnected()
+	user.Quotas.LastWgActivity.Total = now
+	user.firstConnectEvents(now, never)
+	user.firstConnectEvents(now, user.neverConnected())
+
+	if len(user.Events) != 1 || user.Events[0].Kind != UserEventFirstConnect || user.Events[0].Detail != userEventProtoWireguard {
+		t.Errorf("first connect: %+v", user.Events)
+	}
+}
@@ -0,0 +1,212 @@
+package storage
+
+import (
+	"fmt"
+	"net/netip"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Fsck checks.
+const (
+	FsckDuplicateIPv4     = "duplicate_ipv4"
+	FsckDuplicateIPv6     = "duplicate_ipv6"
+	FsckIPv4OutOfRange    = "ipv4_out_of_range"
+	FsckIPv6OutOfRange    = "ipv6_out_of_range"
+	FsckManyBrigadiers    = "many_brigadiers"
+	FsckNameCollision     = "name_collision"
+	FsckDelayedNotBlocked = "delayed_not_blocked"
+	FsckStaleEndpoint     = "stale_endpoint"
+	FsckMissingSecret     = "missing_secret"
+)
+
+// FsckProblem - one found problem.
+type FsckProblem struct {
+	Check   string `json:"check"`
+	UserID  string `json:"user_id,omitempty"`
+	Detail  string `json:"detail"`
+	Fixable bool   `json:"fixable"`
+	Fixed   bool   `json:"fixed"`
+}
+
+// FsckReport - brigade check report.
+type FsckReport struct {
+	BrigadeID string        `json:"brigade_id"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Users     int           `json:"users"`
+	Problems  []FsckProblem `json:"problems"`
+	Fixed     int           `json:"fixed"`
+}
+
+// Fsck - check the brigade, with fix do the safe repairs and store the brigade.
+func (db *BrigadeStorage) Fsck(fix bool, endpointsTTL time.Duration) (*FsckReport, error) {
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	report := FsckBrigade(data, db.now(), endpointsTTL, fix)
+
+	if report.Fixed > 0 {
+		if err := commitBrigade(f, "fsck", data); err != nil {
+			return report, fmt.Errorf("commit: %w", err)
+		}
+	}
+
+	return report, nil
+}
+
+// FsckBrigade - check the brigade structure.
+// Only the safe repairs are done with fix, the rest needs the endpoint calls.
+func FsckBrigade(data *Brigade, now time.Time, endpointsTTL time.Duration, fix bool) *FsckReport {
+	report := &FsckReport{
+		BrigadeID: data.BrigadeID,
+		CheckedAt: now,
+		Users:     len(data.Users),
+		Problems:  []FsckProblem{},
+	}
+
+	add := func(p FsckProblem) {
+		report.Problems = append(report.Problems, p)
+		if p.Fixed {
+			report.Fixed++
+		}
+	}
+
+	var (
+		ip4L       = make(map[netip.Addr]string, len(data.Users))
+		ip6L       = make(map[netip.Addr]string, len(data.Users))
+		nameL      = make(map[string]string, len(data.Users))
+		brigadiers []string
+		protocols  = data.GetSupportedVPNProtocols()
+	)
+
+	for _, user := range data.Users {
+		id := user.UserID.String()
+
+		if other, ok := ip4L[user.IPv4Addr]; ok {
+			add(FsckProblem{Check: FsckDuplicateIPv4, UserID: id, Detail: fmt.Sprintf("%s is used by %s", user.IPv4Addr, other)})
+		}
+
+		ip4L[user.IPv4Addr] = id
+
+		if other, ok := ip6L[user.IPv6Addr]; ok {
+			add(FsckProblem{Check: FsckDuplicateIPv6, UserID: id, Detail: fmt.Sprintf("%s is used by %s", user.IPv6Addr, other)})
+		}
+
+		ip6L[user.IPv6Addr] = id
+
+		if !data.IPv4CGNAT.Contains(user.IPv4Addr) {
+			add(FsckProblem{Check: FsckIPv4OutOfRange, UserID: id, Detail: fmt.Sprintf("%s is out of %s", user.IPv4Addr, data.IPv4CGNAT)})
+		}
+
+		if !data.IPv6ULA.Contains(user.IPv6Addr) {
+			add(FsckProblem{Check: FsckIPv6OutOfRange, UserID: id, Detail: fmt.Sprintf("%s is out of %s", user.IPv6Addr, data.IPv6ULA)})
+		}
+
+		if other, ok := nameL[user.Name]; ok {
+			add(FsckProblem{Check: FsckNameCollision, UserID: id, Detail: fmt.Sprintf("%q is used by %s", user.Name, other)})
+		}
+
+		nameL[user.Name] = id
+
+		if user.IsBrigadier {
+			brigadiers = append(brigadiers, id)
+		}
+
+		if flags := delayedFlags(user); len(flags) > 0 && !user.IsBlocked {
+			add(FsckProblem{Check: FsckDelayedNotBlocked, UserID: id, Detail: strings.Join(flags, ",") + " is still set, replay the delayed operations"})
+		}
+
+		if missing := missingSecrets(user, protocols); len(missing) > 0 {
+			add(FsckProblem{Check: FsckMissingSecret, UserID: id, Detail: strings.Join(missing, ",")})
+		}
+	}
+
+	if len(brigadiers) > 1 {
+		add(FsckProblem{Check: FsckManyBrigadiers, Detail: strings.Join(brigadiers, ",")})
+	}
+
+	prefixes := make([]string, 0, len(data.Endpoints))
+	for prefix := range data.Endpoints {
+		prefixes = append(prefixes, prefix)
+	}
+
+	sort.Strings(prefixes)
+
+	// the same as mergeStats does.
+	lowLimit := now.Add(-endpointsTTL)
+	for _, prefix := range prefixes {
+		updated := data.Endpoints[prefix]
+		if !updated.Before(lowLimit) {
+			continue
+		}
+
+		if fix {
+			delete(data.Endpoints, prefix)
+		}
+
+		add(FsckProblem{Check: FsckStaleEndpoint, Detail: fmt.Sprintf("%s updated at %s", prefix, updated.Format(time.RFC3339)), Fixable: true, Fixed: fix})
+	}
+
+	return report
+}
+
+func delayedFlags(user *User) []string {
+	var flags []string
+
+	if user.DelayedCreation {
+		flags = append(flags, "delayed_creation")
+	}
+
+	if user.DelayedDeletion {
+		flags = append(flags, "delayed_deletion")
+	}
+
+	if user.DelayedBlocking {
+		flags = append(flags, "delayed_blocking")
+	}
+
+	if user.DelayedReplay {
+		flags = append(flags, "delayed_reply")
+	}
+
+	return flags
+}
+
+// missingSecrets - empty encrypted fields for the enabled protocols.
+func missingSecrets(user *User, protocols []string) []string {
+	var missing []string
+
+	check := func(name string, fields ...string) {
+		for _, f := range fields {
+			if f == "" {
+				missing = append(missing, name)
+
+				return
+			}
+		}
+	}
+
+	for _, proto := range protocols {
+		switch proto {
+		case "wireguard":
+			check(proto, string(user.WgPublicKey), string(user.WgPSKRouterEnc), string(user.WgPSKShufflerEnc))
+		case "openvpn":
+			check(proto, user.OvCSRGzipBase64)
+		case "l2tp":
+			check(proto, user.IPSecUsernameRouterEnc, user.IPSecUsernameShufflerEnc, user.IPSecPasswordRouterEnc, user.IPSecPasswordShufflerEnc)
+		case "shadowsocks":
+			check(proto, user.OutlineSecretRouterEnc, user.OutlineSecretShufflerEnc)
+		case "cloak":
+			check(proto, user.CloakByPassUIDRouterEnc, user.CloakByPassUIDShufflerEnc)
+		case "proto0":
+			check(proto, user.Proto0SecretRouterEnc, user.Proto0SecretShufflerEnc)
+		}
+	}
+
+	return missing
+}
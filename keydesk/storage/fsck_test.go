@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestFsckBrigade(t *testing.T) {
+	now := time.Now().UTC()
+
+	user := func(name, ip4, ip6 string) *User {
+		return &User{
+			UserID:           uuid.New(),
+			Name:             name,
+			IPv4Addr:         netip.MustParseAddr(ip4),
+			IPv6Addr:         netip.MustParseAddr(ip6),
+			WgPublicKey:      []byte("pub"),
+			WgPSKRouterEnc:   []byte("router"),
+			WgPSKShufflerEnc: []byte("shuffler"),
+		}
+	}
+
+	data := &Brigade{
+		BrigadeID: "test",
+		IPv4CGNAT: netip.MustParsePrefix("100.64.0.0/24"),
+		IPv6ULA:   netip.MustParsePrefix("fd00::/64"),
+		Users: []*User{
+			user("001 a", "100.64.0.2", "fd00::2"),
+			user("001 a", "100.64.0.2", "fd00::3"),
+			user("002 b", "10.0.0.1", "fd01::1"),
+		},
+		Endpoints: UsersNetworks{
+			"1.1.1.0/24": now,
+			"2.2.2.0/24": now.Add(-24 * time.Hour),
+		},
+	}
+
+	data.Users[0].IsBrigadier = true
+	data.Users[1].IsBrigadier = true
+	data.Users[2].DelayedBlocking = true
+	data.Users[2].WgPSKShufflerEnc = nil
+
+	report := FsckBrigade(data, now, 12*time.Hour, true)
+
+	got := map[string]int{}
+	for _, p := range report.Problems {
+		got[p.Check]++
+	}
+
+	want := map[string]int{
+		FsckDuplicateIPv4:     1,
+		FsckNameCollision:     1,
+		FsckIPv4OutOfRange:    1,
+		FsckIPv6OutOfRange:    1,
+		FsckManyBrigadiers:    1,
+		FsckDelayedNotBlocked: 1,
+		FsckMissingSecret:     1,
+		FsckStaleEndpoint:     1,
+	}
+
+	for check, n := range want {
+		if got[check] != n {
+			t.Errorf("%s: got %d, want %d", check, got[check], n)
+		}
+	}
+
+	if len(report.Problems) != len(want) {
+		t.Errorf("problems: got %d, want %d: %+v", len(report.Problems), len(want), report.Problems)
+	}
+
+	if report.Fixed != 1 || len(data.Endpoints) != 1 {
+		t.Errorf("fixed: %d, endpoints left: %d", report.Fixed, len(data.Endpoints))
+	}
+}
@@ -0,0 +1,174 @@
+package storage
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+// GeoLocation - the network country and autonomous system, zero if unknown.
+type GeoLocation struct {
+	Country string // ISO 3166-1 alpha-2
+	ASN     uint32
+	ASOrg   string
+}
+
+// GeoLocator - locates the users networks, nil disables the geo summary.
+type GeoLocator interface {
+	Locate(addr netip.Addr) (GeoLocation, error)
+}
+
+// CountryUsers - the active users from the country.
+type CountryUsers struct {
+	Country string `json:"country"`
+	Users   int    `json:"users"`
+}
+
+// ASNUsers - the active users from the autonomous system.
+type ASNUsers struct {
+	ASN   uint32 `json:"asn"`
+	Org   string `json:"org,omitempty"`
+	Users int    `json:"users"`
+}
+
+// GeoSummary - the active users by countries and autonomous systems.
+// Only the aggregates are kept, the user locations are not stored.
+type GeoSummary struct {
+	Update    time.Time      `json:"update"`
+	Countries []CountryUsers `json:"countries,omitempty"`
+	ASNs      []ASNUsers     `json:"asns,omitempty"`
+	Unknown   int            `json:"unknown,omitempty"` // the active users without the location
+}
+
+// geoCounter - counts the active users locations of the stats round.
+type geoCounter struct {
+	locator   GeoLocator
+	countries map[string]int
+	asns      map[uint32]*ASNUsers
+	unknown   int
+}
+
+func newGeoCounter(locator GeoLocator) *geoCounter {
+	if locator == nil {
+		return nil
+	}
+
+	return &geoCounter{
+		locator:   locator,
+		countries: map[string]int{},
+		asns:      map[uint32]*ASNUsers{},
+	}
+}
+
+// add - count the user by the network, the invalid prefix is unknown.
+func (c *geoCounter) add(userID string, prefix netip.Prefix) {
+	if c == nil {
+		return
+	}
+
+	var loc GeoLocation
+
+	if prefix.IsValid() {
+		var err error
+
+		loc, err = c.locator.Locate(prefix.Addr())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "User %s geo: %s\n", userID, err)
+		}
+	}
+
+	if loc.Country == "" && loc.ASN == 0 {
+		c.unknown++
+
+		return
+	}
+
+	if loc.Country != "" {
+		c.countries[loc.Country]++
+	}
+
+	if loc.ASN != 0 {
+		as, ok := c.asns[loc.ASN]
+		if !ok {
+			as = &ASNUsers{ASN: loc.ASN, Org: loc.ASOrg}
+			c.asns[loc.ASN] = as
+		}
+
+		as.Users++
+	}
+}
+
+// summary - the most users first.
+func (c *geoCounter) summary(now time.Time) *GeoSummary {
+	if c == nil {
+		return nil
+	}
+
+	s := &GeoSummary{Update: now, Unknown: c.unknown}
+
+	for country, users := range c.countries {
+		s.Countries = append(s.Countries, CountryUsers{Country: country, Users: users})
+	}
+
+	slices.SortFunc(s.Countries, func(a, b CountryUsers) int {
+		return cmp.Or(cmp.Compare(b.Users, a.Users), cmp.Compare(a.Country, b.Country))
+	})
+
+	for _, as := range c.asns {
+		s.ASNs = append(s.ASNs, *as)
+	}
+
+	slices.SortFunc(s.ASNs, func(a, b ASNUsers) int {
+		return cmp.Or(cmp.Compare(b.Users, a.Users), cmp.Compare(a.ASN, b.ASN))
+	})
+
+	return s
+}
+
+// userNetwork - the user network of the latest seen protocol.
+func userNetwork(id string, lastSeenMap *vpnapi.WgStatLastActivityMap, endpointMap *vpnapi.WgStatEndpointMap) netip.Prefix {
+	var (
+		network netip.Prefix
+		seen    time.Time
+	)
+
+	for _, proto := range []struct {
+		lastSeen  map[string]time.Time
+		endpoints map[string]netip.Prefix
+	}{
+		{lastSeenMap.Wg, endpointMap.Wg},
+		{lastSeenMap.IPSec, endpointMap.IPSec},
+		{lastSeenMap.Ovc, endpointMap.Ovc},
+		{lastSeenMap.Olc, endpointMap.Olc},
+		{lastSeenMap.Outline, endpointMap.Outline},
+		{lastSeenMap.Proto0, endpointMap.Proto0},
+	} {
+		prefix, ok := proto.endpoints[id]
+		if !ok || !prefix.IsValid() {
+			continue
+		}
+
+		if ts := proto.lastSeen[id]; !network.IsValid() || ts.After(seen) {
+			network, seen = prefix, ts
+		}
+	}
+
+	return network
+}
+
+// GetGeoSummary - the last stats round geo summary, nil if there is no one.
+func (db *BrigadeStorage) GetGeoSummary() (*GeoSummary, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	return data.Geo, nil
+}
@@ -0,0 +1,96 @@
+package storage
+
+import (
+	"encoding/base64"
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+type testLocator map[netip.Addr]GeoLocation
+
+func (l testLocator) Locate(addr netip.Addr) (GeoLocation, error) {
+	return l[addr], nil
+}
+
+func TestMergeStatsGeo(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// seen - how long ago the peer was seen and its subnet, per protocol.
+	type seen map[string]struct {
+		ago    time.Duration
+		subnet string
+	}
+
+	peers := []seen{
+		{"wireguard": {time.Minute, "10.0.0.0/24"}},
+		{"wireguard": {time.Hour, "10.0.0.0/24"}, "ipsec": {time.Minute, "192.0.2.0/24"}},
+		{"wireguard": {time.Minute, "(none)"}},
+		{"wireguard": {time.Minute, "198.51.100.0/24"}},
+		{}, // never connected
+	}
+
+	data := &Brigade{}
+	in := &vpnapi.WGStatsIn{
+		Code:      "0",
+		Timestamp: strconv.FormatInt(now.Unix(), 10),
+		Data: vpnapi.WgStatDataIn{
+			WgStatLastseenMapIn: vpnapi.WgStatLastseenMapIn{},
+			WgStatEndpointMapIn: vpnapi.WgStatEndpointMapIn{},
+		},
+	}
+
+	for i, peer := range peers {
+		key := []byte("merge-stats-geo-wg-public-key-0" + strconv.Itoa(i))
+		id := base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(key)
+
+		data.Users = append(data.Users, &User{WgPublicKey: key})
+
+		lastSeen, endpoints := vpnapi.WgStatLastseenDataIn{}, vpnapi.WgStatEndpointDataIn{}
+		for proto, s := range peer {
+			lastSeen[proto] = vpnapi.WgStatLastseenIn{Timestamp: strconv.FormatInt(now.Add(-s.ago).Unix(), 10)}
+			endpoints[proto] = vpnapi.WgStatEndpointIn{Subnet: s.subnet}
+		}
+
+		in.Data.WgStatLastseenMapIn[id] = lastSeen
+		in.Data.WgStatEndpointMapIn[id] = endpoints
+	}
+
+	locator := testLocator{
+		netip.MustParseAddr("10.0.0.0"):     {Country: "RU", ASN: 8359, ASOrg: "MTS"},
+		netip.MustParseAddr("192.0.2.0"):    {Country: "DE", ASN: 3320, ASOrg: "DTAG"},
+		netip.MustParseAddr("198.51.100.0"): {Country: "RU"},
+	}
+
+	if err := mergeStats(data, now, in, false, time.Hour, 24*time.Hour, 0, ThrottlePolicy{}, locator); err != nil {
+		t.Fatalf("merge: %s", err)
+	}
+
+	geo := data.Geo
+	if geo == nil || !geo.Update.Equal(now) {
+		t.Fatalf("summary: %+v", geo)
+	}
+
+	if want := []CountryUsers{{"RU", 2}, {"DE", 1}}; len(geo.Countries) != len(want) || geo.Countries[0] != want[0] || geo.Countries[1] != want[1] {
+		t.Errorf("countries: %+v, want %+v", geo.Countries, want)
+	}
+
+	if want := []ASNUsers{{3320, "DTAG", 1}, {8359, "MTS", 1}}; len(geo.ASNs) != len(want) || geo.ASNs[0] != want[0] || geo.ASNs[1] != want[1] {
+		t.Errorf("asns: %+v, want %+v", geo.ASNs, want)
+	}
+
+	if geo.Unknown != 1 {
+		t.Errorf("unknown: %d", geo.Unknown)
+	}
+
+	if err := mergeStats(data, now.Add(time.Minute), in, false, time.Hour, 24*time.Hour, 0, ThrottlePolicy{}, nil); err != nil {
+		t.Fatalf("merge: %s", err)
+	}
+
+	if data.Geo != nil {
+		t.Errorf("no locator: %+v", data.Geo)
+	}
+}
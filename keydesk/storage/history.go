@@ -0,0 +1,154 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxTrafficHistoryDays - the daily traffic history depth.
+const MaxTrafficHistoryDays = 90
+
+// DailyHistory - daily traffic ring buffer, Head is the Last day slot.
+type DailyHistory struct {
+	Last time.Time `json:"last,omitempty"` // the newest day, 00:00 UTC
+	Head int       `json:"head"`
+	Rx   []uint64  `json:"rx,omitempty"`
+	Tx   []uint64  `json:"tx,omitempty"`
+}
+
+// DailyTraffic - the day traffic per protocol.
+type DailyTraffic struct {
+	Day     time.Time
+	Total   RxTx
+	Wg      RxTx
+	IPSec   RxTx
+	Ovc     RxTx
+	Outline RxTx
+	Proto0  RxTx
+}
+
+func dayOf(ts time.Time) time.Time {
+	year, month, day := ts.UTC().Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from) / (24 * time.Hour))
+}
+
+// add - count the traffic to the now day, the skipped days are zeroed.
+func (h *DailyHistory) add(now time.Time, rx, tx uint64) {
+	day := dayOf(now)
+
+	switch {
+	case len(h.Rx) == 0 || len(h.Rx) != len(h.Tx) || h.Head >= len(h.Rx):
+		h.Rx, h.Tx, h.Head, h.Last = []uint64{0}, []uint64{0}, 0, day
+	case day.After(h.Last):
+		for gap := min(daysBetween(h.Last, day), MaxTrafficHistoryDays); gap > 0; gap-- {
+			if len(h.Rx) < MaxTrafficHistoryDays {
+				h.Rx, h.Tx = append(h.Rx, 0), append(h.Tx, 0)
+				h.Head = len(h.Rx) - 1
+
+				continue
+			}
+
+			h.Head = (h.Head + 1) % MaxTrafficHistoryDays
+			h.Rx[h.Head], h.Tx[h.Head] = 0, 0
+		}
+
+		h.Last = day
+	}
+
+	h.Rx[h.Head] += rx
+	h.Tx[h.Head] += tx
+}
+
+// days - the n days up to the now day, the oldest first.
+func (h *DailyHistory) days(n int, now time.Time) []RxTx {
+	out := make([]RxTx, n)
+	if len(h.Rx) == 0 || len(h.Rx) != len(h.Tx) || h.Head >= len(h.Rx) {
+		return out
+	}
+
+	behind := daysBetween(h.Last, dayOf(now))
+	for i := range out {
+		age := n - 1 - i - behind
+		if age < 0 || age >= len(h.Rx) {
+			continue
+		}
+
+		idx := (h.Head - age + len(h.Rx)) % len(h.Rx)
+		out[i] = RxTx{Rx: h.Rx[idx], Tx: h.Tx[idx]}
+	}
+
+	return out
+}
+
+func dailyTraffic(n int, now time.Time, total, wg, ipsec, ovc, outline, proto0 *DateSummaryNetCounters) []DailyTraffic {
+	if n < 1 {
+		n = 1
+	}
+
+	if n > MaxTrafficHistoryDays {
+		n = MaxTrafficHistoryDays
+	}
+
+	var (
+		totalDays   = total.History.days(n, now)
+		wgDays      = wg.History.days(n, now)
+		ipsecDays   = ipsec.History.days(n, now)
+		ovcDays     = ovc.History.days(n, now)
+		outlineDays = outline.History.days(n, now)
+		proto0Days  = proto0.History.days(n, now)
+	)
+
+	today := dayOf(now)
+	out := make([]DailyTraffic, n)
+
+	for i := range out {
+		out[i] = DailyTraffic{
+			Day:     today.AddDate(0, 0, i-n+1),
+			Total:   totalDays[i],
+			Wg:      wgDays[i],
+			IPSec:   ipsecDays[i],
+			Ovc:     ovcDays[i],
+			Outline: outlineDays[i],
+			Proto0:  proto0Days[i],
+		}
+	}
+
+	return out
+}
+
+// GetUserTraffic - the user daily traffic for the last days.
+func (db *BrigadeStorage) GetUserTraffic(id string, days int) ([]DailyTraffic, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	for _, user := range data.Users {
+		if user.UserID.String() == id {
+			q := &user.Quotas
+
+			return dailyTraffic(days, db.now(), &q.CountersTotal, &q.CountersWg, &q.CountersIPSec, &q.CountersOvc, &q.CountersOutline, &q.CountersProto0), nil
+		}
+	}
+
+	return nil, ErrUserNotFound
+}
+
+// GetBrigadeTraffic - the brigade daily traffic for the last days.
+func (db *BrigadeStorage) GetBrigadeTraffic(days int) ([]DailyTraffic, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	return dailyTraffic(days, db.now(), &data.TotalTraffic, &data.TotalWgTraffic, &data.TotalIPSecTraffic, &data.TotalOvcTraffic, &data.TotalOutlineTraffic, &data.TotalProto0Traffic), nil
+}
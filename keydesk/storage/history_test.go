@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDailyHistory(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	h := &DailyHistory{}
+
+	h.add(start, 1, 2)
+	h.add(start.Add(time.Hour), 1, 2)
+	h.add(start.AddDate(0, 0, 2), 5, 6)
+
+	days := h.days(4, start.AddDate(0, 0, 2))
+	want := []RxTx{{}, {Rx: 2, Tx: 4}, {}, {Rx: 5, Tx: 6}}
+
+	for i := range want {
+		if days[i] != want[i] {
+			t.Errorf("day %d: %+v, want %+v", i, days[i], want[i])
+		}
+	}
+
+	// wrap the ring, the first days are gone.
+	for i := 3; i < MaxTrafficHistoryDays+10; i++ {
+		h.add(start.AddDate(0, 0, i), uint64(i), 0)
+	}
+
+	now := start.AddDate(0, 0, MaxTrafficHistoryDays+9)
+	if len(h.Rx) != MaxTrafficHistoryDays {
+		t.Fatalf("ring size: %d", len(h.Rx))
+	}
+
+	days = h.days(MaxTrafficHistoryDays, now)
+	if days[0].Rx != 10 || days[MaxTrafficHistoryDays-1].Rx != MaxTrafficHistoryDays+9 {
+		t.Errorf("wrapped: first %d, last %d", days[0].Rx, days[MaxTrafficHistoryDays-1].Rx)
+	}
+
+	// no stats for two days yet.
+	days = h.days(3, now.AddDate(0, 0, 2))
+	if days[0].Rx != MaxTrafficHistoryDays+9 || days[1].Rx != 0 || days[2].Rx != 0 {
+		t.Errorf("idle days: %+v", days)
+	}
+
+	counters := &DateSummaryNetCounters{}
+	incDateSwitchRelated(start, 1, 1, counters)
+	incDateSwitchRelated(start.Add(time.Minute), 3, 4, counters)
+
+	traffic := dailyTraffic(2, start, counters, counters, counters, counters, counters, counters)
+	if traffic[1].Total != counters.Daily || !traffic[1].Day.Equal(dayOf(start)) {
+		t.Errorf("fed by the daily counters: %+v, daily %+v", traffic[1], counters.Daily)
+	}
+}
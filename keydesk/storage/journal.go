@@ -0,0 +1,685 @@
+package storage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib"
+)
+
+// JournalMaxSize - the journal is rotated to .1 after this size,
+// the new one starts with the base entry, the seq goes on.
+const JournalMaxSize = 64 << 20
+
+// Journal operations.
+const (
+	JournalOpBase = "base" // full brigade at the journal start
+	JournalOpRaw  = "raw"  // prefix for commits from the raw tools
+)
+
+// Journal patch operations.
+const (
+	JournalPatchAdd     = "add"
+	JournalPatchRemove  = "remove"
+	JournalPatchReplace = "replace"
+)
+
+var (
+	// ErrJournalPosition - no such journal position.
+	ErrJournalPosition = errors.New("no such journal position")
+	// ErrJournalPath - patch path doesn't match the document.
+	ErrJournalPath = errors.New("wrong journal path")
+)
+
+// JournalEntry - one committed mutation.
+type JournalEntry struct {
+	Seq  uint64         `json:"seq"`
+	Time time.Time      `json:"time"`
+	Op   string         `json:"op"`
+	Diff []JournalPatch `json:"diff"`
+}
+
+// JournalPatch - JSON diff element, a subset of RFC 6902.
+// Path is a JSON pointer, the empty path is the whole brigade.
+type JournalPatch struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// journalBackend - append-only journal in front of any backend.
+type journalBackend struct {
+	Backend
+	Filename string      // i.e. /home/<BrigadeID>/brigade.journal
+	Clock    kdlib.Clock // nil means the system clock
+}
+
+type journalTx struct {
+	BackendTx
+	filename string
+	clock    kdlib.Clock
+	prev     []byte // last loaded or stored brigade
+}
+
+func (b *journalBackend) Open() (BackendTx, error) {
+	tx, err := b.Backend.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &journalTx{BackendTx: tx, filename: b.Filename, clock: b.Clock}, nil
+}
+
+// OpenRead - readers don't write the journal.
+func (b *journalBackend) OpenRead() (BackendTx, error) {
+	if rb, ok := b.Backend.(ReadBackend); ok {
+		return rb.OpenRead()
+	}
+
+	return b.Backend.Open()
+}
+
+func (tx *journalTx) Load(data *Brigade) error {
+	if err := tx.BackendTx.Load(data); err != nil {
+		return err
+	}
+
+	buf, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	tx.prev = buf
+
+	return nil
+}
+
+func (tx *journalTx) Store(data *Brigade) error {
+	return tx.commit("store", data)
+}
+
+// commit - write the journal entry ahead, then store the brigade.
+// The entry is cut off if the store is failed.
+func (tx *journalTx) commit(op string, data *Brigade) error {
+	cur, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	diff, err := JournalDiff(tx.prev, cur)
+	if err != nil {
+		return fmt.Errorf("journal diff: %w", err)
+	}
+
+	if len(diff) == 0 {
+		return tx.BackendTx.Store(data)
+	}
+
+	j, err := openJournal(tx.filename, tx.prev, tx.clock)
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	defer j.Close()
+
+	offset, err := j.append(op, diff)
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	if err := tx.BackendTx.Store(data); err != nil {
+		if terr := j.f.Truncate(offset); terr != nil {
+			return fmt.Errorf("journal truncate: %w (store: %w)", terr, err)
+		}
+
+		return err
+	}
+
+	tx.prev = cur
+
+	return nil
+}
+
+type journalFile struct {
+	f     *os.File
+	seq   uint64
+	clock kdlib.Clock
+}
+
+// openJournal - open the journal to append, rotate it if it's too big.
+// The journal always starts with the base entry if there is a brigade before it,
+// the seq goes on from the rotated one.
+func openJournal(filename string, prev []byte, clock kdlib.Clock) (*journalFile, error) {
+	if fi, err := os.Stat(filename); err == nil && fi.Size() > JournalMaxSize {
+		if err := os.Rename(filename, filename+".1"); err != nil {
+			return nil, fmt.Errorf("rotate: %w", err)
+		}
+	}
+
+	f, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_APPEND, FileDbMode)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	j := &journalFile{f: f, clock: clock}
+
+	last, err := lastJournalEntry(f)
+	switch {
+	case err == nil:
+		j.seq = last.Seq + 1
+	case errors.Is(err, io.EOF):
+		seq, err := rotatedJournalSeq(filename + ".1")
+		if err != nil {
+			f.Close()
+
+			return nil, fmt.Errorf("rotated: %w", err)
+		}
+
+		j.seq = seq
+
+		if prev == nil {
+			break
+		}
+
+		diff, err := JournalDiff(nil, prev)
+		if err != nil {
+			f.Close()
+
+			return nil, fmt.Errorf("base: %w", err)
+		}
+
+		if _, err := j.append(JournalOpBase, diff); err != nil {
+			f.Close()
+
+			return nil, fmt.Errorf("base: %w", err)
+		}
+	default:
+		f.Close()
+
+		return nil, fmt.Errorf("last entry: %w", err)
+	}
+
+	return j, nil
+}
+
+// restartJournal - replace the journal and the rotated one by the single base entry,
+// the seq goes on. It drops the history, i.e. with the plaintext secrets.
+func restartJournal(filename string, base []byte, clock kdlib.Clock) error {
+	seq, err := rotatedJournalSeq(filename)
+	if err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	if seq == 0 {
+		if seq, err = rotatedJournalSeq(filename + ".1"); err != nil {
+			return fmt.Errorf("rotated: %w", err)
+		}
+	}
+
+	diff, err := JournalDiff(nil, base)
+	if err != nil {
+		return fmt.Errorf("base: %w", err)
+	}
+
+	tmp := filename + ".tmp"
+
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, FileDbMode)
+	if err != nil {
+		return fmt.Errorf("create: %w", err)
+	}
+
+	j := &journalFile{f: f, seq: seq, clock: clock}
+
+	if _, err := j.append(JournalOpBase, diff); err != nil {
+		j.Close()
+		os.Remove(tmp)
+
+		return fmt.Errorf("base: %w", err)
+	}
+
+	if err := j.Close(); err != nil {
+		os.Remove(tmp)
+
+		return fmt.Errorf("close: %w", err)
+	}
+
+	if err := os.Rename(tmp, filename); err != nil {
+		os.Remove(tmp)
+
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	if err := os.Remove(filename + ".1"); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove rotated: %w", err)
+	}
+
+	return nil
+}
+
+// rotatedJournalSeq - the seq after the last entry of the journal file, 0 if there is none.
+func rotatedJournalSeq(filename string) (uint64, error) {
+	f, err := os.OpenFile(filename, os.O_RDWR, FileDbMode)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, fmt.Errorf("open: %w", err)
+	}
+
+	defer f.Close()
+
+	last, err := lastJournalEntry(f)
+	switch {
+	case err == nil:
+		return last.Seq + 1, nil
+	case errors.Is(err, io.EOF):
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("last entry: %w", err)
+	}
+}
+
+// append - append and sync the entry, returns the offset before it.
+func (j *journalFile) append(op string, diff []JournalPatch) (int64, error) {
+	offset, err := j.f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return 0, fmt.Errorf("seek: %w", err)
+	}
+
+	buf, err := json.Marshal(&JournalEntry{
+		Seq:  j.seq,
+		Time: kdlib.Now(j.clock).UTC(),
+		Op:   op,
+		Diff: diff,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encode: %w", err)
+	}
+
+	if _, err := j.f.Write(append(buf, '\n')); err != nil {
+		return 0, fmt.Errorf("write: %w", err)
+	}
+
+	if err := j.f.Sync(); err != nil {
+		return 0, fmt.Errorf("sync: %w", err)
+	}
+
+	j.seq++
+
+	return offset, nil
+}
+
+func (j *journalFile) Close() error {
+	return j.f.Close()
+}
+
+// lastJournalEntry - read the last line of the journal backwards.
+// A torn line after a crash is cut off, it was never committed.
+func lastJournalEntry(f *os.File) (*JournalEntry, error) {
+	const chunk = 64 << 10
+
+	end, err := f.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("seek: %w", err)
+	}
+
+	var (
+		tail []byte
+		torn bool
+	)
+
+	for pos := end; pos > 0; {
+		n := min(int64(chunk), pos)
+		pos -= n
+
+		buf := make([]byte, n)
+		if _, err := f.ReadAt(buf, pos); err != nil {
+			return nil, fmt.Errorf("read: %w", err)
+		}
+
+		tail = append(buf, tail...)
+
+		if !torn && tail[len(tail)-1] != '\n' {
+			torn = true
+		}
+
+		if torn {
+			i := bytes.LastIndexByte(tail, '\n')
+			if i < 0 && pos > 0 {
+				continue
+			}
+
+			if err := f.Truncate(pos + int64(i+1)); err != nil {
+				return nil, fmt.Errorf("truncate torn: %w", err)
+			}
+
+			tail, torn = tail[:i+1], false
+		}
+
+		line := bytes.TrimRight(tail, "\n")
+		if len(line) == 0 {
+			if pos == 0 {
+				break
+			}
+
+			continue
+		}
+
+		if i := bytes.LastIndexByte(line, '\n'); i >= 0 || pos == 0 {
+			entry := &JournalEntry{}
+			if err := json.Unmarshal(line[i+1:], entry); err != nil {
+				return nil, fmt.Errorf("decode: %w", err)
+			}
+
+			return entry, nil
+		}
+	}
+
+	return nil, io.EOF
+}
+
+// ReadJournal - read all the journal entries.
+func ReadJournal(filename string) ([]JournalEntry, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+
+	defer f.Close()
+
+	var entries []JournalEntry
+
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		// skip the torn line, see lastJournalEntry.
+		if len(bytes.TrimSpace(line)) > 0 && bytes.HasSuffix(line, []byte{'\n'}) {
+			entry := JournalEntry{}
+			if err := json.Unmarshal(line, &entry); err != nil {
+				return nil, fmt.Errorf("entry %d: %w", len(entries), err)
+			}
+
+			entries = append(entries, entry)
+		}
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return entries, nil
+			}
+
+			return nil, fmt.Errorf("read: %w", err)
+		}
+	}
+}
+
+// RebuildJournal - the brigade as it was after the entry with the seq.
+func RebuildJournal(entries []JournalEntry, seq uint64) (*Brigade, error) {
+	var (
+		doc   any
+		found bool
+	)
+
+	for _, entry := range entries {
+		if entry.Seq > seq {
+			break
+		}
+
+		var err error
+		if doc, err = JournalApply(doc, entry.Diff); err != nil {
+			return nil, fmt.Errorf("apply %d: %w", entry.Seq, err)
+		}
+
+		found = entry.Seq == seq
+	}
+
+	if !found || doc == nil {
+		return nil, fmt.Errorf("%d: %w", seq, ErrJournalPosition)
+	}
+
+	buf, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+
+	data := &Brigade{}
+	if err := json.Unmarshal(buf, data); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	return data, nil
+}
+
+// JournalDiff - diff between two JSON documents, nil is an absent document.
+func JournalDiff(prev, cur []byte) ([]JournalPatch, error) {
+	var a, b any
+
+	if prev != nil {
+		if err := json.Unmarshal(prev, &a); err != nil {
+			return nil, fmt.Errorf("prev: %w", err)
+		}
+	}
+
+	if err := json.Unmarshal(cur, &b); err != nil {
+		return nil, fmt.Errorf("cur: %w", err)
+	}
+
+	if prev == nil {
+		return []JournalPatch{{Op: JournalPatchReplace, Path: "", Value: cur}}, nil
+	}
+
+	return diffValue(nil, "", a, b)
+}
+
+func diffValue(diff []JournalPatch, path string, a, b any) ([]JournalPatch, error) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			p := path + "/" + escapePointer(k)
+			x, inA := av[k]
+			y, inB := bv[k]
+
+			var err error
+
+			switch {
+			case !inB:
+				diff = append(diff, JournalPatch{Op: JournalPatchRemove, Path: p})
+			case !inA:
+				diff, err = appendPatch(diff, JournalPatchAdd, p, y)
+			default:
+				diff, err = diffValue(diff, p, x, y)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return diff, nil
+	case []any:
+		bv, ok := b.([]any)
+		if !ok {
+			break
+		}
+
+		var err error
+
+		for i := 0; i < min(len(av), len(bv)); i++ {
+			if diff, err = diffValue(diff, path+"/"+strconv.Itoa(i), av[i], bv[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		for i := len(av); i < len(bv); i++ {
+			if diff, err = appendPatch(diff, JournalPatchAdd, path+"/"+strconv.Itoa(i), bv[i]); err != nil {
+				return nil, err
+			}
+		}
+
+		// remove from the end, so indexes stay valid.
+		for i := len(av) - 1; i >= len(bv); i-- {
+			diff = append(diff, JournalPatch{Op: JournalPatchRemove, Path: path + "/" + strconv.Itoa(i)})
+		}
+
+		return diff, nil
+	default:
+		if a == b {
+			return diff, nil
+		}
+	}
+
+	return appendPatch(diff, JournalPatchReplace, path, b)
+}
+
+func appendPatch(diff []JournalPatch, op, path string, v any) ([]JournalPatch, error) {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return append(diff, JournalPatch{Op: op, Path: path, Value: buf}), nil
+}
+
+// JournalApply - apply the diff to the decoded JSON document.
+func JournalApply(doc any, diff []JournalPatch) (any, error) {
+	for _, p := range diff {
+		var (
+			v   any
+			err error
+		)
+
+		if p.Op != JournalPatchRemove {
+			if err := json.Unmarshal(p.Value, &v); err != nil {
+				return nil, fmt.Errorf("%s: %w", p.Path, err)
+			}
+		}
+
+		if doc, err = applyPatch(doc, splitPointer(p.Path), p.Op, v); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", p.Op, p.Path, err)
+		}
+	}
+
+	return doc, nil
+}
+
+func applyPatch(doc any, path []string, op string, v any) (any, error) {
+	if len(path) == 0 {
+		if op != JournalPatchReplace {
+			return nil, ErrJournalPath
+		}
+
+		return v, nil
+	}
+
+	key, last := path[0], len(path) == 1
+
+	switch d := doc.(type) {
+	case map[string]any:
+		if !last {
+			x, ok := d[key]
+			if !ok {
+				return nil, ErrJournalPath
+			}
+
+			x, err := applyPatch(x, path[1:], op, v)
+			if err != nil {
+				return nil, err
+			}
+
+			d[key] = x
+
+			return d, nil
+		}
+
+		switch op {
+		case JournalPatchRemove:
+			delete(d, key)
+		default:
+			d[key] = v
+		}
+
+		return d, nil
+	case []any:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i > len(d) {
+			return nil, ErrJournalPath
+		}
+
+		if !last {
+			if i == len(d) {
+				return nil, ErrJournalPath
+			}
+
+			if d[i], err = applyPatch(d[i], path[1:], op, v); err != nil {
+				return nil, err
+			}
+
+			return d, nil
+		}
+
+		switch {
+		case op == JournalPatchAdd && i == len(d):
+			return append(d, v), nil
+		case op == JournalPatchAdd:
+			return append(d[:i], append([]any{v}, d[i:]...)...), nil
+		case i == len(d):
+			return nil, ErrJournalPath
+		case op == JournalPatchRemove:
+			return append(d[:i], d[i+1:]...), nil
+		default:
+			d[i] = v
+
+			return d, nil
+		}
+	}
+
+	return nil, ErrJournalPath
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+func escapePointer(s string) string {
+	return pointerEscaper.Replace(s)
+}
+
+func splitPointer(path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i := range parts {
+		parts[i] = pointerUnescaper.Replace(parts[i])
+	}
+
+	return parts
+}
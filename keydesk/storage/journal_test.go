@@ -0,0 +1,108 @@
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJournalRebuild(t *testing.T) {
+	if err := db.CreateMessage("journal"); err != nil {
+		t.Fatalf("save notification: %s", err)
+	}
+
+	if err := db.SetVIP(true); err != nil {
+		t.Fatalf("set vip: %s", err)
+	}
+
+	entries, err := ReadJournal(filepath.Join(filepath.Dir(db.BrigadeFilename), BrigadeJournalFilename))
+	if err != nil {
+		t.Fatalf("read journal: %s", err)
+	}
+
+	if len(entries) < 2 {
+		t.Fatalf("journal entries: %d", len(entries))
+	}
+
+	last := entries[len(entries)-1]
+	if last.Op != JournalOpRaw+":storage.test:storage.(*BrigadeStorage).SetVIP" {
+		t.Errorf("op: %s", last.Op)
+	}
+
+	rebuilt, err := RebuildJournal(entries, last.Seq)
+	if err != nil {
+		t.Fatalf("rebuild: %s", err)
+	}
+
+	f, err := (&JSONBackend{Filename: db.BrigadeFilename, Spinlock: db.BrigadeSpinlock}).Open()
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	defer f.Close()
+
+	stored := &Brigade{}
+	if err := f.Load(stored); err != nil {
+		t.Fatalf("load: %s", err)
+	}
+
+	a, _ := json.Marshal(rebuilt)
+	b, _ := json.Marshal(stored)
+
+	if string(a) != string(b) {
+		t.Errorf("rebuilt brigade mismatch:\n%s\n%s", a, b)
+	}
+
+	if _, err := RebuildJournal(entries, last.Seq+1); err == nil {
+		t.Error("rebuild beyond the end: expected error")
+	}
+
+	prev, err := RebuildJournal(entries, last.Seq-1)
+	if err != nil {
+		t.Fatalf("rebuild previous: %s", err)
+	}
+
+	if prev.VIP != 0 || rebuilt.VIP != 1 {
+		t.Errorf("vip: %d -> %d", prev.VIP, rebuilt.VIP)
+	}
+}
+
+func TestJournalRotationSeq(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), BrigadeJournalFilename)
+	clock := NewTestClock(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+	prev := []byte(`{"brigade_id":"rotation"}`)
+
+	j, err := openJournal(filename, prev, clock)
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	if _, err := j.append("store", []JournalPatch{{Op: JournalPatchReplace, Path: "/brigade_id", Value: []byte(`"rotated"`)}}); err != nil {
+		t.Fatalf("append: %s", err)
+	}
+
+	j.Close()
+
+	if err := os.Rename(filename, filename+".1"); err != nil {
+		t.Fatalf("rotate: %s", err)
+	}
+
+	j, err = openJournal(filename, prev, clock)
+	if err != nil {
+		t.Fatalf("open after the rotation: %s", err)
+	}
+
+	j.Close()
+
+	entries, err := ReadJournal(filename)
+	if err != nil {
+		t.Fatalf("read: %s", err)
+	}
+
+	// the base entry goes on after the rotated base and store ones.
+	if len(entries) != 1 || entries[0].Seq != 2 || entries[0].Op != JournalOpBase || !entries[0].Time.Equal(clock.Now()) {
+		t.Errorf("entries: %+v", entries)
+	}
+}
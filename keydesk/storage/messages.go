@@ -2,20 +2,15 @@ package storage
 
 import (
 	"fmt"
-	"time"
 )
 
 func (db *BrigadeStorage) GetMessages() ([]Message, error) {
-	f, brigade, err := db.openWithReading()
+	f, brigade, err := db.openReadOnly()
 	if err != nil {
 		return nil, fmt.Errorf("open to read: %w", err)
 	}
 	defer f.Close()
 
-	if err := f.Commit(); err != nil {
-		return nil, fmt.Errorf("commit: %w", err)
-	}
-
 	return brigade.Messages, nil
 }
 
@@ -29,7 +24,7 @@ func (db *BrigadeStorage) CreateMessage(text string) error {
 	brigade.Messages = append(brigade.Messages, Message{
 		Text:      text,
 		IsRead:    false,
-		CreatedAt: time.Now(),
+		CreatedAt: db.now(),
 	})
 
 	if err := f.Commit(brigade); err != nil {
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// ErrMigrationEnv - the migration step needs something absent in the MigrationEnv.
+var ErrMigrationEnv = errors.New("migration env is not provided")
+
+// Migration kinds.
+const (
+	MigrationKindBrigade = "brigade"
+	MigrationKindUser    = "user"
+	MigrationKindQuota   = "quota"
+)
+
+// MigrationEnv - external data for the migration steps.
+// The steps which need an absent field stop the migration
+// with ErrMigrationEnv, the brigade stays at the previous version.
+type MigrationEnv struct {
+	Proto0FakeDomains func() []string // i.e. keydesk.GetRandomSites0
+}
+
+// Migration - schema migration step.
+// Version is the version after the step, steps are ordered by it.
+type Migration[T any] struct {
+	Version int
+	Name    string
+	Up      func(v *T, env *MigrationEnv) error
+}
+
+// MigrationResult - applied (or planned in dry run) step.
+type MigrationResult struct {
+	Kind    string         `json:"kind"`
+	Version int            `json:"version"`
+	Name    string         `json:"name"`
+	Target  string         `json:"target,omitempty"` // user ID for user and quota steps
+	Diff    []JournalPatch `json:"diff"`
+}
+
+// BrigadeMigrations - brigade schema steps up to BrigadeVersion.
+var BrigadeMigrations = []Migration[Brigade]{
+	{
+		Version: 7,
+		Name:    "endpoint_port",
+		Up: func(data *Brigade, _ *MigrationEnv) error {
+			if data.EndpointPort == 0 {
+				data.EndpointPort = 51820
+			}
+
+			return nil
+		},
+	},
+	{
+		Version: 11,
+		Name:    "proto0_fake_domains",
+		Up: func(data *Brigade, env *MigrationEnv) error {
+			if data.Proto0Port == 0 || len(data.Proto0FakeDomains) != 0 {
+				return nil
+			}
+
+			if env.Proto0FakeDomains == nil {
+				return ErrMigrationEnv
+			}
+
+			data.Proto0FakeDomains = env.Proto0FakeDomains()
+
+			return nil
+		},
+	},
+}
+
+// UserMigrations - user schema steps up to UserVersion.
+var UserMigrations = []Migration[User]{}
+
+// QuotaMigrations - quota schema steps up to QuotaVesrion.
+var QuotaMigrations = []Migration[Quota]{}
+
+// migrateSteps - apply the steps newer than ver, stop on ErrMigrationEnv.
+func migrateSteps[T any](v *T, ver *int, last int, steps []Migration[T], env *MigrationEnv, report func(step Migration[T], diff []JournalPatch)) (bool, error) {
+	if *ver >= last {
+		return false, nil
+	}
+
+	changed := false
+
+	for _, step := range steps {
+		if step.Version <= *ver {
+			continue
+		}
+
+		prev, err := json.Marshal(v)
+		if err != nil {
+			return changed, fmt.Errorf("%s: %w", step.Name, err)
+		}
+
+		if err := step.Up(v, env); err != nil {
+			return changed, fmt.Errorf("%s: %w", step.Name, err)
+		}
+
+		*ver = step.Version
+		changed = true
+
+		cur, err := json.Marshal(v)
+		if err != nil {
+			return changed, fmt.Errorf("%s: %w", step.Name, err)
+		}
+
+		diff, err := JournalDiff(prev, cur)
+		if err != nil {
+			return changed, fmt.Errorf("%s: %w", step.Name, err)
+		}
+
+		report(step, diff)
+	}
+
+	// the rest versions have no steps.
+	*ver = last
+
+	return true, nil
+}
+
+// migrateBrigade - bring the brigade, users and quotas to the current versions.
+func migrateBrigade(data *Brigade, env *MigrationEnv) ([]MigrationResult, bool, error) {
+	var results []MigrationResult
+
+	if env == nil {
+		env = &MigrationEnv{}
+	}
+
+	changed, err := migrateSteps(data, &data.Ver, BrigadeVersion, BrigadeMigrations, env, func(step Migration[Brigade], diff []JournalPatch) {
+		results = append(results, MigrationResult{Kind: MigrationKindBrigade, Version: step.Version, Name: step.Name, Diff: diff})
+	})
+	if err != nil {
+		return results, changed, fmt.Errorf("brigade: %w", err)
+	}
+
+	for _, user := range data.Users {
+		target := user.UserID.String()
+
+		ok, err := migrateSteps(user, &user.Ver, UserVersion, UserMigrations, env, func(step Migration[User], diff []JournalPatch) {
+			results = append(results, MigrationResult{Kind: MigrationKindUser, Version: step.Version, Name: step.Name, Target: target, Diff: diff})
+		})
+		changed = changed || ok
+
+		if err != nil {
+			return results, changed, fmt.Errorf("user %s: %w", target, err)
+		}
+
+		ok, err = migrateSteps(&user.Quotas, &user.Quotas.Ver, QuotaVesrion, QuotaMigrations, env, func(step Migration[Quota], diff []JournalPatch) {
+			results = append(results, MigrationResult{Kind: MigrationKindQuota, Version: step.Version, Name: step.Name, Target: target, Diff: diff})
+		})
+		changed = changed || ok
+
+		if err != nil {
+			return results, changed, fmt.Errorf("quota %s: %w", target, err)
+		}
+	}
+
+	return results, changed, nil
+}
+
+// Migrate - run the schema migrations in one transaction.
+// In dry run the brigade is not stored, results have the planned diffs.
+// If a step needs something absent in the env, the previous steps are kept.
+func (db *BrigadeStorage) Migrate(dryRun bool) ([]MigrationResult, error) {
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	return db.migrate(f, data, dryRun)
+}
+
+func (db *BrigadeStorage) migrate(f BackendTx, data *Brigade, dryRun bool) ([]MigrationResult, error) {
+	results, changed, err := migrateBrigade(data, &db.Migration)
+	if err != nil && !errors.Is(err, ErrMigrationEnv) {
+		return results, fmt.Errorf("migrate: %w", err)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Migration stopped: %s\n", err)
+	}
+
+	if !changed || dryRun {
+		return results, nil
+	}
+
+	if err := commitBrigade(f, "migrate", data); err != nil {
+		return results, fmt.Errorf("commit: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testMigrationEnv = &MigrationEnv{
+	Proto0FakeDomains: func() []string { return []string{"example.com", "example.org"} },
+}
+
+// migrationFixture - testdata/migrations/<kind>_v<version>_<name>.json
+type migrationFixture struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+func checkMigrationsOrder[T any](t *testing.T, kind string, steps []Migration[T], last int) {
+	t.Helper()
+
+	prev := 0
+	for _, step := range steps {
+		if step.Version <= prev || step.Version > last {
+			t.Errorf("%s %s: step version %d is out of order or above %d", kind, step.Name, step.Version, last)
+		}
+
+		prev = step.Version
+	}
+}
+
+func TestMigrationsOrder(t *testing.T) {
+	checkMigrationsOrder(t, MigrationKindBrigade, BrigadeMigrations, BrigadeVersion)
+	checkMigrationsOrder(t, MigrationKindUser, UserMigrations, UserVersion)
+	checkMigrationsOrder(t, MigrationKindQuota, QuotaMigrations, QuotaVesrion)
+}
+
+func TestBrigadeMigrationFixtures(t *testing.T) {
+	for _, step := range BrigadeMigrations {
+		name := fmt.Sprintf("%s_v%d_%s.json", MigrationKindBrigade, step.Version, step.Name)
+
+		t.Run(name, func(t *testing.T) {
+			buf, err := os.ReadFile(filepath.Join("testdata", "migrations", name))
+			if err != nil {
+				t.Fatalf("fixture: %s", err)
+			}
+
+			fixture := migrationFixture{}
+			if err := json.Unmarshal(buf, &fixture); err != nil {
+				t.Fatalf("fixture: %s", err)
+			}
+
+			before, after := &Brigade{}, &Brigade{}
+			if err := json.Unmarshal(fixture.Before, before); err != nil {
+				t.Fatalf("before: %s", err)
+			}
+
+			if err := json.Unmarshal(fixture.After, after); err != nil {
+				t.Fatalf("after: %s", err)
+			}
+
+			if err := step.Up(before, testMigrationEnv); err != nil {
+				t.Fatalf("up: %s", err)
+			}
+
+			got, _ := json.Marshal(before)
+			want, _ := json.Marshal(after)
+
+			if string(got) != string(want) {
+				t.Errorf("mismatch:\n got: %s\nwant: %s", got, want)
+			}
+		})
+	}
+}
+
+func TestMigrateBrigade(t *testing.T) {
+	data := &Brigade{
+		Ver:        6,
+		BrigadeID:  "test",
+		Proto0Port: 8443,
+		Users:      []*User{{Ver: 5, Name: "001"}},
+	}
+
+	// without env the migration stops before the proto0 step.
+	results, changed, err := migrateBrigade(data, nil)
+	if !errors.Is(err, ErrMigrationEnv) {
+		t.Fatalf("expected env error: %v", err)
+	}
+
+	if !changed || data.Ver != 7 || len(results) != 1 || data.Users[0].Ver != 5 {
+		t.Fatalf("partial migration: ver %d, results %d, user ver %d", data.Ver, len(results), data.Users[0].Ver)
+	}
+
+	results, changed, err = migrateBrigade(data, testMigrationEnv)
+	if err != nil {
+		t.Fatalf("migrate: %s", err)
+	}
+
+	if !changed || data.Ver != BrigadeVersion || data.Users[0].Ver != UserVersion || data.Users[0].Quotas.Ver != QuotaVesrion {
+		t.Fatalf("versions: brigade %d, user %d, quota %d", data.Ver, data.Users[0].Ver, data.Users[0].Quotas.Ver)
+	}
+
+	if len(results) != 1 || results[0].Name != "proto0_fake_domains" || len(results[0].Diff) == 0 {
+		t.Fatalf("results: %+v", results)
+	}
+
+	if _, changed, _ := migrateBrigade(data, testMigrationEnv); changed {
+		t.Error("second run changed the brigade")
+	}
+}
@@ -0,0 +1,381 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+// Outbox operation kinds, the endpoint calls of the user peer.
+const (
+	OutboxPeerAdd     = "peer_add"
+	OutboxPeerDel     = "peer_del"
+	OutboxThrottleOn  = "throttle_on"
+	OutboxThrottleOff = "throttle_off"
+)
+
+const (
+	// OutboxMaxAttempts - the failed attempts before the operation is dead-lettered.
+	OutboxMaxAttempts = 20
+	// OutboxBackoffMin - the retry delay after the first failure, doubled by every next one.
+	OutboxBackoffMin = 10 * time.Second
+	// OutboxBackoffMax - the retry delay limit.
+	OutboxBackoffMax = time.Hour
+	// OutboxCallTimeout - the endpoint call limit, the calls are made under the brigade lock.
+	OutboxCallTimeout = 10 * time.Second
+)
+
+var (
+	// ErrOutboxOpNotFound - no such outbox operation.
+	ErrOutboxOpNotFound = errors.New("outbox operation not found")
+	// errOutboxSuperseded - the operation has lost its meaning, it is dropped.
+	errOutboxSuperseded = errors.New("superseded")
+	// errOutboxUnknownKind - the operation can't be done, it is dead-lettered.
+	errOutboxUnknownKind = errors.New("unknown kind")
+)
+
+// OutboxOp - the pending endpoint operation of the user peer.
+// The peer is kept by the key, the user can be gone by the time.
+type OutboxOp struct {
+	ID          uuid.UUID `json:"id"` // the idempotency key, the same for all the attempts
+	Kind        string    `json:"kind"`
+	UserID      uuid.UUID `json:"user_id"`
+	WgPublicKey []byte    `json:"wg_public_key"`
+	CreatedAt   time.Time `json:"created_at"`
+	Attempts    int       `json:"attempts,omitempty"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	DeadAt      time.Time `json:"dead_at,omitempty"` // dead-lettered, no more attempts
+}
+
+// IsDead - the operation is dead-lettered.
+func (op *OutboxOp) IsDead() bool {
+	return !op.DeadAt.IsZero()
+}
+
+// outboxBackoff - the retry delay after the attempts.
+func outboxBackoff(attempts int) time.Duration {
+	d := OutboxBackoffMin
+	for i := 1; i < attempts && d < OutboxBackoffMax; i++ {
+		d *= 2
+	}
+
+	return min(d, OutboxBackoffMax)
+}
+
+// retryableEndpointError - the endpoint is unavailable or asks to retry,
+// the other failures are final.
+func retryableEndpointError(err error) bool {
+	return vpnapi.IsRetryable(err)
+}
+
+// superseded - the kinds the newer operation makes pointless.
+func superseded(kind string) []string {
+	switch kind {
+	case OutboxPeerDel:
+		return []string{OutboxPeerAdd, OutboxPeerDel, OutboxThrottleOn, OutboxThrottleOff}
+	case OutboxPeerAdd:
+		return []string{OutboxPeerAdd, OutboxPeerDel}
+	case OutboxThrottleOn, OutboxThrottleOff:
+		return []string{OutboxThrottleOn, OutboxThrottleOff}
+	}
+
+	return nil
+}
+
+// pendingOps - the peer has the operations waiting for a retry.
+func (data *Brigade) pendingOps(wgPub []byte) bool {
+	for _, op := range data.Outbox {
+		if !op.IsDead() && bytes.Equal(op.WgPublicKey, wgPub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// enqueue - queue the peer operation, the operations it supersedes are dropped.
+func (data *Brigade) enqueue(kind string, user *User, now time.Time) {
+	kinds := superseded(kind)
+
+	kept := data.Outbox[:0]
+	for _, op := range data.Outbox {
+		if bytes.Equal(op.WgPublicKey, user.WgPublicKey) && slices.Contains(kinds, op.Kind) {
+			fmt.Fprintf(os.Stderr, "Outbox %s %s superseded by %s\n", op.ID, op.Kind, kind)
+
+			continue
+		}
+
+		kept = append(kept, op)
+	}
+
+	data.Outbox = append(kept, OutboxOp{
+		ID:          uuid.New(),
+		Kind:        kind,
+		UserID:      user.UserID,
+		WgPublicKey: user.WgPublicKey,
+		CreatedAt:   now,
+		NextAttempt: now,
+	})
+}
+
+// peerCall - make the user peer endpoint call, it is queued behind the pending
+// operations of the peer or on the transport failure. The API errors are returned.
+func (db *BrigadeStorage) peerCall(ctx context.Context, data *Brigade, kind string, user *User) error {
+	now := db.now()
+
+	if !data.pendingOps(user.WgPublicKey) {
+		err := db.execPeerOp(ctx, db.EndpointClient(), data, kind, user.WgPublicKey, user)
+		if err == nil || !retryableEndpointError(err) {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "User %s %s queued: %s\n", user.UserID, kind, err)
+	}
+
+	data.enqueue(kind, user, now)
+
+	return nil
+}
+
+// execPeerOp - the endpoint call of the operation kind, the user is nil if it is gone.
+func (db *BrigadeStorage) execPeerOp(ctx context.Context, client vpnapi.EndpointClient, data *Brigade, kind string, wgPub []byte, user *User) error {
+	switch kind {
+	case OutboxPeerAdd:
+		if user == nil {
+			return errOutboxSuperseded
+		}
+
+		_, err := client.PeerAdd(ctx, peerAddRequest(data, user))
+
+		return err
+	case OutboxPeerDel:
+		return client.PeerDel(ctx, peerRequest(data, wgPub))
+	case OutboxThrottleOn:
+		return client.ThrottleOn(ctx, peerRequest(data, wgPub))
+	case OutboxThrottleOff:
+		return client.ThrottleOff(ctx, peerRequest(data, wgPub))
+	}
+
+	return fmt.Errorf("%w: %q", errOutboxUnknownKind, kind)
+}
+
+// throttlingState - the endpoint throttling of the user after the pending operations.
+func (data *Brigade) throttlingState(user *User) bool {
+	on := user.Quotas.ThrottlingOn
+
+	for _, op := range data.Outbox {
+		if op.IsDead() || !bytes.Equal(op.WgPublicKey, user.WgPublicKey) {
+			continue
+		}
+
+		switch op.Kind {
+		case OutboxThrottleOn:
+			on = true
+		case OutboxThrottleOff:
+			on = false
+		}
+	}
+
+	return on
+}
+
+// opDone - the user state after the operation is done on the endpoint.
+func opDone(op *OutboxOp, user *User) {
+	if user == nil {
+		return
+	}
+
+	switch op.Kind {
+	case OutboxThrottleOn:
+		user.Quotas.ThrottlingOn = true
+	case OutboxThrottleOff:
+		user.Quotas.ThrottlingOn = false
+	}
+}
+
+// outboxDue - the outbox has the operations to retry now, it is read without the lock.
+func (db *BrigadeStorage) outboxDue() (bool, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return false, fmt.Errorf("db: %w", err)
+	}
+
+	f.Close()
+
+	now := db.now()
+
+	for _, op := range data.Outbox {
+		if !op.IsDead() && !op.NextAttempt.After(now) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// opUser - the current user of the operation peer, nil if it is gone or blocked.
+func (data *Brigade) opUser(op *OutboxOp) *User {
+	for _, user := range data.Users {
+		if user.UserID == op.UserID && bytes.Equal(user.WgPublicKey, op.WgPublicKey) && !user.IsBlocked {
+			return user
+		}
+	}
+
+	return nil
+}
+
+// ProcessOutbox - retry the due outbox operations in the order, a failure holds
+// the next operations of the peer. The done and failed attempts are returned.
+// The brigade is locked only if some operation is due, every call is bounded by OutboxCallTimeout.
+func (db *BrigadeStorage) ProcessOutbox(ctx context.Context) (int, int, error) {
+	if due, err := db.outboxDue(); err != nil || !due {
+		return 0, 0, err
+	}
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return 0, 0, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	if len(data.Outbox) == 0 {
+		return 0, 0, nil
+	}
+
+	var (
+		now          = db.now()
+		client       = db.EndpointClient()
+		held         = map[string]bool{}
+		done, failed int
+		dropped      bool
+	)
+
+	kept := data.Outbox[:0]
+	for _, op := range data.Outbox {
+		peer := string(op.WgPublicKey)
+
+		if op.IsDead() || held[peer] || op.NextAttempt.After(now) || ctx.Err() != nil {
+			if !op.IsDead() {
+				held[peer] = true
+			}
+
+			kept = append(kept, op)
+
+			continue
+		}
+
+		user := data.opUser(&op)
+
+		callCtx, cancel := context.WithTimeout(ctx, OutboxCallTimeout)
+		err := db.execPeerOp(callCtx, client, data, op.Kind, op.WgPublicKey, user)
+
+		cancel()
+
+		switch {
+		case err == nil:
+			done++
+
+			opDone(&op, user)
+
+			fmt.Fprintf(os.Stderr, "Outbox %s %s (%s) done after %d failures\n", op.ID, op.Kind, base64.StdEncoding.EncodeToString(op.WgPublicKey), op.Attempts)
+
+			continue
+		case errors.Is(err, errOutboxSuperseded):
+			dropped = true
+
+			fmt.Fprintf(os.Stderr, "Outbox %s %s superseded: the user is gone\n", op.ID, op.Kind)
+
+			continue
+		}
+
+		failed++
+
+		op.Attempts++
+		op.LastError = err.Error()
+
+		switch {
+		case !retryableEndpointError(err) || op.Attempts >= OutboxMaxAttempts:
+			op.DeadAt = now
+
+			fmt.Fprintf(os.Stderr, "Outbox %s %s dead-lettered after %d attempts: %s\n", op.ID, op.Kind, op.Attempts, err)
+		default:
+			op.NextAttempt = now.Add(outboxBackoff(op.Attempts))
+			held[peer] = true
+		}
+
+		kept = append(kept, op)
+	}
+
+	data.Outbox = kept
+
+	if done+failed == 0 && !dropped {
+		return 0, 0, nil
+	}
+
+	if err := commitBrigade(f, "outbox", data); err != nil {
+		return done, failed, fmt.Errorf("save: %w", err)
+	}
+
+	return done, failed, nil
+}
+
+// Outbox - the pending and dead-lettered endpoint operations.
+func (db *BrigadeStorage) Outbox() ([]OutboxOp, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	f.Close()
+
+	return data.Outbox, nil
+}
+
+// RetryOutboxOp - retry the operation with the next processing, the dead-lettered one too.
+func (db *BrigadeStorage) RetryOutboxOp(id uuid.UUID) error {
+	return db.modifyOutboxOp(id, "outbox_retry", func(data *Brigade, i int) {
+		data.Outbox[i].Attempts = 0
+		data.Outbox[i].DeadAt = time.Time{}
+		data.Outbox[i].NextAttempt = db.now()
+	})
+}
+
+// DropOutboxOp - forget the operation, the endpoint is left as it is.
+func (db *BrigadeStorage) DropOutboxOp(id uuid.UUID) error {
+	return db.modifyOutboxOp(id, "outbox_drop", func(data *Brigade, i int) {
+		data.Outbox = append(data.Outbox[:i], data.Outbox[i+1:]...)
+	})
+}
+
+func (db *BrigadeStorage) modifyOutboxOp(id uuid.UUID, name string, modify func(data *Brigade, i int)) error {
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	for i := range data.Outbox {
+		if data.Outbox[i].ID == id {
+			modify(data, i)
+
+			if err := commitBrigade(f, name, data); err != nil {
+				return fmt.Errorf("save: %w", err)
+			}
+
+			return nil
+		}
+	}
+
+	return ErrOutboxOpNotFound
+}
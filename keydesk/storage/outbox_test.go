@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+func TestOutboxEnqueue(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	user := &User{UserID: uuid.New(), WgPublicKey: []byte("outbox-user")}
+	other := &User{UserID: uuid.New(), WgPublicKey: []byte("outbox-other")}
+
+	data := &Brigade{}
+
+	data.enqueue(OutboxPeerAdd, user, now)
+	data.enqueue(OutboxThrottleOn, user, now)
+	data.enqueue(OutboxThrottleOn, other, now)
+
+	// the opposite throttling replaces the pending one.
+	data.enqueue(OutboxThrottleOff, user, now)
+
+	if len(data.Outbox) != 3 || data.Outbox[2].Kind != OutboxThrottleOff {
+		t.Fatalf("throttle: %+v", data.Outbox)
+	}
+
+	// the delete cancels the pending add and throttling of the peer only.
+	data.enqueue(OutboxPeerDel, user, now)
+
+	if len(data.Outbox) != 2 || data.Outbox[0].Kind != OutboxThrottleOn || data.Outbox[1].Kind != OutboxPeerDel {
+		t.Fatalf("delete: %+v", data.Outbox)
+	}
+
+	if !data.pendingOps(user.WgPublicKey) || data.pendingOps([]byte("outbox-none")) {
+		t.Errorf("pending: %+v", data.Outbox)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	for attempts, want := range map[int]time.Duration{
+		1:  OutboxBackoffMin,
+		2:  2 * OutboxBackoffMin,
+		4:  8 * OutboxBackoffMin,
+		20: OutboxBackoffMax,
+	} {
+		if got := outboxBackoff(attempts); got != want {
+			t.Errorf("attempts %d: %s, want %s", attempts, got, want)
+		}
+	}
+
+	if retryableEndpointError(&vpnapi.APIResponse{Code: "128"}) || !retryableEndpointError(&vpnapi.APIResponse{Code: "999"}) || !retryableEndpointError(fmt.Errorf("do req: %w: %w", vpnapi.ErrUnavailable, errors.New("refused"))) {
+		t.Errorf("retryable")
+	}
+}
+
+func TestOutboxThrottlingState(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	user := &User{UserID: uuid.New(), WgPublicKey: []byte("outbox-user")}
+
+	data := &Brigade{Users: []*User{user}}
+
+	// the queued call doesn't change the state, the pending one is seen.
+	data.enqueue(OutboxThrottleOn, user, now)
+
+	if user.Quotas.ThrottlingOn || !data.throttlingState(user) {
+		t.Fatalf("pending: %+v", user.Quotas)
+	}
+
+	// the dead-lettered one is not.
+	data.Outbox[0].DeadAt = now
+
+	if data.throttlingState(user) {
+		t.Errorf("dead: %+v", data.Outbox)
+	}
+
+	opDone(&data.Outbox[0], data.opUser(&data.Outbox[0]))
+
+	if !user.Quotas.ThrottlingOn {
+		t.Errorf("done: %+v", user.Quotas)
+	}
+}
@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib"
+)
+
+// MaxQuotaResetDay - the last allowed monthly reset day, clamped in the shorter months.
+const MaxQuotaResetDay = 31
+
+// ErrInvalidQuota - the quota override is out of range.
+var ErrInvalidQuota = errors.New("invalid quota")
+
+// UserQuotaLimits - per user monthly quota override, nil keeps the current value.
+type UserQuotaLimits struct {
+	Monthly  *uint64 // bytes, 0 - the brigade default
+	ResetDay *int    // 0..MaxQuotaResetDay, 0 - the 1st as the brigade resets
+}
+
+// empty - nothing to override.
+func (l UserQuotaLimits) empty() bool {
+	return l.Monthly == nil && l.ResetDay == nil
+}
+
+func (l UserQuotaLimits) validate() error {
+	if l.ResetDay != nil && (*l.ResetDay < 0 || *l.ResetDay > MaxQuotaResetDay) {
+		return fmt.Errorf("%w: reset day %d", ErrInvalidQuota, *l.ResetDay)
+	}
+
+	return nil
+}
+
+// monthlyLimit - the user monthly limit, def is the brigade default.
+func (q *Quota) monthlyLimit(def int) uint64 {
+	if q.LimitMonthly > 0 {
+		return q.LimitMonthly
+	}
+
+	return uint64(def)
+}
+
+// nextMonthlyResetOn - the next reset on the user reset day.
+func (q *Quota) nextMonthlyResetOn(now time.Time) time.Time {
+	return kdlib.NextMonthlyResetOnDay(now, q.LimitMonthlyResetDay)
+}
+
+// setLimits - apply the override keeping the quota spent in the current period.
+func (q *Quota) setLimits(limits UserQuotaLimits, now time.Time, def int) {
+	if limits.Monthly != nil {
+		prev := q.monthlyLimit(def)
+
+		spent := uint64(0)
+		if q.LimitMonthlyRemaining < prev {
+			spent = prev - q.LimitMonthlyRemaining
+		}
+
+		q.LimitMonthly = *limits.Monthly
+
+		q.LimitMonthlyRemaining = 0
+		if limit := q.monthlyLimit(def); limit > spent {
+			q.LimitMonthlyRemaining = limit - spent
+		}
+	}
+
+	if limits.ResetDay != nil {
+		q.LimitMonthlyResetDay = *limits.ResetDay
+		q.LimitMonthlyResetOn = q.nextMonthlyResetOn(now)
+	}
+}
+
+// SetUserQuota - set the user monthly quota override.
+// The revision is the expected brigade revision, nil means any.
+// The empty override changes nothing, the user is only checked.
+func (db *BrigadeStorage) SetUserQuota(id string, limits UserQuotaLimits, revision *uint64) error {
+	if err := limits.validate(); err != nil {
+		return err
+	}
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	if err := checkRevision(data, revision); err != nil {
+		return err
+	}
+
+	var user *User
+
+	for _, u := range data.Users {
+		if u.UserID.String() == id {
+			user = u
+
+			break
+		}
+	}
+
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	if limits.empty() {
+		return nil
+	}
+
+	now := db.now()
+
+	user.Quotas.setLimits(limits, now, db.MonthlyQuotaRemaining)
+	user.addEvent(UserEventQuotaChanged, now, fmt.Sprintf("%d bytes, day %d", user.Quotas.monthlyLimit(db.MonthlyQuotaRemaining), user.Quotas.LimitMonthlyResetDay))
+
+	if err := commitBrigade(f, "user_quota", data); err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "User %s quota: %d bytes, reset on %s\n", id, user.Quotas.monthlyLimit(db.MonthlyQuotaRemaining), user.Quotas.LimitMonthlyResetOn.Format(time.DateOnly))
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestQuotaLimits(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	q := &Quota{LimitMonthlyRemaining: 70 * gb}
+
+	limit, day := uint64(500*gb), 20
+	q.setLimits(UserQuotaLimits{Monthly: &limit, ResetDay: &day}, now, 100*gb)
+
+	if q.LimitMonthlyRemaining != 470*gb {
+		t.Errorf("spent kept: %d GB remaining", q.LimitMonthlyRemaining/gb)
+	}
+
+	if want := time.Date(2024, 3, 20, 0, 0, 0, 0, time.UTC); !q.LimitMonthlyResetOn.Equal(want) {
+		t.Errorf("reset on: %s", q.LimitMonthlyResetOn)
+	}
+
+	limit = 20 * gb
+	q.setLimits(UserQuotaLimits{Monthly: &limit}, now, 100*gb)
+
+	if q.LimitMonthlyRemaining != 0 || q.LimitMonthlyResetDay != day {
+		t.Errorf("overspent: %d remaining, day %d", q.LimitMonthlyRemaining, q.LimitMonthlyResetDay)
+	}
+
+	limit = 0
+	q.setLimits(UserQuotaLimits{Monthly: &limit}, now, 100*gb)
+
+	if q.monthlyLimit(100*gb) != 100*gb || q.LimitMonthlyRemaining != 80*gb {
+		t.Errorf("default: %d GB limit, %d GB remaining", q.monthlyLimit(100*gb)/gb, q.LimitMonthlyRemaining/gb)
+	}
+
+	day = 0
+	q.setLimits(UserQuotaLimits{ResetDay: &day}, now, 100*gb)
+
+	if want := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC); !q.LimitMonthlyResetOn.Equal(want) {
+		t.Errorf("zero day, the 1st: %s", q.LimitMonthlyResetOn)
+	}
+
+	day = MaxQuotaResetDay + 1
+	if err := db.SetUserQuota("absent", UserQuotaLimits{ResetDay: &day}, nil); !errors.Is(err, ErrInvalidQuota) {
+		t.Errorf("invalid day: %v", err)
+	}
+
+	if err := db.SetUserQuota("absent", UserQuotaLimits{}, nil); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("absent user: %v", err)
+	}
+}
+
+func TestEmptyQuotaLimits(t *testing.T) {
+	id := uuid.New()
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	data.Users = append(data.Users, &User{UserID: id, WgPublicKey: []byte("empty-quota-user-wg-public-key32")})
+
+	if err := commitBrigade(f, "test", data); err != nil {
+		f.Close()
+		t.Fatalf("commit: %s", err)
+	}
+
+	f.Close()
+
+	defer func() {
+		if err := db.DeleteUser(context.Background(), id.String(), false, false, nil); err != nil {
+			t.Errorf("delete: %s", err)
+		}
+	}()
+
+	_, revision, err := db.ListUsers()
+	if err != nil {
+		t.Fatalf("list users: %s", err)
+	}
+
+	if err := db.SetUserQuota(id.String(), UserQuotaLimits{}, nil); err != nil {
+		t.Fatalf("empty quota: %s", err)
+	}
+
+	users, current, err := db.ListUsers()
+	if err != nil {
+		t.Fatalf("list users: %s", err)
+	}
+
+	if current != revision {
+		t.Errorf("revision: %d after %d", current, revision)
+	}
+
+	for _, user := range users {
+		if user.UserID == id && len(user.Events) > 0 {
+			t.Errorf("events: %v", user.Events)
+		}
+	}
+}
@@ -2,13 +2,14 @@ package storage
 
 import (
 	"fmt"
-
-	"github.com/vpngen/keydesk/kdlib"
+	"os"
+	"path/filepath"
+	"runtime"
 )
 
 // RawOpenDbToModify - open FileDb to modify in raw tool.
 type RawOpenDbToModify struct {
-	f *kdlib.FileDb
+	f BackendTx
 }
 
 // Close - close FileDb.
@@ -17,8 +18,16 @@ func (r *RawOpenDbToModify) Close() error {
 }
 
 // Commit - commit FileDb.
+// The journal operation is the tool name and the calling function.
 func (r *RawOpenDbToModify) Commit(data *Brigade) error {
-	return commitBrigade(r.f, data)
+	op := JournalOpRaw + ":" + filepath.Base(os.Args[0])
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		if fn := runtime.FuncForPC(pc); fn != nil {
+			op += ":" + filepath.Base(fn.Name())
+		}
+	}
+
+	return commitBrigade(r.f, op, data)
 }
 
 // OpenDbToModify - open FileDb to modify.
@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestReadOnlyMethods(t *testing.T) {
+	// the first visit is the only write on the read path.
+	if _, _, err := db.ListUsers(); err != nil {
+		t.Fatalf("list users: %s", err)
+	}
+
+	journal := filepath.Join(filepath.Dir(db.BrigadeFilename), BrigadeJournalFilename)
+
+	before, err := os.Stat(db.BrigadeFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	journalBefore, err := os.Stat(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the editor holds the spinlock, i.e. the stats collector.
+	raw, _, err := db.OpenDbToModify()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reader, _, err := db.openReadOnly()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if _, err := db.GetMessages(); err != nil {
+			done <- err
+
+			return
+		}
+
+		if _, _, _, err := db.GetUsersStats(); err != nil {
+			done <- err
+
+			return
+		}
+
+		if _, _, err := db.ListUsers(); err != nil {
+			done <- err
+
+			return
+		}
+
+		db.IsVIP()
+
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("read: %s", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("readers are blocked")
+	}
+
+	reader.Close()
+	raw.Close()
+
+	after, err := os.Stat(db.BrigadeFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !os.SameFile(before, after) || !before.ModTime().Equal(after.ModTime()) {
+		t.Error("readers rewrote the brigade")
+	}
+
+	journalAfter, err := os.Stat(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if journalBefore.Size() != journalAfter.Size() {
+		t.Error("readers wrote the journal")
+	}
+}
@@ -0,0 +1,215 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+// Reconcile reasons.
+const (
+	ReconcileMissing = "missing" // the brigade peer is not on the endpoint
+	ReconcileExtra   = "extra"   // the endpoint peer is not in the brigade or blocked
+	ReconcileChanged = "changed" // the peer parameters differ, the peer is re-added
+)
+
+// ReconcilePeer - the peer out of sync.
+type ReconcilePeer struct {
+	Reason      string
+	UserID      uuid.UUID // zero for the unknown peers
+	Name        string
+	WgPublicKey []byte
+	Params      []string // the changed peer_add parameters
+}
+
+// ReconcileBrigade - converge the endpoint peers to the brigade, only the needed
+// peer_add and peer_del calls are made. The dry run only reports the diff, it reads
+// the brigade without the lock. The users with the delayed actions or the pending
+// outbox operations are left as they are, every call is bounded by OutboxCallTimeout.
+func (db *BrigadeStorage) ReconcileBrigade(ctx context.Context, dryRun bool) ([]ReconcilePeer, error) {
+	if dryRun {
+		f, data, err := db.openReadOnly()
+		if err != nil {
+			return nil, fmt.Errorf("db: %w", err)
+		}
+
+		f.Close()
+
+		return db.reconcileList(ctx, db.EndpointClient(), data)
+	}
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	client := db.EndpointClient()
+
+	diff, err := db.reconcileList(ctx, client, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var queued bool
+
+	for _, peer := range diff {
+		q, err := db.reconcilePeer(ctx, client, data, peer)
+		queued = queued || q
+
+		if err != nil {
+			err = fmt.Errorf("%s peer %s: %w", peer.Reason, base64.StdEncoding.EncodeToString(peer.WgPublicKey), err)
+
+			if queued {
+				if cerr := commitBrigade(f, "reconcile", data); cerr != nil {
+					return diff, fmt.Errorf("%w, save: %w", err, cerr)
+				}
+			}
+
+			return diff, err
+		}
+	}
+
+	if queued {
+		if err := commitBrigade(f, "reconcile", data); err != nil {
+			return diff, fmt.Errorf("save: %w", err)
+		}
+	}
+
+	return diff, nil
+}
+
+// reconcileList - the endpoint peers list and the diff.
+func (db *BrigadeStorage) reconcileList(ctx context.Context, client vpnapi.EndpointClient, data *Brigade) ([]ReconcilePeer, error) {
+	callCtx, cancel := context.WithTimeout(ctx, OutboxCallTimeout)
+	defer cancel()
+
+	list, err := client.PeerList(callCtx, vpnapi.PeerListRequest{WgPublicKey: data.WgPublicKey})
+	if err != nil {
+		return nil, fmt.Errorf("peer list: %w", err)
+	}
+
+	return reconcileDiff(data, list.Peers)
+}
+
+// reconcileDiff - the brigade users against the endpoint peers, the extra peers go last.
+func reconcileDiff(data *Brigade, peers map[string]vpnapi.PeerInfo) ([]ReconcilePeer, error) {
+	var diff []ReconcilePeer
+
+	seen := make(map[string]struct{}, len(data.Users))
+
+	for _, user := range data.Users {
+		key := base64.StdEncoding.EncodeToString(user.WgPublicKey)
+		seen[key] = struct{}{}
+
+		if user.DelayedCreation || user.DelayedDeletion || user.DelayedReplay || user.DelayedBlocking || data.pendingOps(user.WgPublicKey) {
+			continue
+		}
+
+		info, ok := peers[key]
+
+		switch {
+		case user.IsBlocked && ok:
+			diff = append(diff, ReconcilePeer{Reason: ReconcileExtra, UserID: user.UserID, Name: user.Name, WgPublicKey: user.WgPublicKey})
+		case user.IsBlocked:
+		case !ok:
+			diff = append(diff, ReconcilePeer{Reason: ReconcileMissing, UserID: user.UserID, Name: user.Name, WgPublicKey: user.WgPublicKey})
+		default:
+			if params := peerAddRequest(data, user).PeerInfo().Diff(info); len(params) > 0 {
+				diff = append(diff, ReconcilePeer{Reason: ReconcileChanged, UserID: user.UserID, Name: user.Name, WgPublicKey: user.WgPublicKey, Params: params})
+			}
+		}
+	}
+
+	extra := make([]string, 0, len(peers))
+	for key := range peers {
+		if _, ok := seen[key]; !ok {
+			extra = append(extra, key)
+		}
+	}
+
+	sort.Strings(extra)
+
+	for _, key := range extra {
+		wgPub, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			return nil, fmt.Errorf("peer key %q: %w", key, err)
+		}
+
+		diff = append(diff, ReconcilePeer{Reason: ReconcileExtra, WgPublicKey: wgPub})
+	}
+
+	return diff, nil
+}
+
+// reconcilePeer - the calls to sync the peer, the re-added peer gets the throttling back.
+// The failed add is queued to the outbox, the removed peer is not left behind, true is
+// returned if the brigade outbox is changed.
+func (db *BrigadeStorage) reconcilePeer(ctx context.Context, client vpnapi.EndpointClient, data *Brigade, peer ReconcilePeer) (bool, error) {
+	if peer.Reason != ReconcileMissing {
+		callCtx, cancel := context.WithTimeout(ctx, OutboxCallTimeout)
+		err := client.PeerDel(callCtx, peerRequest(data, peer.WgPublicKey))
+
+		cancel()
+
+		if err != nil {
+			return false, fmt.Errorf("peer del: %w", err)
+		}
+	}
+
+	if peer.Reason == ReconcileExtra {
+		return false, nil
+	}
+
+	for _, user := range data.Users {
+		if user.UserID != peer.UserID {
+			continue
+		}
+
+		// the new peer is not throttled till the throttle_on is done.
+		throttled := user.Quotas.ThrottlingOn
+		user.Quotas.ThrottlingOn = false
+
+		callCtx, cancel := context.WithTimeout(ctx, OutboxCallTimeout)
+		_, err := client.PeerAdd(callCtx, peerAddRequest(data, user))
+
+		cancel()
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "User %s %s queued: %s\n", user.UserID, OutboxPeerAdd, err)
+
+			data.enqueue(OutboxPeerAdd, user, db.now())
+
+			if throttled {
+				data.enqueue(OutboxThrottleOn, user, db.now())
+			}
+
+			return true, nil
+		}
+
+		if throttled {
+			callCtx, cancel := context.WithTimeout(ctx, OutboxCallTimeout)
+			err := client.ThrottleOn(callCtx, peerRequest(data, user.WgPublicKey))
+
+			cancel()
+
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "User %s %s queued: %s\n", user.UserID, OutboxThrottleOn, err)
+
+				data.enqueue(OutboxThrottleOn, user, db.now())
+
+				return true, nil
+			}
+
+			user.Quotas.ThrottlingOn = true
+		}
+	}
+
+	return false, nil
+}
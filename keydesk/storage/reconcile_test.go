@@ -0,0 +1,185 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/internal/fakeendpoint"
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+func TestReconcileBrigade(t *testing.T) {
+	fake := fakeendpoint.New()
+
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	addr := netip.MustParseAddrPort(srv.Listener.Addr().String())
+	client := vpnapi.NewClient(addr, addr)
+
+	db.Endpoint = client
+	defer func() { db.Endpoint = nil }()
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	users := map[string]*User{}
+
+	for i, name := range []string{"synced", "missing", "changed", "blocked"} {
+		wgPub := make([]byte, 32)
+		copy(wgPub, "reconcile-"+name)
+
+		user := &User{
+			UserID:         uuid.New(),
+			Name:           "reconcile " + name,
+			WgPublicKey:    wgPub,
+			WgPSKRouterEnc: []byte("psk"),
+			IPv4Addr:       netip.AddrFrom4([4]byte{100, 64, 1, byte(i + 1)}),
+			IPv6Addr:       netip.AddrFrom16([16]byte{0xfd, 15: byte(i + 1)}),
+		}
+
+		users[name] = user
+		data.Users = append(data.Users, user)
+	}
+
+	ctx := context.Background()
+
+	// the endpoint has the synced, changed, blocked and an unknown peer.
+	for _, name := range []string{"synced", "changed", "blocked"} {
+		if _, err := client.PeerAdd(ctx, peerAddRequest(data, users[name])); err != nil {
+			f.Close()
+			t.Fatalf("peer add: %s", err)
+		}
+	}
+
+	unknown := make([]byte, 32)
+	copy(unknown, "reconcile-unknown")
+	if _, err := client.PeerAdd(ctx, vpnapi.PeerAddRequest{PeerPublicKey: unknown, WgPublicKey: data.WgPublicKey}); err != nil {
+		f.Close()
+		t.Fatalf("peer add: %s", err)
+	}
+
+	users["changed"].CloakByPassUIDRouterEnc = "new-cloak-uid"
+	users["blocked"].IsBlocked = true
+
+	if err := commitBrigade(f, "test", data); err != nil {
+		f.Close()
+		t.Fatalf("commit: %s", err)
+	}
+
+	f.Close()
+
+	want := map[string]string{
+		base64.StdEncoding.EncodeToString(users["missing"].WgPublicKey): ReconcileMissing,
+		base64.StdEncoding.EncodeToString(users["changed"].WgPublicKey): ReconcileChanged,
+		base64.StdEncoding.EncodeToString(users["blocked"].WgPublicKey): ReconcileExtra,
+		base64.StdEncoding.EncodeToString(unknown):                      ReconcileExtra,
+	}
+
+	// the other tests users are missing on the fake too.
+	check := func(diff []ReconcilePeer) map[string]string {
+		got := map[string]string{}
+
+		for _, peer := range diff {
+			key := base64.StdEncoding.EncodeToString(peer.WgPublicKey)
+			if _, ok := want[key]; ok || peer.Reason != ReconcileMissing {
+				got[key] = peer.Reason
+			}
+
+			if peer.Reason == ReconcileChanged && (len(peer.Params) != 1 || peer.Params[0] != "cloak-uid") {
+				t.Errorf("changed params: %v", peer.Params)
+			}
+		}
+
+		return got
+	}
+
+	diff, err := db.ReconcileBrigade(context.Background(), true)
+	if err != nil {
+		t.Fatalf("dry run: %s", err)
+	}
+
+	got := check(diff)
+	for key, reason := range want {
+		if got[key] != reason {
+			t.Errorf("peer %s: %q, want %q", key, got[key], reason)
+		}
+	}
+
+	if len(got) != len(want) {
+		t.Errorf("diff: %v", got)
+	}
+
+	// the dry run makes no calls but the list.
+	if reqs := fake.Requests(); len(reqs) != 5 {
+		t.Errorf("dry run calls: %v", reqs[4:])
+	}
+
+	if _, err := db.ReconcileBrigade(context.Background(), false); err != nil {
+		t.Fatalf("reconcile: %s", err)
+	}
+
+	diff, err = db.ReconcileBrigade(context.Background(), true)
+	if err != nil {
+		t.Fatalf("dry run after: %s", err)
+	}
+
+	for _, peer := range diff {
+		t.Errorf("out of sync after: %s %s", peer.Reason, peer.Name)
+	}
+
+	peers := fake.Peers(nil)
+	if peers[base64.StdEncoding.EncodeToString(users["changed"].WgPublicKey)].CloakUID != "new-cloak-uid" {
+		t.Errorf("changed peer: %+v", peers)
+	}
+
+	// the failed re-add of the changed peer goes to the outbox, the peer is not lost.
+	f, data, err = db.openWithReading()
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	for _, user := range data.Users {
+		if user.UserID == users["changed"].UserID {
+			user.CloakByPassUIDRouterEnc = "newer-cloak-uid"
+		}
+	}
+
+	if err := commitBrigade(f, "test", data); err != nil {
+		f.Close()
+		t.Fatalf("commit: %s", err)
+	}
+
+	f.Close()
+
+	fake.Inject(fakeendpoint.CallPeerAdd, fakeendpoint.Fault{Status: 500, Times: 1})
+
+	if _, err := db.ReconcileBrigade(context.Background(), false); err != nil {
+		t.Fatalf("reconcile with the failed add: %s", err)
+	}
+
+	key := base64.StdEncoding.EncodeToString(users["changed"].WgPublicKey)
+
+	ops, err := db.Outbox()
+	if err != nil || len(ops) != 1 || ops[0].Kind != OutboxPeerAdd || ops[0].UserID != users["changed"].UserID {
+		t.Fatalf("outbox: %+v, %v", ops, err)
+	}
+
+	if _, ok := fake.Peers(nil)[key]; ok {
+		t.Errorf("changed peer before the outbox: %+v", fake.Peers(nil)[key])
+	}
+
+	if done, failed, err := db.ProcessOutbox(context.Background()); err != nil || done != 1 || failed != 0 {
+		t.Fatalf("process: done %d, failed %d, %v", done, failed, err)
+	}
+
+	if peers := fake.Peers(nil); peers[key].CloakUID != "newer-cloak-uid" {
+		t.Errorf("changed peer after the outbox: %+v", peers[key])
+	}
+}
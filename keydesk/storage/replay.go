@@ -1,15 +1,14 @@
 package storage
 
 import (
+	"context"
 	"fmt"
-	"net/netip"
-	"strings"
 
 	"github.com/vpngen/keydesk/vpnapi"
 )
 
-// ReplayBrigade - create brigade config.
-func (db *BrigadeStorage) ReplayBrigade(fresh, bonly, uonly, delayed, donly bool) error {
+// ReplayBrigade - create brigade config, ctx bounds the endpoint calls.
+func (db *BrigadeStorage) ReplayBrigade(ctx context.Context, fresh, bonly, uonly, delayed, donly bool) error {
 	f, data, err := db.openWithReading()
 	if err != nil {
 		return fmt.Errorf("db: %w", err)
@@ -17,42 +16,20 @@ func (db *BrigadeStorage) ReplayBrigade(fresh, bonly, uonly, delayed, donly bool
 
 	defer f.Close()
 
+	client := db.EndpointClient()
+
 	if !donly {
 		if fresh {
 			// if we catch a slowdown problems we need organize queue
-			err = vpnapi.WgDel(data.BrigadeID, db.actualAddrPort, db.calculatedAddrPort, data.WgPrivateRouterEnc)
+			err = client.WgDel(ctx, vpnapi.WgDelRequest{WgPrivateKey: data.WgPrivateRouterEnc})
 			if err != nil {
 				return fmt.Errorf("wg del: %w", err)
 			}
 		}
 
-		proto0Decoy := []string{}
-		if data.Proto0FakeDomain != "" {
-			proto0Decoy = append(proto0Decoy, data.Proto0FakeDomain)
-		}
-
-		if len(data.Proto0FakeDomains) > 0 {
-			proto0Decoy = append(proto0Decoy, data.Proto0FakeDomains...)
-		}
-
 		if !uonly {
 			// if we catch a slowdown problems we need organize queue
-			err = vpnapi.WgAdd(
-				data.BrigadeID,
-				db.actualAddrPort,
-				db.calculatedAddrPort,
-				data.WgPrivateRouterEnc,
-				data.EndpointIPv4,
-				data.EndpointPort,
-				data.IPv4CGNAT,
-				data.IPv6ULA,
-				data.CloakFakeDomain,
-				data.OvCACertPemGzipBase64,
-				data.OvCAKeyRouterEnc,
-				data.IPSecPSKRouterEnc,
-				data.OutlinePort,
-				strings.Join(proto0Decoy, ","),
-			)
+			err = client.WgAdd(ctx, wgAddRequest(data))
 			if err != nil {
 				return fmt.Errorf("wg add: %w", err)
 			}
@@ -68,11 +45,7 @@ func (db *BrigadeStorage) ReplayBrigade(fresh, bonly, uonly, delayed, donly bool
 		for {
 			for i, user := range data.Users {
 				if user.DelayedDeletion {
-					if err = vpnapi.WgPeerDel(
-						data.BrigadeID,
-						db.actualAddrPort, db.calculatedAddrPort,
-						user.WgPublicKey, data.WgPublicKey,
-					); err != nil {
+					if err = client.PeerDel(ctx, peerRequest(data, user.WgPublicKey)); err != nil {
 						return fmt.Errorf("wg del: %w", err)
 					}
 
@@ -87,11 +60,6 @@ func (db *BrigadeStorage) ReplayBrigade(fresh, bonly, uonly, delayed, donly bool
 	}
 
 	for _, user := range data.Users {
-		kd6 := netip.Addr{}
-		if user.IsBrigadier {
-			kd6 = data.KeydeskIPv6
-		}
-
 		if !donly && !delayed && (user.DelayedCreation || user.DelayedDeletion || user.DelayedReplay || user.DelayedBlocking) {
 			continue
 		}
@@ -101,22 +69,14 @@ func (db *BrigadeStorage) ReplayBrigade(fresh, bonly, uonly, delayed, donly bool
 			case user.DelayedBlocking:
 				user.DelayedBlocking = false
 
-				if err = vpnapi.WgPeerDel(
-					data.BrigadeID,
-					db.actualAddrPort, db.calculatedAddrPort,
-					user.WgPublicKey, data.WgPublicKey,
-				); err != nil {
+				if err = client.PeerDel(ctx, peerRequest(data, user.WgPublicKey)); err != nil {
 					return fmt.Errorf("wg del: %w", err)
 				}
 			case user.DelayedReplay:
 				user.DelayedReplay = false
 				user.DelayedCreation = true
 
-				if err = vpnapi.WgPeerDel(
-					data.BrigadeID,
-					db.actualAddrPort, db.calculatedAddrPort,
-					user.WgPublicKey, data.WgPublicKey,
-				); err != nil {
+				if err = client.PeerDel(ctx, peerRequest(data, user.WgPublicKey)); err != nil {
 					return fmt.Errorf("wg del: %w", err)
 				}
 			}
@@ -131,18 +91,17 @@ func (db *BrigadeStorage) ReplayBrigade(fresh, bonly, uonly, delayed, donly bool
 		}
 
 		// if we catch a slowdown problems we need organize queue
-		if _, err = vpnapi.WgPeerAdd(
-			data.BrigadeID,
-			db.actualAddrPort, db.calculatedAddrPort,
-			user.WgPublicKey, data.WgPublicKey, user.WgPSKRouterEnc,
-			user.IPv4Addr, user.IPv6Addr, kd6,
-			user.OvCSRGzipBase64, user.CloakByPassUIDRouterEnc,
-			user.IPSecUsernameRouterEnc, user.IPSecPasswordRouterEnc,
-			user.OutlineSecretRouterEnc, user.Proto0SecretRouterEnc,
-		); err != nil {
+		if _, err = client.PeerAdd(ctx, peerAddRequest(data, user)); err != nil {
 			return fmt.Errorf("wg add: %w", err)
 		}
 
+		// the new peer isn't throttled yet.
+		if user.Quotas.ThrottlingOn {
+			if err = client.ThrottleOn(ctx, peerRequest(data, user.WgPublicKey)); err != nil {
+				return fmt.Errorf("throttle on: %w", err)
+			}
+		}
+
 		if (donly || delayed) && user.DelayedCreation {
 			user.DelayedCreation = false
 		}
@@ -150,7 +109,7 @@ func (db *BrigadeStorage) ReplayBrigade(fresh, bonly, uonly, delayed, donly bool
 
 	// beacuse we need to save changes only if delayed || donly flags are set
 	if donly || delayed {
-		if err := commitBrigade(f, data); err != nil {
+		if err := commitBrigade(f, "replay", data); err != nil {
 			return fmt.Errorf("commit: %w", err)
 		}
 	}
@@ -12,7 +12,7 @@ func (db *BrigadeStorage) DomainSet(domain string) error {
 
 	data.EndpointDomain = domain
 
-	if err := commitBrigade(f, data); err != nil {
+	if err := commitBrigade(f, "domain_set", data); err != nil {
 		return fmt.Errorf("commit: %w", err)
 	}
 
@@ -29,7 +29,7 @@ func (db *BrigadeStorage) PortSet(port uint16) error {
 
 	data.EndpointPort = port
 
-	if err := commitBrigade(f, data); err != nil {
+	if err := commitBrigade(f, "port_set", data); err != nil {
 		return fmt.Errorf("commit: %w", err)
 	}
 
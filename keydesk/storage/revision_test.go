@@ -0,0 +1,68 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRevision(t *testing.T) {
+	_, revision, err := db.ListUsers()
+	if err != nil {
+		t.Fatalf("list users: %s", err)
+	}
+
+	if err := db.CreateMessage("revision"); err != nil {
+		t.Fatalf("create message: %s", err)
+	}
+
+	_, current, err := db.ListUsers()
+	if err != nil {
+		t.Fatalf("list users: %s", err)
+	}
+
+	if current != revision+1 {
+		t.Fatalf("revision: %d after %d", current, revision)
+	}
+
+	if err := db.DeleteUser(context.Background(), "absent", false, true, &revision); !errors.Is(err, ErrRevisionMismatch) {
+		t.Errorf("stale block: %v", err)
+	}
+
+	if err := db.UnblockUser(context.Background(), "absent", &revision); !errors.Is(err, ErrRevisionMismatch) {
+		t.Errorf("stale unblock: %v", err)
+	}
+
+	if err := db.DeleteUser(context.Background(), "absent", false, false, &current); err != nil {
+		t.Errorf("actual delete: %s", err)
+	}
+
+	if err := db.DeleteUser(context.Background(), "absent", false, false, nil); err != nil {
+		t.Errorf("any delete: %s", err)
+	}
+}
+
+func TestRevisionHousekeeping(t *testing.T) {
+	_, revision, err := db.ListUsers()
+	if err != nil {
+		t.Fatalf("list users: %s", err)
+	}
+
+	for _, op := range []string{"stats", "outbox", "first_visit"} {
+		f, data, err := db.openWithReading()
+		if err != nil {
+			t.Fatalf("open: %s", err)
+		}
+
+		err = commitBrigade(f, op, data)
+		f.Close()
+
+		if err != nil {
+			t.Fatalf("%s: %s", op, err)
+		}
+	}
+
+	if _, current, _ := db.ListUsers(); current != revision {
+		t.Errorf("revision: %d after the housekeeping, want %d", current, revision)
+	}
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+	_ "time/tzdata" // the DST rules are the same everywhere
+
+	"github.com/google/uuid"
+)
+
+// trafficStep - count the traffic at the time and expect the counters.
+type trafficStep struct {
+	at                                      time.Time
+	traffic                                 uint64
+	daily, prevDay, weekly, monthly, yearly uint64
+}
+
+func checkTrafficSteps(t *testing.T, steps []trafficStep) {
+	t.Helper()
+
+	counters := &DateSummaryNetCounters{}
+	for _, s := range steps {
+		incDateSwitchRelated(s.at, s.traffic, 0, counters)
+
+		got := []uint64{counters.Daily.Rx, counters.PrevDay.Rx, counters.Weekly.Rx, counters.Monthly.Rx, counters.Yearly.Rx}
+		want := []uint64{s.daily, s.prevDay, s.weekly, s.monthly, s.yearly}
+
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%s: daily, prev day, weekly, monthly, yearly %v, want %v", s.at, got, want)
+
+				break
+			}
+		}
+	}
+}
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestRolloverISOWeek(t *testing.T) {
+	// 2020-12-31 is in the 53rd week of 2020, the week ends on 2021-01-03.
+	checkTrafficSteps(t, []trafficStep{
+		{at: date(2020, 12, 31, 10)},
+		{date(2020, 12, 31, 11), 1, 1, 0, 1, 1, 1},
+		{date(2021, 1, 3, 12), 2, 2, 0, 3, 2, 2},
+		{date(2021, 1, 4, 12), 4, 4, 2, 4, 6, 6},
+	})
+
+	// 2024-12-30 is in the 1st week of 2025.
+	checkTrafficSteps(t, []trafficStep{
+		{at: date(2024, 12, 29, 10)},
+		{date(2024, 12, 29, 11), 1, 1, 0, 1, 1, 1},
+		{date(2024, 12, 30, 12), 2, 2, 1, 2, 3, 3},
+		{date(2025, 1, 1, 12), 4, 4, 0, 6, 4, 4},
+	})
+
+	points := &LastActivityPoints{}
+	lastActivityMark(date(2020, 12, 31, 11), date(2020, 12, 31, 11), points)
+
+	lastActivityMark(date(2021, 1, 3, 12), time.Time{}, points)
+	if !points.Weekly.Equal(date(2020, 12, 31, 11)) || !points.Yearly.IsZero() || !points.Monthly.IsZero() || !points.Daily.IsZero() {
+		t.Errorf("the same ISO week: %+v", points)
+	}
+
+	lastActivityMark(date(2021, 1, 4, 12), time.Time{}, points)
+	if !points.Weekly.IsZero() {
+		t.Errorf("the next ISO week: %+v", points)
+	}
+}
+
+func TestRolloverMonthEnd(t *testing.T) {
+	checkTrafficSteps(t, []trafficStep{
+		{at: date(2024, 1, 31, 10)},
+		{date(2024, 1, 31, 11), 1, 1, 0, 1, 1, 1},
+		{date(2024, 2, 1, 1), 2, 2, 1, 3, 2, 3},
+		{date(2024, 2, 29, 23), 4, 4, 0, 4, 6, 7},
+		{date(2024, 3, 1, 0), 8, 8, 4, 12, 8, 15},
+		{date(2024, 4, 30, 0), 16, 16, 0, 16, 16, 31},
+	})
+
+	points := &LastActivityPoints{}
+	lastActivityMark(date(2024, 2, 15, 12), date(2024, 2, 15, 12), points)
+	lastActivityMark(date(2024, 3, 31, 12), time.Time{}, points)
+
+	if !points.PrevMonthly.Equal(date(2024, 2, 15, 12)) || !points.Monthly.IsZero() {
+		t.Errorf("the 31st: prev monthly %s, monthly %s", points.PrevMonthly, points.Monthly)
+	}
+
+	id := uuid.New()
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	data.Users = append(data.Users, &User{
+		UserID:      id,
+		WgPublicKey: []byte("month-end-user-wg-public-key-32b"),
+		Quotas: Quota{
+			LimitMonthlyResetDay: 31,
+			LimitMonthlyResetOn:  date(2024, 1, 31, 0),
+		},
+	})
+
+	if err := commitBrigade(f, "test", data); err != nil {
+		f.Close()
+		t.Fatalf("commit: %s", err)
+	}
+
+	f.Close()
+
+	clock := NewTestClock(date(2024, 1, 30, 23))
+	db.Clock = clock
+
+	defer func() {
+		db.Clock = nil
+
+		if err := db.DeleteUser(context.Background(), id.String(), false, false, nil); err != nil {
+			t.Errorf("delete: %s", err)
+		}
+	}()
+
+	resetOn := func() time.Time {
+		t.Helper()
+
+		data, _, err := db.getStatsQuota(context.Background(), true, time.Hour)
+		if err != nil {
+			t.Fatalf("stats: %s", err)
+		}
+
+		for _, u := range data.Users {
+			if u.UserID == id {
+				return u.Quotas.LimitMonthlyResetOn
+			}
+		}
+
+		t.Fatal("user is gone")
+
+		return time.Time{}
+	}
+
+	if got := resetOn(); !got.Equal(date(2024, 1, 31, 0)) {
+		t.Errorf("before the reset: %s", got)
+	}
+
+	clock.Set(date(2024, 1, 31, 0).Add(time.Second))
+
+	if got := resetOn(); !got.Equal(date(2024, 2, 29, 0)) {
+		t.Errorf("after the reset: %s", got)
+	}
+}
+
+func TestRolloverDST(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("location: %s", err)
+	}
+
+	key := []byte("rollover-dst-user-wg-public-key3")
+	id := base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(key)
+	user := &User{WgPublicKey: key}
+	data := &Brigade{Users: []*User{user}}
+
+	clock := NewTestClock(time.Time{})
+	clocked := &BrigadeStorage{Clock: clock}
+
+	var rx uint64
+
+	step := func(local time.Time, traffic uint64) time.Time {
+		t.Helper()
+
+		clock.Set(local)
+		rx += traffic
+
+		now := clocked.now()
+		if err := mergeStats(data, now, wgStatsIn(id, now, statsSample{"wireguard": {rx, 0}}), false, time.Hour, time.Hour, 0, ThrottlePolicy{}, nil); err != nil {
+			t.Fatalf("merge: %s", err)
+		}
+
+		return now
+	}
+
+	// the spring forward night, the same local day is two UTC days.
+	step(time.Date(2024, 3, 30, 22, 0, 0, 0, berlin), 0)
+	step(time.Date(2024, 3, 31, 0, 30, 0, 0, berlin), 1)
+	step(time.Date(2024, 3, 31, 3, 30, 0, 0, berlin), 2)
+
+	if c := user.Quotas.CountersTotal; c.PrevDay.Rx != 1 || c.Daily.Rx != 2 {
+		t.Errorf("spring forward: prev day %d, daily %d", c.PrevDay.Rx, c.Daily.Rx)
+	}
+
+	// the fall back night, the same local time twice.
+	step(date(2024, 10, 27, 0).Add(30*time.Minute).In(berlin), 4)
+	now := step(date(2024, 10, 27, 1).Add(30*time.Minute).In(berlin), 8)
+
+	if c := user.Quotas.CountersTotal; c.Daily.Rx != 12 || !c.Update.Equal(now) || c.Update.Location() != time.UTC {
+		t.Errorf("fall back: daily %d, update %s", c.Daily.Rx, c.Update)
+	}
+}
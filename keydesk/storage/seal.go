@@ -0,0 +1,400 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+// SealKeyFilename - the local secretbox key in DefaultEtcDir.
+// The plaintext secrets are sealed at rest if the key exists.
+const SealKeyFilename = "seal.key"
+
+const (
+	sealKeyLength   = 32
+	sealNonceLength = 24
+	sealedPrefix    = "secretbox:"
+)
+
+var (
+	// ErrSealKeyMissing - the brigade has sealed secrets, but the key is absent.
+	ErrSealKeyMissing = errors.New("sealed secrets without the key")
+	// ErrSealKeyLength - the key file is broken.
+	ErrSealKeyLength = errors.New("bad seal key length")
+	// ErrUnseal - the sealed secret is broken or sealed by another key.
+	ErrUnseal = errors.New("can't unseal")
+)
+
+// SealKey - secretbox key for the plaintext secrets.
+type SealKey [sealKeyLength]byte
+
+type sealKeyFile struct {
+	Key []byte `json:"secretbox-key"`
+}
+
+// ReadSealKeyFile - read the seal key.
+func ReadSealKeyFile(name string) (*SealKey, error) {
+	blob, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	var js sealKeyFile
+	if err := json.Unmarshal(blob, &js); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if len(js.Key) != sealKeyLength {
+		return nil, fmt.Errorf("%w: %d", ErrSealKeyLength, len(js.Key))
+	}
+
+	key := &SealKey{}
+	copy(key[:], js.Key)
+
+	return key, nil
+}
+
+// GenSealKeyFile - generate the new seal key, the file must not exist.
+func GenSealKeyFile(name string) (*SealKey, error) {
+	key := &SealKey{}
+	if _, err := io.ReadFull(rand.Reader, key[:]); err != nil {
+		return nil, fmt.Errorf("rand: %w", err)
+	}
+
+	blob, err := json.Marshal(&sealKeyFile{Key: key[:]})
+	if err != nil {
+		return nil, fmt.Errorf("encode: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(name), 0o700); err != nil {
+		return nil, fmt.Errorf("mkdir: %w", err)
+	}
+
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("create: %w", err)
+	}
+
+	if _, err := f.Write(append(blob, '\n')); err != nil {
+		f.Close()
+
+		return nil, fmt.Errorf("write: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return nil, fmt.Errorf("close: %w", err)
+	}
+
+	return key, nil
+}
+
+// sealKey - the local key, nil if there is no key file.
+func (db *BrigadeStorage) sealKey() (*SealKey, error) {
+	name := db.SealKeyFilename
+	if name == "" {
+		name = filepath.Join(DefaultEtcDir, SealKeyFilename)
+	}
+
+	key, err := ReadSealKeyFile(name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("seal key: %w", err)
+	}
+
+	return key, nil
+}
+
+func isSealed(s string) bool {
+	return strings.HasPrefix(s, sealedPrefix)
+}
+
+func (k *SealKey) seal(plain string) (string, error) {
+	var nonce [sealNonceLength]byte
+	if _, err := io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return "", fmt.Errorf("rand: %w", err)
+	}
+
+	box := secretbox.Seal(nonce[:], []byte(plain), &nonce, (*[sealKeyLength]byte)(k))
+
+	return sealedPrefix + base64.StdEncoding.EncodeToString(box), nil
+}
+
+func (k *SealKey) open(sealed string) (string, error) {
+	box, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(sealed, sealedPrefix))
+	if err != nil || len(box) < sealNonceLength {
+		return "", ErrUnseal
+	}
+
+	var nonce [sealNonceLength]byte
+	copy(nonce[:], box)
+
+	plain, ok := secretbox.Open(nil, box[sealNonceLength:], &nonce, (*[sealKeyLength]byte)(k))
+	if !ok {
+		return "", ErrUnseal
+	}
+
+	return string(plain), nil
+}
+
+// sealedFields - the brigade secrets which are not sealed
+// for the router and the shuffler.
+func sealedFields(data *Brigade) []*string {
+	fields := []*string{&data.IPSecPSK, &data.OvCACertPemGzipBase64, &data.Proto0FakeDomain}
+
+	for i := range data.Proto0FakeDomains {
+		fields = append(fields, &data.Proto0FakeDomains[i])
+	}
+
+	for _, user := range data.Users {
+		fields = append(fields, &user.Proto0UserFakeDomain)
+	}
+
+	return fields
+}
+
+// sealer - seals and unseals the brigade secrets.
+// The unchanged secrets keep the ciphertext, so they don't hit the journal.
+type sealer struct {
+	key    *SealKey
+	sealed map[string]string // plaintext MAC -> ciphertext
+}
+
+// mac - the cache key of the plaintext, keyed by the seal key,
+// so the plaintext is not kept as is.
+func (s *sealer) mac(plain string) string {
+	h := hmac.New(sha256.New, s.key[:])
+	h.Write([]byte(plain))
+
+	return string(h.Sum(nil))
+}
+
+func newSealer(key *SealKey) *sealer {
+	return &sealer{key: key, sealed: make(map[string]string)}
+}
+
+// unseal - in place.
+func (s *sealer) unseal(data *Brigade) error {
+	for _, field := range sealedFields(data) {
+		if !isSealed(*field) {
+			continue
+		}
+
+		if s.key == nil {
+			return ErrSealKeyMissing
+		}
+
+		plain, err := s.key.open(*field)
+		if err != nil {
+			return err
+		}
+
+		s.sealed[s.mac(plain)] = *field
+		*field = plain
+	}
+
+	return nil
+}
+
+// seal - the sealed copy, the data is untouched.
+// Without the key the data is returned as is.
+func (s *sealer) seal(data *Brigade) (*Brigade, error) {
+	if s.key == nil {
+		return data, nil
+	}
+
+	sealed := *data
+	sealed.Proto0FakeDomains = append([]string(nil), data.Proto0FakeDomains...)
+
+	if data.Users != nil {
+		sealed.Users = make([]*User, len(data.Users))
+		for i, user := range data.Users {
+			u := *user
+			sealed.Users[i] = &u
+		}
+	}
+
+	for _, field := range sealedFields(&sealed) {
+		if *field == "" || isSealed(*field) {
+			continue
+		}
+
+		mac := s.mac(*field)
+
+		ciphertext, ok := s.sealed[mac]
+		if !ok {
+			var err error
+
+			if ciphertext, err = s.key.seal(*field); err != nil {
+				return nil, fmt.Errorf("seal: %w", err)
+			}
+
+			s.sealed[mac] = ciphertext
+		}
+
+		*field = ciphertext
+	}
+
+	return &sealed, nil
+}
+
+// sealBackend - transparent sealing in front of the journal,
+// so neither the storage nor the journal gets the plaintext secrets.
+type sealBackend struct {
+	Backend
+	db *BrigadeStorage
+}
+
+type sealTx struct {
+	BackendTx
+	*sealer
+}
+
+func (b *sealBackend) Open() (BackendTx, error) {
+	key, err := b.db.sealKey()
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := b.Backend.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	return &sealTx{BackendTx: tx, sealer: newSealer(key)}, nil
+}
+
+func (b *sealBackend) OpenRead() (BackendTx, error) {
+	key, err := b.db.sealKey()
+	if err != nil {
+		return nil, err
+	}
+
+	var tx BackendTx
+
+	if rb, ok := b.Backend.(ReadBackend); ok {
+		tx, err = rb.OpenRead()
+	} else {
+		tx, err = b.Backend.Open()
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sealTx{BackendTx: tx, sealer: newSealer(key)}, nil
+}
+
+func (tx *sealTx) Load(data *Brigade) error {
+	if err := tx.BackendTx.Load(data); err != nil {
+		return err
+	}
+
+	if err := tx.unseal(data); err != nil {
+		return fmt.Errorf("unseal: %w", err)
+	}
+
+	return nil
+}
+
+func (tx *sealTx) Store(data *Brigade) error {
+	sealed, err := tx.seal(data)
+	if err != nil {
+		return err
+	}
+
+	return tx.BackendTx.Store(sealed)
+}
+
+func (tx *sealTx) commit(op string, data *Brigade) error {
+	sealed, err := tx.seal(data)
+	if err != nil {
+		return err
+	}
+
+	if c, ok := tx.BackendTx.(opTx); ok {
+		return c.commit(op, sealed)
+	}
+
+	return tx.BackendTx.Store(sealed)
+}
+
+// SealSecrets - store the brigade with the plaintext secrets
+// sealed by the local key, or unsealed back if seal is false.
+// The sealing scrubs the plaintext history: the journal restarts
+// from the sealed base, the backup and the snapshots are resealed.
+func (db *BrigadeStorage) SealSecrets(seal bool) error {
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	tx, ok := f.(*sealTx)
+	if !ok || tx.key == nil {
+		return ErrSealKeyMissing
+	}
+
+	op := "seal"
+	if !seal {
+		op = "unseal"
+		tx.key = nil
+	}
+
+	if err := commitBrigade(f, op, data); err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+
+	if !seal {
+		return nil
+	}
+
+	if err := db.scrubPlaintext(tx, data); err != nil {
+		return fmt.Errorf("scrub: %w", err)
+	}
+
+	return nil
+}
+
+// scrubPlaintext - drop the plaintext secrets left by the commits before the sealing.
+func (db *BrigadeStorage) scrubPlaintext(tx *sealTx, data *Brigade) error {
+	sealed, err := tx.seal(data)
+	if err != nil {
+		return err
+	}
+
+	base, err := json.Marshal(sealed)
+	if err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+
+	journal := filepath.Join(filepath.Dir(db.BrigadeFilename), BrigadeJournalFilename)
+	if err := restartJournal(journal, base, db.Clock); err != nil {
+		return fmt.Errorf("journal: %w", err)
+	}
+
+	// the backup is replaced by the just sealed brigade.
+	if err := tx.Backup(); err != nil {
+		return fmt.Errorf("backup: %w", err)
+	}
+
+	if err := db.resealSnapshots(tx.key); err != nil {
+		return fmt.Errorf("snapshots: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package storage
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib"
+)
+
+func TestSealSecrets(t *testing.T) {
+	const psk = "plaintext-ipsec-psk"
+
+	keyFilename := filepath.Join(t.TempDir(), SealKeyFilename)
+
+	defer func(name string) { db.SealKeyFilename = name }(db.SealKeyFilename)
+
+	db.SealKeyFilename = keyFilename
+
+	if err := db.SealSecrets(true); err == nil {
+		t.Fatal("sealed without the key")
+	}
+
+	f, data, err := db.OpenDbToModify()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data.IPSecPSK = psk
+	data.Proto0FakeDomains = []string{"proto0.example.com"}
+
+	err = f.Commit(data)
+	f.Close()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db.Snapshots = kdlib.SnapshotRetention{Last: 5, Interval: time.Hour}
+
+	defer func() {
+		db.Snapshots, db.snapshotAt = kdlib.SnapshotRetention{}, time.Time{}
+
+		list, _ := kdlib.ListSnapshots(db.BrigadeFilename)
+		for _, s := range list {
+			os.Remove(s.Filename)
+		}
+	}()
+
+	snapshot, err := db.TakeSnapshot(data, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := GenSealKeyFile(keyFilename); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.SealSecrets(true); err != nil {
+		t.Fatalf("seal: %s", err)
+	}
+
+	raw, err := os.ReadFile(db.BrigadeFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(raw, []byte(psk)) || bytes.Contains(raw, []byte("proto0.example.com")) {
+		t.Error("plaintext secrets in the storage")
+	}
+
+	// the plaintext history is scrubbed.
+	for _, name := range []string{db.BrigadeFilename + ".bak", snapshot} {
+		raw, err := kdlib.ReadSnapshot(name)
+		if name != snapshot {
+			raw, err = os.ReadFile(name)
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Contains(raw, []byte(psk)) {
+			t.Errorf("plaintext secrets in %s", name)
+		}
+	}
+
+	journal := filepath.Join(filepath.Dir(db.BrigadeFilename), BrigadeJournalFilename)
+
+	entries, err := ReadJournal(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 1 || entries[0].Op != JournalOpBase {
+		t.Errorf("journal after the sealing: %d entries", len(entries))
+	}
+
+	for _, entry := range entries {
+		for _, patch := range entry.Diff {
+			if bytes.Contains(patch.Value, []byte(psk)) {
+				t.Errorf("plaintext secret in the journal: %s", patch.Path)
+			}
+		}
+	}
+
+	// unchanged secrets keep the ciphertext.
+
+	before := len(entries)
+
+	if err := db.CreateMessage("sealed"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = ReadJournal(journal)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries[before:] {
+		for _, patch := range entry.Diff {
+			if patch.Path == "/ipsec_psk" || bytes.Contains(patch.Value, []byte(psk)) {
+				t.Errorf("secret in the journal: %s %s", patch.Path, patch.Value)
+			}
+		}
+	}
+
+	f2, data, err := db.openReadOnly()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f2.Close()
+
+	if data.IPSecPSK != psk || data.Proto0FakeDomains[0] != "proto0.example.com" {
+		t.Errorf("unsealed: %q %q", data.IPSecPSK, data.Proto0FakeDomains)
+	}
+
+	db.SealKeyFilename = filepath.Join(t.TempDir(), "absent.key")
+
+	if _, _, err := db.openReadOnly(); err == nil {
+		t.Error("opened without the key")
+	}
+
+	db.SealKeyFilename = keyFilename
+
+	if err := db.SealSecrets(false); err != nil {
+		t.Fatalf("unseal: %s", err)
+	}
+
+	raw, err = os.ReadFile(db.BrigadeFilename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Contains(raw, []byte(psk)) {
+		t.Error("secrets are still sealed")
+	}
+}
@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib"
+)
+
+// snapshot - gzip snapshot of the just read brigade if it's time,
+// the retention is applied after the new one.
+// Snapshots are brigade.json regardless of the backend.
+// The dir is scanned only if no snapshot is known within the interval.
+func (db *BrigadeStorage) snapshot(data *Brigade) error {
+	if !db.Snapshots.Enabled() {
+		return nil
+	}
+
+	now := db.now()
+
+	if !db.snapshotAt.IsZero() && now.Sub(db.snapshotAt) < db.Snapshots.Interval {
+		return nil
+	}
+
+	list, err := kdlib.ListSnapshots(db.BrigadeFilename)
+	if err != nil {
+		return err
+	}
+
+	if len(list) > 0 && now.Sub(list[0].Time) < db.Snapshots.Interval {
+		db.snapshotAt = list[0].Time
+
+		return nil
+	}
+
+	if _, err = db.TakeSnapshot(data, now); err != nil {
+		return err
+	}
+
+	db.snapshotAt = now
+
+	return nil
+}
+
+// TakeSnapshot - write the brigade snapshot and apply the retention.
+// The secrets are sealed as in the storage.
+func (db *BrigadeStorage) TakeSnapshot(data *Brigade, now time.Time) (string, error) {
+	key, err := db.sealKey()
+	if err != nil {
+		return "", err
+	}
+
+	data, err = newSealer(key).seal(data)
+	if err != nil {
+		return "", err
+	}
+
+	buf, err := json.MarshalIndent(data, " ", " ")
+	if err != nil {
+		return "", fmt.Errorf("encode: %w", err)
+	}
+
+	filename, err := kdlib.WriteSnapshot(db.BrigadeFilename, now, buf, FileDbMode)
+	if err != nil {
+		return "", fmt.Errorf("write: %w", err)
+	}
+
+	if _, err := kdlib.PruneSnapshots(db.BrigadeFilename, now, db.Snapshots); err != nil {
+		return filename, fmt.Errorf("prune: %w", err)
+	}
+
+	return filename, nil
+}
+
+// resealSnapshots - rewrite the snapshots with the secrets sealed by the key,
+// the snapshot names and times are kept.
+func (db *BrigadeStorage) resealSnapshots(key *SealKey) error {
+	list, err := kdlib.ListSnapshots(db.BrigadeFilename)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range list {
+		data, err := db.ReadSnapshot(s.Filename)
+		if err != nil {
+			return fmt.Errorf("%s: %w", s.Filename, err)
+		}
+
+		if data, err = newSealer(key).seal(data); err != nil {
+			return fmt.Errorf("%s: %w", s.Filename, err)
+		}
+
+		buf, err := json.MarshalIndent(data, " ", " ")
+		if err != nil {
+			return fmt.Errorf("%s: encode: %w", s.Filename, err)
+		}
+
+		if _, err := kdlib.WriteSnapshot(db.BrigadeFilename, s.Time, buf, FileDbMode); err != nil {
+			return fmt.Errorf("%s: write: %w", s.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadSnapshot - read and validate the snapshot.
+func (db *BrigadeStorage) ReadSnapshot(filename string) (*Brigade, error) {
+	buf, err := kdlib.ReadSnapshot(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	data := &Brigade{}
+	if err := json.Unmarshal(buf, data); err != nil {
+		return nil, fmt.Errorf("decode: %w", err)
+	}
+
+	if data.BrigadeID != db.BrigadeID {
+		return nil, fmt.Errorf("check: %w", ErrUnknownBrigade)
+	}
+
+	key, err := db.sealKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := newSealer(key).unseal(data); err != nil {
+		return nil, fmt.Errorf("unseal: %w", err)
+	}
+
+	return data, nil
+}
+
+// RestoreSnapshot - validate the snapshot and swap it in under the spinlock.
+// The current brigade is kept as the backup if it's readable.
+// The revision goes on from the newer one, so no old ETag matches again.
+func (db *BrigadeStorage) RestoreSnapshot(filename string) error {
+	data, err := db.ReadSnapshot(filename)
+	if err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+
+	f, err := db.backend().Open()
+	if err != nil {
+		return fmt.Errorf("open: %w", err)
+	}
+
+	defer f.Close()
+
+	cur := &Brigade{}
+
+	switch err := f.Load(cur); err {
+	case nil:
+		if cur.BrigadeID != db.BrigadeID {
+			return fmt.Errorf("check: %w", ErrUnknownBrigade)
+		}
+
+		if err := f.Backup(); err != nil {
+			return fmt.Errorf("backup: %w", err)
+		}
+
+		data.Revision = max(data.Revision, cur.Revision)
+	case io.EOF:
+	default:
+		fmt.Fprintf(os.Stderr, "Current brigade is broken: %s\n", err)
+	}
+
+	if err := commitBrigade(f, "restore", data); err != nil {
+		return fmt.Errorf("commit: %w", err)
+	}
+
+	return nil
+}
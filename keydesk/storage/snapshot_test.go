@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vpngen/keydesk/kdlib"
+)
+
+func TestSnapshotInterval(t *testing.T) {
+	clock := NewTestClock(time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC))
+
+	db.Clock, db.Snapshots = clock, kdlib.SnapshotRetention{Last: 5, Interval: time.Hour}
+
+	defer func() {
+		db.Clock, db.Snapshots, db.snapshotAt = nil, kdlib.SnapshotRetention{}, time.Time{}
+
+		list, _ := kdlib.ListSnapshots(db.BrigadeFilename)
+		for _, s := range list {
+			os.Remove(s.Filename)
+		}
+	}()
+
+	open := func() {
+		t.Helper()
+
+		f, _, err := db.openWithReading()
+		if err != nil {
+			t.Fatalf("open: %s", err)
+		}
+
+		f.Close()
+	}
+
+	count := func() int {
+		t.Helper()
+
+		list, err := kdlib.ListSnapshots(db.BrigadeFilename)
+		if err != nil {
+			t.Fatalf("list: %s", err)
+		}
+
+		return len(list)
+	}
+
+	open()
+	open()
+
+	if n := count(); n != 1 {
+		t.Errorf("snapshots within the interval: %d", n)
+	}
+
+	clock.Set(clock.Now().Add(time.Hour))
+	open()
+
+	if n := count(); n != 2 {
+		t.Errorf("snapshots after the interval: %d", n)
+	}
+}
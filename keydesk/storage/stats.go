@@ -1,10 +1,13 @@
 package storage
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
+	"math"
 	"math/rand"
 	"net/netip"
+	"os"
 	"time"
 
 	"github.com/vpngen/keydesk/kdlib"
@@ -13,18 +16,19 @@ import (
 
 var nullUnixTime = time.Unix(0, 0)
 
-// GetStats - create brigade config.
-func (db *BrigadeStorage) GetStats(rdata bool, statsFilename, statsSpinlock string, endpointsTTL time.Duration) error {
-	data, err := db.getStatsQuota(rdata, endpointsTTL)
+// GetStats - create brigade config, ctx bounds the endpoint calls.
+// Returns the time of the successful endpoint stat call, zero if it wasn't.
+func (db *BrigadeStorage) GetStats(ctx context.Context, rdata bool, statsFilename, statsSpinlock string, endpointsTTL time.Duration) (time.Time, error) {
+	data, wgStatTime, err := db.getStatsQuota(ctx, rdata, endpointsTTL)
 	if err != nil {
-		return fmt.Errorf("quota: %w", err)
+		return wgStatTime, fmt.Errorf("quota: %w", err)
 	}
 
 	if err := db.putStatsStats(data, statsFilename, statsSpinlock); err != nil {
-		return fmt.Errorf("stats: %w", err)
+		return wgStatTime, fmt.Errorf("stats: %w", err)
 	}
 
-	return nil
+	return wgStatTime, nil
 }
 
 func lastActivityMark(now, lastActivity time.Time, points *LastActivityPoints) {
@@ -77,7 +81,8 @@ func lastActivityMark(now, lastActivity time.Time, points *LastActivityPoints) {
 		}
 	}
 
-	prevYear, prevMonth, _ := now.AddDate(0, -1, 0).Date()
+	// the 1st as AddDate normalizes the 31st to the month after.
+	prevYear, prevMonth, _ := time.Date(year, month-1, 1, 0, 0, 0, 0, time.UTC).Date()
 	if !points.PrevMonthly.IsZero() {
 		pmthYear, pmthMonth, _ := points.PrevMonthly.Date()
 		if pmthYear != prevYear || pmthMonth != prevMonth {
@@ -143,6 +148,8 @@ func incDateSwitchRelated(now time.Time, rx, tx uint64, counters *DateSummaryNet
 		return
 	}
 
+	counters.History.add(now, rx, tx)
+
 	prevYear, prevMonth, prevDay := counters.Update.Date()
 	year, month, day := now.Date()
 
@@ -180,7 +187,7 @@ func incDateSwitchRelated(now time.Time, rx, tx uint64, counters *DateSummaryNet
 	if prevMonth != month {
 		counters.Monthly.Reset(0, 0)
 
-		testYear, testMonth, _ := counters.Update.AddDate(0, 1, 0).Date()
+		testYear, testMonth, _ := time.Date(prevYear, prevMonth+1, 1, 0, 0, 0, 0, time.UTC).Date()
 		if testYear != year || testMonth != month {
 			counters.Daily.Reset(0, 0)
 			counters.PrevDay.Reset(0, 0)
@@ -263,8 +270,19 @@ func handleUserUsageStats(ustats *MarkedUsersCounters, quotas *Quota, limit uint
 	}
 }
 
+// counterWrapWindow - the previous counter value this close to the max
+// means the 64 bit counter wrapped, not the session reset.
+const counterWrapWindow = 1 << 40
+
+// counterRegressed - the endpoint counter went back not by the wrap.
+func counterRegressed(prev, cur uint64) bool {
+	return cur < prev && prev <= math.MaxUint64-counterWrapWindow
+}
+
 func handleTrafficStat(
 	id string,
+	userID string,
+	proto string,
 	now time.Time,
 	m map[string]*vpnapi.WgStatTraffic,
 	osCounters *RxTx,
@@ -273,15 +291,16 @@ func handleTrafficStat(
 	counters *DateSummaryNetCounters,
 ) {
 	if traffic, ok := m[id]; ok {
-		rx := traffic.Rx
-		tx := traffic.Tx
+		// the wrapped counter difference is right in the unsigned arithmetic.
+		rx := traffic.Rx - osCounters.Rx
+		tx := traffic.Tx - osCounters.Tx
 
-		if osCounters.Rx <= traffic.Rx {
-			rx = traffic.Rx - osCounters.Rx
-		}
+		// the endpoint restarted or the peer was re-added,
+		// rebase the session: all its traffic is after the reset.
+		if counterRegressed(osCounters.Rx, traffic.Rx) || counterRegressed(osCounters.Tx, traffic.Tx) {
+			fmt.Fprintf(os.Stderr, "User %s %s counters reset: rx %d -> %d, tx %d -> %d\n", userID, proto, osCounters.Rx, traffic.Rx, osCounters.Tx, traffic.Tx)
 
-		if osCounters.Tx <= traffic.Tx {
-			tx = traffic.Tx - osCounters.Tx
+			rx, tx = traffic.Rx, traffic.Tx
 		}
 
 		osCounters.Reset(traffic.Rx, traffic.Tx)
@@ -337,7 +356,7 @@ func handleLastActivity(
 	return lastActivityTotal
 }
 
-func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsTTL, maxUserInactiveDuration time.Duration, monthlyQuotaRemaining int) error {
+func mergeStats(data *Brigade, now time.Time, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsTTL, maxUserInactiveDuration time.Duration, monthlyQuotaRemaining int, throttle ThrottlePolicy, geo GeoLocator) error {
 	var (
 		totalTraffic TrafficCountersContainer
 
@@ -363,8 +382,6 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 		err            error
 	)
 
-	now := time.Now().UTC()
-
 	switch rdata {
 	case true:
 		statsTimestamp, trafficMap, lastSeenMap, endpointMap = randomData(data, now)
@@ -379,15 +396,18 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 		data.Endpoints = UsersNetworks{}
 	}
 
+	geoUsers := newGeoCounter(geo)
+
 	for _, user := range data.Users {
 		id := base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(user.WgPublicKey)
+		userID := user.UserID.String()
 		sum := RxTx{}
 
-		handleTrafficStat(id, now, trafficMap.Wg, &user.Quotas.OSWgCounters, &sum, &totalTraffic.TrafficWg, &user.Quotas.CountersWg)
-		handleTrafficStat(id, now, trafficMap.IPSec, &user.Quotas.OSIPSecCounters, &sum, &totalTraffic.TrafficIPSec, &user.Quotas.CountersIPSec)
-		handleTrafficStat(id, now, trafficMap.Ovc, &user.Quotas.OSOvcCounters, &sum, &totalTraffic.TrafficOvc, &user.Quotas.CountersOvc)
-		handleTrafficStat(id, now, trafficMap.Outline, &user.Quotas.OSOutlineCounters, &sum, &totalTraffic.TrafficOutline, &user.Quotas.CountersOutline)
-		handleTrafficStat(id, now, trafficMap.Proto0, &user.Quotas.OSProto0Counters, &sum, &totalTraffic.TrafficProto0, &user.Quotas.CountersProto0)
+		handleTrafficStat(id, userID, userEventProtoWireguard, now, trafficMap.Wg, &user.Quotas.OSWgCounters, &sum, &totalTraffic.TrafficWg, &user.Quotas.CountersWg)
+		handleTrafficStat(id, userID, userEventProtoIPSec, now, trafficMap.IPSec, &user.Quotas.OSIPSecCounters, &sum, &totalTraffic.TrafficIPSec, &user.Quotas.CountersIPSec)
+		handleTrafficStat(id, userID, userEventProtoOpenVPN, now, trafficMap.Ovc, &user.Quotas.OSOvcCounters, &sum, &totalTraffic.TrafficOvc, &user.Quotas.CountersOvc)
+		handleTrafficStat(id, userID, userEventProtoOutline, now, trafficMap.Outline, &user.Quotas.OSOutlineCounters, &sum, &totalTraffic.TrafficOutline, &user.Quotas.CountersOutline)
+		handleTrafficStat(id, userID, userEventProtoProto0, now, trafficMap.Proto0, &user.Quotas.OSProto0Counters, &sum, &totalTraffic.TrafficProto0, &user.Quotas.CountersProto0)
 
 		totalTraffic.TrafficSummary.Inc(sum.Rx, sum.Tx)
 		incDateSwitchRelated(now, sum.Rx, sum.Tx, &user.Quotas.CountersTotal)
@@ -399,8 +419,9 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 
 		if user.Quotas.LimitMonthlyResetOn.Before(now) {
 			// !!! reset monthly throttle ....
-			user.Quotas.LimitMonthlyRemaining = uint64(monthlyQuotaRemaining)
-			user.Quotas.LimitMonthlyResetOn = kdlib.NextMonthlyResetOn(now)
+			user.Quotas.LimitMonthlyRemaining = user.Quotas.monthlyLimit(monthlyQuotaRemaining)
+			user.Quotas.LimitMonthlyResetOn = user.Quotas.nextMonthlyResetOn(now)
+			user.addEvent(UserEventQuotaReset, now, "")
 		}
 
 		spentQuota := (sum.Rx + sum.Tx)
@@ -411,6 +432,8 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 			user.Quotas.LimitMonthlyRemaining = 0
 		}
 
+		neverConnected := user.neverConnected()
+
 		lastActivityTotal := user.Quotas.LastActivity.Total
 		userInactiveEdge := now.Add(-maxUserInactiveDuration)
 
@@ -421,6 +444,8 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 		lastActivityTotal = handleLastActivity(id, now, &activeOutlineUsers, userInactiveEdge, lastSeenMap.Outline, endpointMap.Outline, &user.Quotas.LastOutlineActivity, data.Endpoints, lastActivityTotal)
 		lastActivityTotal = handleLastActivity(id, now, &activeProto0Users, userInactiveEdge, lastSeenMap.Proto0, endpointMap.Proto0, &user.Quotas.LastProto0Activity, data.Endpoints, lastActivityTotal)
 
+		user.firstConnectEvents(now, neverConnected)
+
 		// !!! fix Unix zero time bug.
 		if user.Quotas.LastActivity.Total.Equal(nullUnixTime) {
 			user.Quotas.LastActivity.Total = time.Time{}
@@ -432,12 +457,18 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 			data.LastActivity = lastActivityTotal
 		}
 
+		throttle.decide(&user.Quotas, now, monthlyQuotaRemaining)
+
 		if !user.Quotas.ThrottlingTill.IsZero() && user.Quotas.ThrottlingTill.After(now) {
 			throttledUsers++
 		}
 
+		user.throttleEvents(now)
+
 		if user.Quotas.LastActivity.Total.After(userInactiveEdge) {
 			activeUsers++
+
+			geoUsers.add(userID, userNetwork(id, lastSeenMap, endpointMap))
 		}
 
 		if user.IsBlocked {
@@ -475,6 +506,8 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 		}
 	}
 
+	data.Geo = geoUsers.summary(now)
+
 	data.CountersUpdateTime = statsTimestamp.Time
 
 	data.StatsCountersStack.Put(data.BrigadeCounters, totalTraffic)
@@ -482,32 +515,43 @@ func mergeStats(data *Brigade, wgStats *vpnapi.WGStatsIn, rdata bool, endpointsT
 	return nil
 }
 
-func (db *BrigadeStorage) getStatsQuota(rdata bool, endpointsTTL time.Duration) (*Brigade, error) {
+func (db *BrigadeStorage) getStatsQuota(ctx context.Context, rdata bool, endpointsTTL time.Duration) (*Brigade, time.Time, error) {
 	f, data, err := db.openWithReading()
 	if err != nil {
-		return nil, fmt.Errorf("db: %w", err)
+		return nil, time.Time{}, fmt.Errorf("db: %w", err)
 	}
 
 	defer f.Close()
 
 	// if we catch a slowdown problems we need organize queue
-	wgStats, err := vpnapi.WgStat(data.BrigadeID, db.actualAddrPort, db.calculatedAddrPort, data.WgPublicKey)
+	wgStats, err := db.EndpointClient().Stat(ctx, vpnapi.StatRequest{WgPublicKey: data.WgPublicKey})
 	if err != nil {
-		return nil, fmt.Errorf("wg stat: %w", err)
+		return nil, time.Time{}, fmt.Errorf("wg stat: %w", err)
 	}
 
+	wgStatTime := db.now()
+
 	if wgStats != nil || rdata {
-		if err := mergeStats(data, wgStats, rdata, endpointsTTL, db.MaxUserInctivityPeriod, db.MonthlyQuotaRemaining); err != nil {
-			return nil, fmt.Errorf("merge stats: %w", err)
+		if err := mergeStats(data, wgStatTime, wgStats, rdata, endpointsTTL, db.MaxUserInctivityPeriod, db.MonthlyQuotaRemaining, db.Throttle, db.Geo); err != nil {
+			return nil, wgStatTime, fmt.Errorf("merge stats: %w", err)
+		}
+
+		db.Alerts.notify(data, wgStatTime)
+	}
+
+	// the failed ones are retried on the next round.
+	for _, user := range data.Users {
+		if err := db.syncThrottling(ctx, data, user, wgStatTime); err != nil {
+			fmt.Fprintf(os.Stderr, "User %s throttling: %s\n", user.UserID, err)
 		}
 	}
 
-	err = commitBrigade(f, data)
+	err = commitBrigade(f, "stats", data)
 	if err != nil {
-		return nil, fmt.Errorf("commit: %w", err)
+		return nil, wgStatTime, fmt.Errorf("commit: %w", err)
 	}
 
-	return data, nil
+	return data, wgStatTime, nil
 }
 
 func (db *BrigadeStorage) putStatsStats(data *Brigade, statsFilename, statsSpinlock string) error {
@@ -536,10 +580,12 @@ func (db *BrigadeStorage) putStatsStats(data *Brigade, statsFilename, statsSpinl
 		LastActivity: data.LastActivity,
 
 		BrigadeID:         data.BrigadeID,
+		VIP:               data.VIP > 0,
 		BrigadeCreatedAt:  data.CreatedAt,
 		KeydeskFirstVisit: data.KeydeskFirstVisit,
 		Endpoints:         data.Endpoints,
-		UpdateTime:        time.Now().UTC(),
+		Geo:               data.Geo,
+		UpdateTime:        db.now(),
 		Ver:               StatsVersion,
 	}
 
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"encoding/base64"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/vpngen/keydesk/vpnapi"
+)
+
+// statsSample - the raw endpoint counters per protocol stat name, absent protocol has no peer.
+type statsSample map[string]RxTx
+
+func wgStatsIn(id string, ts time.Time, sample statsSample) *vpnapi.WGStatsIn {
+	data := vpnapi.WgStatTrafficDataIn{}
+	for proto, c := range sample {
+		data[proto] = vpnapi.WgStatTrafficIn{
+			Received: strconv.FormatUint(c.Rx, 10),
+			Sent:     strconv.FormatUint(c.Tx, 10),
+		}
+	}
+
+	return &vpnapi.WGStatsIn{
+		Code:      "0",
+		Timestamp: strconv.FormatInt(ts.Unix(), 10),
+		Data: vpnapi.WgStatDataIn{
+			WgStatTrafficMapIn: vpnapi.WgStatTrafficMapIn{id: data},
+		},
+	}
+}
+
+func TestMergeStatsCounters(t *testing.T) {
+	tests := []struct {
+		name    string
+		os      RxTx // the wireguard session before the first sample
+		samples []statsSample
+		wg      RxTx
+		ipsec   RxTx
+		total   RxTx
+	}{
+		{
+			name:    "monotonic",
+			samples: []statsSample{{"wireguard": {100, 10}}, {"wireguard": {150, 30}}},
+			wg:      RxTx{150, 30},
+			total:   RxTx{150, 30},
+		},
+		{
+			name:    "endpoint restart",
+			samples: []statsSample{{"wireguard": {100, 10}}, {"wireguard": {150, 30}}, {"wireguard": {20, 5}}},
+			wg:      RxTx{170, 35},
+			total:   RxTx{170, 35},
+		},
+		{
+			name:    "re-added peer outgrew the old rx",
+			samples: []statsSample{{"wireguard": {100, 10}}, {"wireguard": {150, 30}}, {"wireguard": {200, 5}}},
+			wg:      RxTx{350, 35},
+			total:   RxTx{350, 35},
+		},
+		{
+			name:    "peer absent for a while",
+			samples: []statsSample{{"wireguard": {100, 10}}, {}, {"wireguard": {150, 30}}},
+			wg:      RxTx{150, 30},
+			total:   RxTx{150, 30},
+		},
+		{
+			name:    "64 bit wrap",
+			os:      RxTx{math.MaxUint64 - 10, 7},
+			samples: []statsSample{{"wireguard": {5, 9}}},
+			wg:      RxTx{16, 2},
+			total:   RxTx{16, 2},
+		},
+		{
+			name:    "reset of one protocol only",
+			samples: []statsSample{{"wireguard": {100, 100}, "ipsec": {100, 100}}, {"wireguard": {10, 10}, "ipsec": {200, 200}}},
+			wg:      RxTx{110, 110},
+			ipsec:   RxTx{200, 200},
+			total:   RxTx{310, 310},
+		},
+	}
+
+	const limit = 1000
+
+	key := []byte("merge-stats-test-wg-public-key-3")
+	id := base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(key)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{WgPublicKey: key}
+			user.Quotas.OSWgCounters = tt.os
+			data := &Brigade{Users: []*User{user}}
+
+			for i, sample := range tt.samples {
+				now := time.Now().UTC()
+
+				if err := mergeStats(data, now, wgStatsIn(id, now, sample), false, time.Hour, time.Hour, limit, ThrottlePolicy{}, nil); err != nil {
+					t.Fatalf("sample %d: %s", i, err)
+				}
+			}
+
+			q := &user.Quotas
+			if q.CountersWg.Total != tt.wg || q.CountersIPSec.Total != tt.ipsec || q.CountersTotal.Total != tt.total {
+				t.Errorf("wg %+v, ipsec %+v, total %+v", q.CountersWg.Total, q.CountersIPSec.Total, q.CountersTotal.Total)
+			}
+
+			spent := tt.total.Rx + tt.total.Tx
+			if want := uint64(max(0, limit-int(spent))); q.LimitMonthlyRemaining != want {
+				t.Errorf("quota remaining %d, want %d", q.LimitMonthlyRemaining, want)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
+	"sync"
 	"testing"
 	"time"
 
@@ -24,6 +26,7 @@ func BrigadeTestMiddleware(db *BrigadeStorage, mw utils.TestMainMiddleware) util
 		}
 
 		if err := db.CreateBrigade(
+			context.Background(),
 			&BrigadeConfig{BrigadeID: db.BrigadeID},
 			&BrigadeWgConfig{},
 			&BrigadeOvcConfig{},
@@ -40,7 +43,7 @@ func BrigadeTestMiddleware(db *BrigadeStorage, mw utils.TestMainMiddleware) util
 
 		code := mw(m)
 
-		if err := db.DestroyBrigade(); err != nil {
+		if err := db.DestroyBrigade(context.Background()); err != nil {
 			log.Println("failed to destroy brigade:", err)
 		}
 
@@ -51,3 +54,30 @@ func BrigadeTestMiddleware(db *BrigadeStorage, mw utils.TestMainMiddleware) util
 		return code
 	}
 }
+
+// TestClock - the clock for the tests, it stands still till it is set.
+type TestClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewTestClock - the test clock at the time.
+func NewTestClock(now time.Time) *TestClock {
+	return &TestClock{now: now}
+}
+
+// Now - the clock time.
+func (c *TestClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// Set - move the clock to the time.
+func (c *TestClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = now
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Throttling reasons.
+const (
+	ThrottleReasonQuota  = "quota"  // the monthly quota is exhausted
+	ThrottleReasonBurst  = "burst"  // the daily traffic is over the burst threshold
+	ThrottleReasonManual = "manual" // the brigadier throttled the user
+)
+
+// MaxManualThrottling - the longest brigadier throttling.
+const MaxManualThrottling = 31 * 24 * time.Hour
+
+// ErrInvalidThrottling - the manual throttling period is out of range.
+var ErrInvalidThrottling = errors.New("invalid throttling")
+
+// ThrottlePolicy - when to throttle the users.
+// The zero policy throttles only by the brigadier.
+type ThrottlePolicy struct {
+	Quota      bool   // throttle on the monthly quota exhaustion
+	DailyBurst uint64 // bytes (in+out) per day, 0 - disabled
+}
+
+// decide - set the user throttling state, the manual throttling is kept till it expires.
+// The monthly quota throttling lasts till the quota reset, the burst one - till the day end.
+func (p ThrottlePolicy) decide(q *Quota, now time.Time, monthlyQuotaRemaining int) {
+	switch {
+	case q.ThrottlingReason == ThrottleReasonManual && q.ThrottlingTill.After(now):
+	case p.Quota && q.LimitMonthlyRemaining == 0 && q.monthlyLimit(monthlyQuotaRemaining) > 0:
+		q.ThrottlingTill = q.LimitMonthlyResetOn
+		q.ThrottlingReason = ThrottleReasonQuota
+	case p.DailyBurst > 0 && q.CountersTotal.Daily.Rx+q.CountersTotal.Daily.Tx >= p.DailyBurst:
+		year, month, day := now.Date()
+
+		q.ThrottlingTill = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+		q.ThrottlingReason = ThrottleReasonBurst
+	default:
+		q.ThrottlingTill = time.Time{}
+		q.ThrottlingReason = ""
+	}
+}
+
+// syncThrottling - turn the endpoint throttling on or off as decided.
+// The blocked users have no peer on the endpoint. The state is set by the done
+// call only, the queued one sets it when the outbox gets it done.
+func (db *BrigadeStorage) syncThrottling(ctx context.Context, data *Brigade, user *User, now time.Time) error {
+	on := user.Quotas.ThrottlingTill.After(now)
+	if user.IsBlocked || on == data.throttlingState(user) {
+		return nil
+	}
+
+	kind := OutboxThrottleOff
+	if on {
+		kind = OutboxThrottleOn
+	}
+
+	if err := db.peerCall(ctx, data, kind, user); err != nil {
+		return fmt.Errorf("throttle: %w", err)
+	}
+
+	if !data.pendingOps(user.WgPublicKey) {
+		user.Quotas.ThrottlingOn = on
+	}
+
+	return nil
+}
+
+// ThrottleUser - throttle the user by the brigadier for the period, zero lifts the brigadier throttling.
+// The revision is the expected brigade revision, nil means any.
+func (db *BrigadeStorage) ThrottleUser(ctx context.Context, id string, period time.Duration, revision *uint64) error {
+	if period < 0 || period > MaxManualThrottling {
+		return fmt.Errorf("%w: %s", ErrInvalidThrottling, period)
+	}
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		return fmt.Errorf("db: %w", err)
+	}
+
+	defer f.Close()
+
+	if err := checkRevision(data, revision); err != nil {
+		return err
+	}
+
+	var user *User
+
+	for _, u := range data.Users {
+		if u.UserID.String() == id {
+			user = u
+
+			break
+		}
+	}
+
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	now := db.now()
+
+	switch period {
+	case 0:
+		if user.Quotas.ThrottlingReason == ThrottleReasonManual {
+			user.Quotas.ThrottlingTill = time.Time{}
+			user.Quotas.ThrottlingReason = ""
+		}
+
+		db.Throttle.decide(&user.Quotas, now, db.MonthlyQuotaRemaining)
+	default:
+		user.Quotas.ThrottlingTill = now.Add(period)
+		user.Quotas.ThrottlingReason = ThrottleReasonManual
+	}
+
+	if err := db.syncThrottling(ctx, data, user, now); err != nil {
+		return fmt.Errorf("sync: %w", err)
+	}
+
+	user.throttleEvents(now)
+
+	if err := commitBrigade(f, "user_throttle", data); err != nil {
+		return fmt.Errorf("save: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "User %s throttling: %v (%s)\n", id, user.Quotas.ThrottlingOn, user.Quotas.ThrottlingReason)
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestThrottlePolicy(t *testing.T) {
+	const gb = 1024 * 1024 * 1024
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	policy := ThrottlePolicy{Quota: true, DailyBurst: 10 * gb}
+	q := &Quota{LimitMonthlyRemaining: 50 * gb, LimitMonthlyResetOn: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+
+	q.CountersTotal.Daily = RxTx{Rx: 8 * gb, Tx: 2 * gb}
+	policy.decide(q, now, 100*gb)
+
+	if q.ThrottlingReason != ThrottleReasonBurst || !q.ThrottlingTill.Equal(time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("burst: %s till %s", q.ThrottlingReason, q.ThrottlingTill)
+	}
+
+	q.LimitMonthlyRemaining = 0
+	policy.decide(q, now, 100*gb)
+
+	if q.ThrottlingReason != ThrottleReasonQuota || !q.ThrottlingTill.Equal(q.LimitMonthlyResetOn) {
+		t.Errorf("quota: %s till %s", q.ThrottlingReason, q.ThrottlingTill)
+	}
+
+	ThrottlePolicy{}.decide(q, now, 100*gb)
+
+	if q.ThrottlingReason != "" {
+		t.Errorf("no policy: %s", q.ThrottlingReason)
+	}
+
+	q.ThrottlingTill, q.ThrottlingReason = now.Add(time.Hour), ThrottleReasonManual
+	q.LimitMonthlyRemaining = 50 * gb
+	q.CountersTotal.Daily = RxTx{}
+	policy.decide(q, now, 100*gb)
+
+	if q.ThrottlingReason != ThrottleReasonManual {
+		t.Errorf("manual kept: %s", q.ThrottlingReason)
+	}
+
+	policy.decide(q, now.Add(2*time.Hour), 100*gb)
+
+	if q.ThrottlingReason != "" || !q.ThrottlingTill.IsZero() {
+		t.Errorf("lifted: %s till %s", q.ThrottlingReason, q.ThrottlingTill)
+	}
+}
+
+func TestThrottleEndpoint(t *testing.T) {
+	id := uuid.New()
+
+	f, data, err := db.openWithReading()
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	data.Users = append(data.Users, &User{
+		UserID:      id,
+		WgPublicKey: []byte("throttled-user-wg-public-key-032"),
+		Quotas: Quota{
+			LimitMonthly:        1,
+			LimitMonthlyResetOn: time.Now().UTC().Add(24 * time.Hour),
+		},
+	})
+
+	if err := commitBrigade(f, "test", data); err != nil {
+		f.Close()
+		t.Fatalf("commit: %s", err)
+	}
+
+	f.Close()
+
+	defer func() {
+		if err := db.DeleteUser(context.Background(), id.String(), false, false, nil); err != nil {
+			t.Errorf("delete: %s", err)
+		}
+	}()
+
+	db.Throttle = ThrottlePolicy{Quota: true}
+
+	defer func() { db.Throttle = ThrottlePolicy{} }()
+
+	endpoint := newRecordingEndpoint()
+
+	db.Endpoint = endpoint
+	defer func() { db.Endpoint = nil }()
+
+	calls := endpoint.count
+
+	// the monthly quota is exhausted.
+	if _, _, err := db.getStatsQuota(context.Background(), true, time.Hour); err != nil {
+		t.Fatalf("stats: %s", err)
+	}
+
+	if n := calls(OutboxThrottleOn); n != 1 {
+		t.Errorf("quota: %d throttle_on calls", n)
+	}
+
+	if _, _, err := db.getStatsQuota(context.Background(), true, time.Hour); err != nil {
+		t.Fatalf("stats: %s", err)
+	}
+
+	if n := calls(OutboxThrottleOn); n != 0 {
+		t.Errorf("already throttled: %d throttle_on calls", n)
+	}
+
+	if err := db.ThrottleUser(context.Background(), id.String(), time.Hour, nil); err != nil {
+		t.Fatalf("manual: %s", err)
+	}
+
+	if err := db.DeleteUser(context.Background(), id.String(), false, true, nil); err != nil {
+		t.Fatalf("block: %s", err)
+	}
+
+	endpoint.count("")
+
+	if err := db.UnblockUser(context.Background(), id.String(), nil); err != nil {
+		t.Fatalf("unblock: %s", err)
+	}
+
+	if n := calls(OutboxThrottleOn); n != 1 {
+		t.Errorf("unblocked: %d throttle_on calls", n)
+	}
+
+	if err := db.SetUserQuota(id.String(), UserQuotaLimits{Monthly: new(uint64)}, nil); err != nil {
+		t.Fatalf("quota: %s", err)
+	}
+
+	if err := db.ThrottleUser(context.Background(), id.String(), 0, nil); err != nil {
+		t.Fatalf("lift: %s", err)
+	}
+
+	if n := calls(OutboxThrottleOff); n != 1 {
+		t.Errorf("lifted: %d throttle_off calls", n)
+	}
+
+	if err := db.ThrottleUser(context.Background(), id.String(), MaxManualThrottling+time.Hour, nil); err == nil {
+		t.Error("too long throttling accepted")
+	}
+}
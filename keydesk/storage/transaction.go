@@ -19,3 +19,19 @@ func (db *BrigadeStorage) RunInTransaction(fn func(brigade *Brigade) error) erro
 
 	return nil
 }
+
+// ViewTransaction - run fn with the brigade read under the shared lock,
+// changes made by fn are not stored.
+func (db *BrigadeStorage) ViewTransaction(fn func(brigade *Brigade) error) error {
+	f, brigade, err := db.openReadOnly()
+	if err != nil {
+		return fmt.Errorf("open to read: %w", err)
+	}
+	defer f.Close()
+
+	if err = fn(brigade); err != nil {
+		return fmt.Errorf("view transaction: %w", err)
+	}
+
+	return nil
+}
@@ -18,14 +18,15 @@ const DateSummaryNetCountersVersion = 1
 
 // DateSummaryNetCounters - traffic counters container.
 type DateSummaryNetCounters struct {
-	Ver     int       `json:"version"`
-	Update  time.Time `json:"update,omitempty"`
-	Total   RxTx      `json:"total"`
-	Yearly  RxTx      `json:"yearly"`
-	Monthly RxTx      `json:"monthly"`
-	Weekly  RxTx      `json:"weekly"`
-	Daily   RxTx      `json:"daily"`
-	PrevDay RxTx      `json:"pday"`
+	Ver     int          `json:"version"`
+	Update  time.Time    `json:"update,omitempty"`
+	Total   RxTx         `json:"total"`
+	Yearly  RxTx         `json:"yearly"`
+	Monthly RxTx         `json:"monthly"`
+	Weekly  RxTx         `json:"weekly"`
+	Daily   RxTx         `json:"daily"`
+	PrevDay RxTx         `json:"pday"`
+	History DailyHistory `json:"history"`
 }
 
 // RxTx - rx/tx counters.
@@ -164,6 +165,8 @@ type Quota struct {
 	CountersProto0        DateSummaryNetCounters `json:"counters_proto0"`
 	LimitMonthlyRemaining uint64                 `json:"limit_monthly_remaining"`
 	LimitMonthlyResetOn   time.Time              `json:"limit_monthly_reset_on,omitempty"`
+	LimitMonthly          uint64                 `json:"limit_monthly,omitempty"`           // 0 - the brigade default
+	LimitMonthlyResetDay  int                    `json:"limit_monthly_reset_day,omitempty"` // 0 - the 1st
 	LastActivity          LastActivityPoints     `json:"last_activity,omitempty"`
 	LastWgActivity        LastActivityPoints     `json:"last_wg_activity,omitempty"`
 	LastIPSecActivity     LastActivityPoints     `json:"last_ipsec_activity,omitempty"`
@@ -172,6 +175,8 @@ type Quota struct {
 	LastOutlineActivity   LastActivityPoints     `json:"last_outline_activity,omitempty"`
 	LastProto0Activity    LastActivityPoints     `json:"last_proto0_activity,omitempty"`
 	ThrottlingTill        time.Time              `json:"throttling_till,omitempty"`
+	ThrottlingReason      string                 `json:"throttling_reason,omitempty"` // quota, burst, manual
+	ThrottlingOn          bool                   `json:"throttling_on,omitempty"`     // the endpoint state
 }
 
 // UserVersion - json version.
@@ -211,6 +216,8 @@ type User struct {
 	Proto0SecretShufflerEnc   string                `json:"proto0_secret_shuffler_enc"`    // Protocol0 secret for shuffler prepared
 	Person                    namesgenerator.Person `json:"person"`
 	Quotas                    Quota                 `json:"quotas"`
+	Events                    []UserEvent           `json:"events,omitempty"`
+	Alerts                    map[string]time.Time  `json:"alerts,omitempty"` // the last alert time per kind
 }
 
 func NewUser(userID uuid.UUID, name string, createdAt time.Time, isBrigadier, isSocket bool, IPv4Addr netip.Addr, IPv6Addr netip.Addr, person namesgenerator.Person) User {
@@ -233,7 +240,8 @@ type Brigade struct {
 	BrigadeCounters
 	StatsCountersStack    `json:"counters_stack"`
 	Ver                   int                  `json:"version"`
-	VIP                   int64                `json:"vip"` // is vip brigade
+	Revision              uint64               `json:"revision,omitempty"` // bumped by the API visible commits
+	VIP                   int64                `json:"vip"`                // is vip brigade
 	BrigadeID             string               `json:"brigade_id"`
 	CreatedAt             time.Time            `json:"created_at"`
 	Mode                  Mode                 `json:"mode"`
@@ -264,7 +272,9 @@ type Brigade struct {
 	KeydeskFirstVisit     time.Time            `json:"keydesk_first_visit,omitempty"`
 	Users                 []*User              `json:"users,omitempty"`
 	Endpoints             UsersNetworks        `json:"endpoints,omitempty"`
+	Geo                   *GeoSummary          `json:"geo,omitempty"`
 	Messages              []Message            `json:"messages,omitempty"`
+	Outbox                []OutboxOp           `json:"outbox,omitempty"` // the endpoint operations to retry
 	Subscription          webpush.Subscription `json:"subscription"`
 }
 
@@ -352,10 +362,12 @@ type Stats struct {
 
 	Ver               int           `json:"version"`
 	BrigadeID         string        `json:"brigade_id"`
+	VIP               bool          `json:"vip,omitempty"`
 	UpdateTime        time.Time     `json:"update_time"`
 	BrigadeCreatedAt  time.Time     `json:"brigade_created_at"`
 	KeydeskFirstVisit time.Time     `json:"keydesk_first_visit,omitempty"`
 	Endpoints         UsersNetworks `json:"endpoints,omitempty"`
+	Geo               *GeoSummary   `json:"geo,omitempty"`
 }
 
 // LastActivityPoints - traffic counters container.
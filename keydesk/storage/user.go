@@ -1,9 +1,9 @@
 package storage
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/netip"
@@ -18,30 +18,9 @@ import (
 	"github.com/vpngen/wordsgens/namesgenerator"
 )
 
-type APIUserResponse struct {
-	Code                     string `json:"code"`
-	OpenvpnClientCertificate string `json:"openvpn-client-certificate"`
-}
-
-const testCert = `-----BEGIN CERTIFICATE-----
-MIIChjCCAeigAwIBAgIUHYRJHPNW+eqW3TkSaWhpRxqyk68wCgYIKoZIzj0EAwIw
-VDELMAkGA1UEBhMCUlUxEzARBgNVBAgMClNvbWUtU3RhdGUxITAfBgNVBAoMGElu
-dGVybmV0IFdpZGdpdHMgUHR5IEx0ZDENMAsGA1UEAwwEVGVzdDAgFw0yMzA4MTcx
-NDE0MTRaGA8yMDUxMDEwMjE0MTQxNFowVDELMAkGA1UEBhMCUlUxEzARBgNVBAgM
-ClNvbWUtU3RhdGUxITAfBgNVBAoMGEludGVybmV0IFdpZGdpdHMgUHR5IEx0ZDEN
-MAsGA1UEAwwEVGVzdDCBmzAQBgcqhkjOPQIBBgUrgQQAIwOBhgAEADrZB/oUNXuU
-kAoyC1DCoqWnp0pdJx5GuxqxAJD9uMYOS05G3PjAboesJohnoFGOld2Zh2Kuj6OJ
-ULh9hTj14eB7AZT4YX/vjA/odBS/Bu9PSjMiyrwTCms1hkMl2EvS06Hc3ElrjsuY
-YMma/Chd8G+GAX12ijNO7BMlhLjhoZm383oao1MwUTAdBgNVHQ4EFgQU3x7cM6Kd
-TEJN6KQvc0cHjAODOCwwHwYDVR0jBBgwFoAU3x7cM6KdTEJN6KQvc0cHjAODOCww
-DwYDVR0TAQH/BAUwAwEB/zAKBggqhkjOPQQDAgOBiwAwgYcCQUtlwuBJgT4gSGfH
-yax9nYcFz6DzTaXWe3CZG0oLReUTrP88CeYfevWAvO7etL8IRKr48OWWm+sARDzY
-GH/IDRigAkIBI45wN1CUGzzBjF8/faxNy6XWhcSkFZW7oCRR0MWaL6bn69naej8K
-0msNdKBh0Uyk4SK0q+4NlBMTgoimpXcNdk8=
------END CERTIFICATE-----`
-
-// CreateUser - put user to the storage.
+// CreateUser - put user to the storage, ctx bounds the endpoint calls.
 func (db *BrigadeStorage) CreateUser(
+	ctx context.Context,
 	uid uuid.UUID,
 	vpnCfgs *ConfigsImplemented,
 	fullname string,
@@ -62,7 +41,13 @@ func (db *BrigadeStorage) CreateUser(
 	outlineSecretShufflerEnc string,
 	proto0SecretRouterEnc string,
 	proto0SecreShufflerEnc string,
+	limits UserQuotaLimits,
+	revision *uint64,
 ) (*UserConfig, error) {
+	if err := limits.validate(); err != nil {
+		return nil, err
+	}
+
 	f, data, err := db.openWithReading()
 	if err != nil {
 		return nil, fmt.Errorf("db: %w", err)
@@ -70,8 +55,14 @@ func (db *BrigadeStorage) CreateUser(
 
 	defer f.Close()
 
+	if err := checkRevision(data, revision); err != nil {
+		return nil, err
+	}
+
+	var events []UserEvent
+
 	if isBrigadier && replaceBrigadier {
-		fullname, person, err = db.removeBrigadier(data)
+		fullname, person, events, err = db.removeBrigadier(ctx, data)
 		if err != nil {
 			return nil, fmt.Errorf("replace: %w", err)
 		}
@@ -82,7 +73,7 @@ func (db *BrigadeStorage) CreateUser(
 		return nil, fmt.Errorf("assemble: %w", err)
 	}
 
-	ts := time.Now().UTC()
+	ts := db.now()
 
 	userconf := &UserConfig{
 		ID:               id,
@@ -139,36 +130,35 @@ func (db *BrigadeStorage) CreateUser(
 		userconf.Proto0Port = data.Proto0Port
 	}
 
-	// if we catch a slowdown problems we need organize queue
-	body, err := vpnapi.WgPeerAdd(
-		data.BrigadeID,
-		db.actualAddrPort, db.calculatedAddrPort,
-		wgPub, data.WgPublicKey, wgRouterPSK,
-		userconf.IPv4, userconf.IPv6, kd6,
-		ovcCertRequestGzipBase64, cloakBypassUIDRouterEnc,
-		ipsecUsernameRouterEnc, ipsecPasswordRouterEnc,
-		outlineSecretRouterEnc,
-		proto0SecretRouterEnc,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("wg peer add: %w", err)
-	}
-
-	payload := &APIUserResponse{}
+	// the peer without the certificate to issue is queued on the transport failure.
+	queued := false
+
+	payload, err := db.EndpointClient().PeerAdd(ctx, vpnapi.PeerAddRequest{
+		PeerPublicKey: wgPub,
+		WgPublicKey:   data.WgPublicKey,
+		WgPSK:         wgRouterPSK,
+		AllowedIPs:    []netip.Prefix{netip.PrefixFrom(userconf.IPv4, userconf.IPv4.BitLen()), netip.PrefixFrom(userconf.IPv6, userconf.IPv6.BitLen())},
+		OpenVPNCSR:    ovcCertRequestGzipBase64,
+		CloakUID:      cloakBypassUIDRouterEnc,
+		L2TPUsername:  ipsecUsernameRouterEnc,
+		L2TPPassword:  ipsecPasswordRouterEnc,
+		OutlineSecret: outlineSecretRouterEnc,
+		Proto0Secret:  proto0SecretRouterEnc,
+		ControlHost:   kd6,
+	})
+	switch {
+	case err == nil:
+	case ovcCertRequestGzipBase64 == "" && retryableEndpointError(err):
+		fmt.Fprintf(os.Stderr, "User %s %s queued: %s\n", userconf.ID, OutboxPeerAdd, err)
 
-	switch db.actualAddrPort.Addr().IsValid() {
-	case true:
-		if err := json.Unmarshal(body, payload); err != nil {
-			return nil, fmt.Errorf("resp body: %w", err)
-		}
+		queued, payload = true, &vpnapi.PeerAddResponse{}
 	default:
-		payload.Code = "0"
-		payload.OpenvpnClientCertificate = testCert
+		return nil, fmt.Errorf("wg peer add: %w", err)
 	}
 
 	userconf.OvClientCertPem = payload.OpenvpnClientCertificate
 
-	data.Users = append(data.Users, &User{
+	user := &User{
 		UserID:                    userconf.ID,
 		Name:                      userconf.Name,
 		CreatedAt:                 ts, // creazy but can be data.KeydeskLastVisit
@@ -215,8 +205,25 @@ func (db *BrigadeStorage) CreateUser(
 			LimitMonthlyResetOn:   kdlib.NextMonthlyResetOn(ts),
 			Ver:                   QuotaVesrion,
 		},
-		Ver: UserVersion,
-	})
+		Events: events,
+		Ver:    UserVersion,
+	}
+
+	user.Quotas.setLimits(limits, ts, db.MonthlyQuotaRemaining)
+
+	switch events {
+	case nil:
+		user.addEvent(UserEventCreated, ts, "")
+	default:
+		// the replaced brigadier keeps the history.
+		user.addEvent(UserEventConfigReissued, ts, "")
+	}
+
+	data.Users = append(data.Users, user)
+
+	if queued {
+		data.enqueue(OutboxPeerAdd, user, ts)
+	}
 
 	sort.Slice(data.Users, func(i, j int) bool {
 		return data.Users[i].IsBrigadier || !data.Users[j].IsBrigadier && (data.Users[i].UserID.String() > data.Users[j].UserID.String())
@@ -225,7 +232,7 @@ func (db *BrigadeStorage) CreateUser(
 	userconf.FreeSlots = db.MaxUsers - len(data.Users)
 	userconf.TotalSlots = db.MaxUsers
 
-	if err := commitBrigade(f, data); err != nil {
+	if err := commitBrigade(f, "create_user", data); err != nil {
 		return nil, fmt.Errorf("save: %w", err)
 	}
 
@@ -326,7 +333,8 @@ func assembleUser(uid uuid.UUID, data *Brigade, fullname string, isBrigadier boo
 }
 
 // DeleteUser - remove user from the storage.
-func (db *BrigadeStorage) DeleteUser(id string, brigadier bool, onlyBlock bool) error {
+// The revision is the expected brigade revision, nil means any.
+func (db *BrigadeStorage) DeleteUser(ctx context.Context, id string, brigadier bool, onlyBlock bool, revision *uint64) error {
 	f, data, err := db.openWithReading()
 	if err != nil {
 		return fmt.Errorf("db: %w", err)
@@ -334,6 +342,10 @@ func (db *BrigadeStorage) DeleteUser(id string, brigadier bool, onlyBlock bool)
 
 	defer f.Close()
 
+	if err := checkRevision(data, revision); err != nil {
+		return err
+	}
+
 	var (
 		user *User
 		idx  int
@@ -353,14 +365,15 @@ func (db *BrigadeStorage) DeleteUser(id string, brigadier bool, onlyBlock bool)
 	}
 
 	if !user.IsBlocked {
-		// if we catch a slowdown problems we need organize queue
-		if err := vpnapi.WgPeerDel(data.BrigadeID, db.actualAddrPort, db.calculatedAddrPort, user.WgPublicKey, data.WgPublicKey); err != nil {
+		if err := db.peerCall(ctx, data, OutboxPeerDel, user); err != nil {
 			return fmt.Errorf("peer del: %w", err)
 		}
 
 		if onlyBlock {
 			user.IsBlocked = true
-			user.BlockedAt = time.Now().UTC()
+			user.BlockedAt = db.now()
+			user.Quotas.ThrottlingOn = false // the peer is gone with its throttling
+			user.addEvent(UserEventBlocked, user.BlockedAt, "")
 		}
 	}
 
@@ -368,7 +381,12 @@ func (db *BrigadeStorage) DeleteUser(id string, brigadier bool, onlyBlock bool)
 		data.Users = append(data.Users[:idx], data.Users[idx+1:]...)
 	}
 
-	if err := commitBrigade(f, data); err != nil {
+	op := "delete_user"
+	if onlyBlock {
+		op = "block_user"
+	}
+
+	if err := commitBrigade(f, op, data); err != nil {
 		return fmt.Errorf("save: %w", err)
 	}
 
@@ -378,7 +396,8 @@ func (db *BrigadeStorage) DeleteUser(id string, brigadier bool, onlyBlock bool)
 }
 
 // UnblockUser - remove user from the storage.
-func (db *BrigadeStorage) UnblockUser(id string) error {
+// The revision is the expected brigade revision, nil means any.
+func (db *BrigadeStorage) UnblockUser(ctx context.Context, id string, revision *uint64) error {
 	f, data, err := db.openWithReading()
 	if err != nil {
 		return fmt.Errorf("db: %w", err)
@@ -386,6 +405,10 @@ func (db *BrigadeStorage) UnblockUser(id string) error {
 
 	defer f.Close()
 
+	if err := checkRevision(data, revision); err != nil {
+		return err
+	}
+
 	wgPub := []byte{}
 	for _, user := range data.Users {
 		if user.UserID.String() == id {
@@ -397,26 +420,18 @@ func (db *BrigadeStorage) UnblockUser(id string) error {
 				break
 			}
 
-			kd6 := netip.Addr{}
-			if user.IsBrigadier {
-				kd6 = data.KeydeskIPv6
-			}
-
-			// if we catch a slowdown problems we need organize queue
-			if _, err = vpnapi.WgPeerAdd(
-				data.BrigadeID,
-				db.actualAddrPort, db.calculatedAddrPort,
-				user.WgPublicKey, data.WgPublicKey, user.WgPSKRouterEnc,
-				user.IPv4Addr, user.IPv6Addr, kd6,
-				user.OvCSRGzipBase64, user.CloakByPassUIDRouterEnc,
-				user.IPSecUsernameRouterEnc, user.IPSecPasswordRouterEnc,
-				user.OutlineSecretRouterEnc, user.Proto0SecretRouterEnc,
-			); err != nil {
+			if err := db.peerCall(ctx, data, OutboxPeerAdd, user); err != nil {
 				return fmt.Errorf("wg add: %w", err)
 			}
 
 			user.IsBlocked = false
 			user.BlockedAt = time.Time{}
+			user.addEvent(UserEventUnblocked, db.now(), "")
+
+			// the stats round retries it.
+			if err := db.syncThrottling(ctx, data, user, db.now()); err != nil {
+				fmt.Fprintf(os.Stderr, "User %s throttling: %s\n", id, err)
+			}
 
 			break
 		}
@@ -426,7 +441,7 @@ func (db *BrigadeStorage) UnblockUser(id string) error {
 		return ErrUserNotFound
 	}
 
-	if err := commitBrigade(f, data); err != nil {
+	if err := commitBrigade(f, "unblock_user", data); err != nil {
 		return fmt.Errorf("save: %w", err)
 	}
 
@@ -435,23 +450,23 @@ func (db *BrigadeStorage) UnblockUser(id string) error {
 	return nil
 }
 
-func (db *BrigadeStorage) removeBrigadier(data *Brigade) (string, namesgenerator.Person, error) {
+func (db *BrigadeStorage) removeBrigadier(ctx context.Context, data *Brigade) (string, namesgenerator.Person, []UserEvent, error) {
 	var (
 		fullname string
 		person   namesgenerator.Person
+		events   []UserEvent
 	)
 
 	for i, user := range data.Users {
 		if user.IsBrigadier {
 			fullname, person = strings.TrimLeft(user.Name, "0123456789 "), user.Person
+			events = append([]UserEvent{}, user.Events...)
 
 			wgPub := user.WgPublicKey
 			data.Users = append(data.Users[:i], data.Users[i+1:]...)
 
-			// if we catch a slowdown problems we need organize queue
-			err := vpnapi.WgPeerDel(data.BrigadeID, db.actualAddrPort, db.calculatedAddrPort, wgPub, data.WgPublicKey)
-			if err != nil {
-				return "", namesgenerator.Person{}, fmt.Errorf("peer del: %w", err)
+			if err := db.peerCall(ctx, data, OutboxPeerDel, user); err != nil {
+				return "", namesgenerator.Person{}, nil, fmt.Errorf("peer del: %w", err)
 			}
 
 			fmt.Fprintf(os.Stderr, "Brigadier %s (%s) removed\n", user.UserID, base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgPub))
@@ -460,31 +475,54 @@ func (db *BrigadeStorage) removeBrigadier(data *Brigade) (string, namesgenerator
 		}
 	}
 
-	return fullname, person, nil
+	return fullname, person, events, nil
+}
+
+// ListUsers - list users and the brigade revision.
+func (db *BrigadeStorage) ListUsers() ([]*User, uint64, error) {
+	f, data, err := db.openReadOnly()
+	if err != nil {
+		return nil, 0, fmt.Errorf("db: %w", err)
+	}
+
+	f.Close()
+
+	revision := data.Revision
+
+	if data.KeydeskFirstVisit.IsZero() {
+		if revision, err = db.firstVisit(); err != nil {
+			return nil, 0, fmt.Errorf("first visit: %w", err)
+		}
+	}
+
+	return data.Users, revision, nil
 }
 
-// ListUsers - list users.
-func (db *BrigadeStorage) ListUsers() ([]*User, error) {
+// firstVisit - the only write on the dashboard read path, once per brigade.
+// Returns the brigade revision after the write.
+func (db *BrigadeStorage) firstVisit() (uint64, error) {
 	f, data, err := db.openWithReading()
 	if err != nil {
-		return nil, fmt.Errorf("db: %w", err)
+		return 0, fmt.Errorf("db: %w", err)
 	}
 
 	defer f.Close()
 
-	if data.KeydeskFirstVisit.IsZero() {
-		data.KeydeskFirstVisit = time.Now().UTC()
+	if !data.KeydeskFirstVisit.IsZero() {
+		return data.Revision, nil
+	}
 
-		if err := commitBrigade(f, data); err != nil {
-			return nil, fmt.Errorf("save: %w", err)
-		}
+	data.KeydeskFirstVisit = db.now()
+
+	if err := commitBrigade(f, "first_visit", data); err != nil {
+		return 0, fmt.Errorf("save: %w", err)
 	}
 
-	return data.Users, nil
+	return data.Revision, nil
 }
 
 func (db *BrigadeStorage) GetUsersStats() (StatsCountersStack, int, int, error) {
-	f, data, err := db.openWithReading()
+	f, data, err := db.openReadOnly()
 	if err != nil {
 		return StatsCountersStack{}, 0, 0, fmt.Errorf("db: %w", err)
 	}
@@ -27,7 +27,7 @@ func (db *BrigadeStorage) SetVIP(vip bool) error {
 }
 
 func (db *BrigadeStorage) IsVIP() bool {
-	f, brigade, err := db.openWithReading()
+	f, brigade, err := db.openReadOnly()
 	if err != nil {
 		return false
 	}
@@ -121,7 +121,7 @@ func (c *ConfigsImplemented) NewProto0Configs(req map[string]bool) {
 
 // GetSupportedVPNProtocols returns the list of supported VPN types
 func (db *BrigadeStorage) GetSupportedVPNProtocols() ([]string, error) {
-	f, data, err := db.openWithReading()
+	f, data, err := db.openReadOnly()
 	if err != nil {
 		return nil, fmt.Errorf("open db: %w", err)
 	}
@@ -1,6 +1,7 @@
 package keydesk
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/x509"
 	"encoding/base64"
@@ -8,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net/http"
 	"net/netip"
 	"net/url"
 	"os"
@@ -64,8 +66,26 @@ const (
 // AddUser - create user.
 func AddUser(db *storage.BrigadeStorage, params operations.PostUserParams, principal interface{}, routerPublicKey, shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte) middleware.Responder {
 	/// fmt.Fprintf(os.Stderr, "****************** AddUser(db *storage.BrigadeStorage\n")
-	user, vpnCfgs, wgPriv, wgPSK, ovcPriv, cloakBypassUID, ipsecUsername, ipsecPassword, outlineSecret, proto0LongID, proto0ShortID, err := pickUpUser(db, routerPublicKey, shufflerPublicKey)
+	revision, err := ifMatchRevision(params.IfMatch)
 	if err != nil {
+		return operations.NewPostUserDefault(http.StatusBadRequest).WithPayload(invalidETagError())
+	}
+
+	limits, err := quotaLimits(params.MonthlyQuotaGB, params.MonthlyQuotaResetDay)
+	if err != nil {
+		return operations.NewPostUserDefault(http.StatusBadRequest).WithPayload(quotaError(err))
+	}
+
+	user, vpnCfgs, wgPriv, wgPSK, ovcPriv, cloakBypassUID, ipsecUsername, ipsecPassword, outlineSecret, proto0LongID, proto0ShortID, err := pickUpUser(params.HTTPRequest.Context(), db, limits, revision, routerPublicKey, shufflerPublicKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrRevisionMismatch) {
+			return operations.NewPostUserPreconditionFailed()
+		}
+
+		if status := EndpointStatus(err); status != 0 {
+			return operations.NewPostUserDefault(status).WithPayload(endpointError(status, err))
+		}
+
 		return operations.NewPostUserInternalServerError()
 	}
 
@@ -78,7 +98,7 @@ func AddUser(db *storage.BrigadeStorage, params operations.PostUserParams, princ
 }
 
 // AddBrigadier - create brigadier user.
-func AddBrigadier(db *storage.BrigadeStorage, fullname string, person namesgenerator.Person, replaceBrigadier bool, reqVpnCfgs *storage.ConfigsImplemented, routerPublicKey, shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte) (string, string, *models.Newuser, error) {
+func AddBrigadier(ctx context.Context, db *storage.BrigadeStorage, fullname string, person namesgenerator.Person, replaceBrigadier bool, reqVpnCfgs *storage.ConfigsImplemented, routerPublicKey, shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte) (string, string, *models.Newuser, error) {
 	if ok, till, msg := maintenance.CheckInPaths("/.maintenance", filepath.Dir(db.BrigadeFilename)+"/.maintenance"); ok {
 		return "", "", nil, maintenance.NewError(till, msg)
 	}
@@ -96,7 +116,7 @@ func AddBrigadier(db *storage.BrigadeStorage, fullname string, person namesgener
 		return "", "", nil, fmt.Errorf("get vpn configs: %w", err)
 	}
 
-	user, wgPriv, wgPSK, ovcPriv, cloakBypassUID, ipsecUsername, ipsecPassword, outlineSecret, proto0LongID, proto0ShortID, err := addUser(db, dbVpnCfgs, fullname, person, true, replaceBrigadier, routerPublicKey, shufflerPublicKey)
+	user, wgPriv, wgPSK, ovcPriv, cloakBypassUID, ipsecUsername, ipsecPassword, outlineSecret, proto0LongID, proto0ShortID, err := addUser(ctx, db, dbVpnCfgs, fullname, person, true, replaceBrigadier, storage.UserQuotaLimits{}, nil, routerPublicKey, shufflerPublicKey)
 	if err != nil {
 		return "", "", nil, fmt.Errorf("addUser: %w", err)
 	}
@@ -279,7 +299,10 @@ func assembleConfig(
 }
 
 func pickUpUser(
+	ctx context.Context,
 	db *storage.BrigadeStorage,
+	limits storage.UserQuotaLimits,
+	revision *uint64,
 	routerPublicKey, shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte,
 ) (*storage.UserConfig, *storage.ConfigsImplemented, []byte, []byte, string, string, string, string, string, string, string, error) {
 	for {
@@ -293,7 +316,7 @@ func pickUpUser(
 			return nil, nil, nil, nil, "", "", "", "", "", "", "", fmt.Errorf("get vpn configs: %w", err)
 		}
 
-		user, wgPriv, wgPSK, ovcPriv, CloakByPassUID, ippsecUsername, ipsecPassword, outlineSecret, proto0LongID, proto0ShortID, err := addUser(db, vpnCfgs, fullname, person, false, false, routerPublicKey, shufflerPublicKey)
+		user, wgPriv, wgPSK, ovcPriv, CloakByPassUID, ippsecUsername, ipsecPassword, outlineSecret, proto0LongID, proto0ShortID, err := addUser(ctx, db, vpnCfgs, fullname, person, false, false, limits, revision, routerPublicKey, shufflerPublicKey)
 		if err != nil {
 			if errors.Is(err, storage.ErrUserCollision) {
 				continue
@@ -307,12 +330,15 @@ func pickUpUser(
 }
 
 func addUser(
+	ctx context.Context,
 	db *storage.BrigadeStorage,
 	vpnCfgs *storage.ConfigsImplemented,
 	fullname string,
 	person namesgenerator.Person,
 	IsBrigadier,
 	replaceBrigadier bool,
+	limits storage.UserQuotaLimits,
+	revision *uint64,
 	routerPublicKey,
 	shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte,
 ) (*storage.UserConfig, []byte, []byte, string, string, string, string, string, string, string, error) {
@@ -393,6 +419,7 @@ func addUser(
 	}
 
 	userconf, err := db.CreateUser(
+		ctx,
 		uuid.Nil,
 		vpnCfgs, fullname, person,
 		IsBrigadier, replaceBrigadier,
@@ -402,6 +429,8 @@ func addUser(
 		ipsecUsernameShuffler, ipsecPasswordShuffler,
 		outlineSecretRouterEnc, outlineSecretShufflerEnc,
 		proto0SecretRouterEnc, proto0SecretShufflerEnc,
+		limits,
+		revision,
 	)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "put: %s\n", err)
@@ -414,10 +443,23 @@ func addUser(
 
 // DelUserUserID - delete user by UserID.
 func DelUserUserID(db *storage.BrigadeStorage, params operations.DeleteUserUserIDParams, principal interface{}) middleware.Responder {
-	err := db.DeleteUser(params.UserID, false, false)
+	revision, err := ifMatchRevision(params.IfMatch)
+	if err != nil {
+		return operations.NewDeleteUserUserIDDefault(http.StatusBadRequest).WithPayload(invalidETagError())
+	}
+
+	err = db.DeleteUser(params.HTTPRequest.Context(), params.UserID, false, false, revision)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Delete user: %s :%s\n", params.UserID, err)
 
+		if errors.Is(err, storage.ErrRevisionMismatch) {
+			return operations.NewDeleteUserUserIDPreconditionFailed()
+		}
+
+		if status := EndpointStatus(err); status != 0 {
+			return operations.NewDeleteUserUserIDDefault(status).WithPayload(endpointError(status, err))
+		}
+
 		return operations.NewDeleteUserUserIDForbidden()
 	}
 
@@ -426,10 +468,23 @@ func DelUserUserID(db *storage.BrigadeStorage, params operations.DeleteUserUserI
 
 // BlockUserUserID - block user by UserID.
 func BlockUserUserID(db *storage.BrigadeStorage, params operations.PatchUserUserIDBlockParams, principal interface{}) middleware.Responder {
-	err := db.DeleteUser(params.UserID, false, true)
+	revision, err := ifMatchRevision(params.IfMatch)
+	if err != nil {
+		return operations.NewPatchUserUserIDBlockDefault(http.StatusBadRequest).WithPayload(invalidETagError())
+	}
+
+	err = db.DeleteUser(params.HTTPRequest.Context(), params.UserID, false, true, revision)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Block user: %s :%s\n", params.UserID, err)
 
+		if errors.Is(err, storage.ErrRevisionMismatch) {
+			return operations.NewPatchUserUserIDBlockPreconditionFailed()
+		}
+
+		if status := EndpointStatus(err); status != 0 {
+			return operations.NewPatchUserUserIDBlockDefault(status).WithPayload(endpointError(status, err))
+		}
+
 		return operations.NewPatchUserUserIDBlockForbidden()
 	}
 
@@ -438,24 +493,226 @@ func BlockUserUserID(db *storage.BrigadeStorage, params operations.PatchUserUser
 
 // UnblockUserUserID - unblock user by UserID.
 func UnblockUserUserID(db *storage.BrigadeStorage, params operations.PatchUserUserIDUnblockParams, principal interface{}) middleware.Responder {
-	err := db.UnblockUser(params.UserID)
+	revision, err := ifMatchRevision(params.IfMatch)
+	if err != nil {
+		return operations.NewPatchUserUserIDUnblockDefault(http.StatusBadRequest).WithPayload(invalidETagError())
+	}
+
+	err = db.UnblockUser(params.HTTPRequest.Context(), params.UserID, revision)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Unblock user: %s :%s\n", params.UserID, err)
 
+		if errors.Is(err, storage.ErrRevisionMismatch) {
+			return operations.NewPatchUserUserIDUnblockPreconditionFailed()
+		}
+
+		if status := EndpointStatus(err); status != 0 {
+			return operations.NewPatchUserUserIDUnblockDefault(status).WithPayload(endpointError(status, err))
+		}
+
 		return operations.NewPatchUserUserIDUnblockForbidden()
 	}
 
 	return operations.NewPatchUserUserIDUnblockOK()
 }
 
+// SetUserQuotaUserID - set the user monthly quota override by UserID.
+func SetUserQuotaUserID(db *storage.BrigadeStorage, params operations.PatchUserUserIDQuotaParams, principal interface{}) middleware.Responder {
+	revision, err := ifMatchRevision(params.IfMatch)
+	if err != nil {
+		return operations.NewPatchUserUserIDQuotaDefault(http.StatusBadRequest).WithPayload(invalidETagError())
+	}
+
+	limits, err := quotaLimits(params.MonthlyQuotaGB, params.MonthlyQuotaResetDay)
+	if err != nil {
+		return operations.NewPatchUserUserIDQuotaDefault(http.StatusBadRequest).WithPayload(quotaError(err))
+	}
+
+	err = db.SetUserQuota(params.UserID, limits, revision)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "User quota: %s :%s\n", params.UserID, err)
+
+		switch {
+		case errors.Is(err, storage.ErrRevisionMismatch):
+			return operations.NewPatchUserUserIDQuotaPreconditionFailed()
+		case errors.Is(err, storage.ErrUserNotFound):
+			return operations.NewPatchUserUserIDQuotaNotFound()
+		}
+
+		return operations.NewPatchUserUserIDQuotaDefault(500)
+	}
+
+	return operations.NewPatchUserUserIDQuotaOK()
+}
+
+// MaxMonthlyQuotaGB - the quota bytes fit the traffic counters.
+const MaxMonthlyQuotaGB = math.MaxUint64 >> 30
+
+// ErrQuotaTooLarge - the quota is over MaxMonthlyQuotaGB.
+var ErrQuotaTooLarge = errors.New("monthly quota is too large")
+
+// quotaLimits - the quota override from the request parameters.
+func quotaLimits(gb, resetDay *int64) (storage.UserQuotaLimits, error) {
+	var limits storage.UserQuotaLimits
+
+	if gb != nil {
+		if *gb < 0 || uint64(*gb) > MaxMonthlyQuotaGB {
+			return limits, fmt.Errorf("%w: %d GB", ErrQuotaTooLarge, *gb)
+		}
+
+		limits.Monthly = swag.Uint64(uint64(*gb) << 30)
+	}
+
+	if resetDay != nil {
+		limits.ResetDay = swag.Int(int(*resetDay))
+	}
+
+	return limits, nil
+}
+
+// quotaError - the payload of the quota out of the range, it is the client error.
+func quotaError(err error) *models.Error {
+	return &models.Error{Code: http.StatusBadRequest, Message: swag.String(err.Error())}
+}
+
+// ThrottleUserID - throttle the user by the brigadier, zero hours lifts it.
+func ThrottleUserID(db *storage.BrigadeStorage, params operations.PatchUserUserIDThrottleParams, principal interface{}) middleware.Responder {
+	revision, err := ifMatchRevision(params.IfMatch)
+	if err != nil {
+		return operations.NewPatchUserUserIDThrottleDefault(http.StatusBadRequest).WithPayload(invalidETagError())
+	}
+
+	period := time.Duration(0)
+	if params.Hours != nil {
+		period = time.Duration(*params.Hours) * time.Hour
+	}
+
+	err = db.ThrottleUser(params.HTTPRequest.Context(), params.UserID, period, revision)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "User throttle: %s :%s\n", params.UserID, err)
+
+		switch {
+		case errors.Is(err, storage.ErrRevisionMismatch):
+			return operations.NewPatchUserUserIDThrottlePreconditionFailed()
+		case errors.Is(err, storage.ErrUserNotFound):
+			return operations.NewPatchUserUserIDThrottleNotFound()
+		}
+
+		if status := EndpointStatus(err); status != 0 {
+			return operations.NewPatchUserUserIDThrottleDefault(status).WithPayload(endpointError(status, err))
+		}
+
+		return operations.NewPatchUserUserIDThrottleDefault(500)
+	}
+
+	return operations.NewPatchUserUserIDThrottleOK()
+}
+
+// GetUserEvents - user event log by UserID.
+func GetUserEvents(db *storage.BrigadeStorage, params operations.GetUserUserIDEventsParams, principal interface{}) middleware.Responder {
+	events, err := db.GetUserEvents(params.UserID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "User events: %s :%s\n", params.UserID, err)
+
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return operations.NewGetUserUserIDEventsNotFound()
+		}
+
+		return operations.NewGetUserUserIDEventsDefault(500)
+	}
+
+	apiEvents := make([]*models.UserEvent, len(events))
+	for i, event := range events {
+		apiEvents[i] = &models.UserEvent{
+			Kind:   swag.String(event.Kind),
+			Time:   conv.DateTime(strfmt.DateTime(event.Time)),
+			Detail: event.Detail,
+		}
+	}
+
+	return operations.NewGetUserUserIDEventsOK().WithPayload(apiEvents)
+}
+
+// DefaultTrafficDays - the traffic history days if not requested.
+const DefaultTrafficDays = 30
+
+// GetUserTraffic - user daily traffic by UserID.
+func GetUserTraffic(db *storage.BrigadeStorage, params operations.GetUserUserIDTrafficParams, principal interface{}) middleware.Responder {
+	days, err := db.GetUserTraffic(params.UserID, trafficDays(params.Days))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "User traffic: %s :%s\n", params.UserID, err)
+
+		if errors.Is(err, storage.ErrUserNotFound) {
+			return operations.NewGetUserUserIDTrafficNotFound()
+		}
+
+		return operations.NewGetUserUserIDTrafficDefault(500)
+	}
+
+	return operations.NewGetUserUserIDTrafficOK().WithPayload(apiTrafficDays(days))
+}
+
+// GetUsersStatsTraffic - brigade daily traffic.
+func GetUsersStatsTraffic(db *storage.BrigadeStorage, params operations.GetUsersStatsTrafficParams, principal interface{}) middleware.Responder {
+	days, err := db.GetBrigadeTraffic(trafficDays(params.Days))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Brigade traffic: %s\n", err)
+
+		return operations.NewGetUsersStatsTrafficDefault(500)
+	}
+
+	return operations.NewGetUsersStatsTrafficOK().WithPayload(apiTrafficDays(days))
+}
+
+func trafficDays(days *int64) int {
+	if days == nil {
+		return DefaultTrafficDays
+	}
+
+	return int(*days)
+}
+
+func apiTrafficDays(days []storage.DailyTraffic) []*models.TrafficDay {
+	apiDays := make([]*models.TrafficDay, len(days))
+	for i, day := range days {
+		apiDays[i] = &models.TrafficDay{
+			Date:        conv.Date(strfmt.Date(day.Day)),
+			TotalRx:     swag.Int64(int64(day.Total.Rx)),
+			TotalTx:     swag.Int64(int64(day.Total.Tx)),
+			WireguardRx: int64(day.Wg.Rx),
+			WireguardTx: int64(day.Wg.Tx),
+			IPSecRx:     int64(day.IPSec.Rx),
+			IPSecTx:     int64(day.IPSec.Tx),
+			OpenVPNRx:   int64(day.Ovc.Rx),
+			OpenVPNTx:   int64(day.Ovc.Tx),
+			OutlineRx:   int64(day.Outline.Rx),
+			OutlineTx:   int64(day.Outline.Tx),
+			Proto0Rx:    int64(day.Proto0.Rx),
+			Proto0Tx:    int64(day.Proto0.Tx),
+		}
+	}
+
+	return apiDays
+}
+
 func GetUsersStats(db *storage.BrigadeStorage, params operations.GetUsersStatsParams, principal interface{}) middleware.Responder {
-	storageUsersStats, total, free, err := db.GetUsersStats()
+	stats, err := usersStats(db)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Stats error: %s\n", err)
 
 		return operations.NewGetUsersStatsDefault(500)
 	}
 
+	return operations.NewGetUsersStatsOK().WithPayload(stats)
+}
+
+// usersStats - the brigade monthly stats.
+func usersStats(db *storage.BrigadeStorage) (*models.Stats, error) {
+	storageUsersStats, total, free, err := db.GetUsersStats()
+	if err != nil {
+		return nil, fmt.Errorf("db: %w", err)
+	}
+
 	stats := &models.Stats{
 		TotalSlots: swag.Int64(int64(total)),
 		FreeSlots:  swag.Int64(int64(free)),
@@ -482,13 +739,13 @@ func GetUsersStats(db *storage.BrigadeStorage, params operations.GetUsersStatsPa
 		prevMonth = monthNum
 	}
 
-	return operations.NewGetUsersStatsOK().WithPayload(stats)
+	return stats, nil
 }
 
 // GetUsers - .
 func GetUsers(db *storage.BrigadeStorage, params operations.GetUserParams, principal interface{}) middleware.Responder {
 	// fmt.Fprintf(os.Stderr, "****************** GetUsers(db *storage.BrigadeStorage\n")
-	storageUsers, err := db.ListUsers()
+	storageUsers, revision, err := db.ListUsers()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "List error: %s\n", err)
 
@@ -533,6 +790,14 @@ func GetUsers(db *storage.BrigadeStorage, params operations.GetUserParams, princ
 		x := float32(float64(math.Round((float64(user.Quotas.LimitMonthlyRemaining/1024/1024)/1024)*100)) / 100)
 		apiUsers[i].MonthlyQuotaRemainingGB = &x
 
+		if user.Quotas.LimitMonthly > 0 {
+			apiUsers[i].MonthlyQuotaGB = int64(user.Quotas.LimitMonthly / 1024 / 1024 / 1024)
+		}
+
+		if !user.Quotas.LimitMonthlyResetOn.IsZero() {
+			apiUsers[i].MonthlyQuotaResetOn = conv.DateTime(strfmt.DateTime(user.Quotas.LimitMonthlyResetOn))
+		}
+
 		status := UserStatusOK
 
 		switch {
@@ -549,7 +814,7 @@ func GetUsers(db *storage.BrigadeStorage, params operations.GetUserParams, princ
 		apiUsers[i].Status = &status
 	}
 
-	return operations.NewGetUserOK().WithPayload(apiUsers)
+	return operations.NewGetUserOK().WithETag(revisionETag(revision)).WithPayload(apiUsers)
 }
 
 func GenUserCloakKeys(routerPublicKey, shufflerPublicKey *[naclkey.NaclBoxKeyLength]byte) (string, string, string, error) {
@@ -20,9 +20,7 @@ var (
 	ErrMissingScopes = errors.New("missing scopes")
 )
 
-func checkTimeLimits(notBefore, expiresAt *jwt.NumericDate) error {
-	now := time.Now().UTC()
-
+func checkTimeLimits(now time.Time, notBefore, expiresAt *jwt.NumericDate) error {
 	if notBefore != nil &&
 		!notBefore.Time.IsZero() &&
 		notBefore.Time.After(now) {
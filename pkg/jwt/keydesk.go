@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/kdlib"
 )
 
 type KeydeskTokenOptions struct {
@@ -26,6 +27,9 @@ type KeydeskTokenOptions struct {
 	VipURL string
 
 	SigningMethod jwt.SigningMethod
+
+	// if Clock is nil, the system clock is used
+	Clock kdlib.Clock
 }
 
 type KeydeskTokenIssuer struct {
@@ -62,7 +66,7 @@ func (i KeydeskTokenIssuer) IsNil() bool {
 }
 
 func (i KeydeskTokenIssuer) CreateToken(ttl time.Duration, vip bool) KeydeskTokenClaims {
-	now := time.Now()
+	now := kdlib.Now(i.options.Clock)
 	return KeydeskTokenClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    i.options.Issuer,
@@ -116,6 +120,7 @@ func (a KeydeskTokenAuthorizer) Validate(tokenStr string) (KeydeskTokenClaims, e
 
 			return a.key, nil
 		},
+		jwt.WithTimeFunc(func() time.Time { return kdlib.Now(a.options.Clock) }),
 	)
 	if err != nil {
 		return KeydeskTokenClaims{}, fmt.Errorf("%w: %w", ErrTokenInvalid, err)
@@ -129,7 +134,7 @@ func (a KeydeskTokenAuthorizer) Validate(tokenStr string) (KeydeskTokenClaims, e
 }
 
 func (a KeydeskTokenAuthorizer) Authorize(claims KeydeskTokenClaims, externalIP string, vip bool) error {
-	if err := checkTimeLimits(claims.NotBefore, claims.ExpiresAt); err != nil {
+	if err := checkTimeLimits(kdlib.Now(a.options.Clock).UTC(), claims.NotBefore, claims.ExpiresAt); err != nil {
 		return err
 	}
 
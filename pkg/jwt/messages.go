@@ -8,6 +8,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/vpngen/keydesk/kdlib"
 )
 
 type MessagesJwtOptions struct {
@@ -19,6 +20,9 @@ type MessagesJwtOptions struct {
 	Audience []string
 
 	SigningMethod jwt.SigningMethod
+
+	// if Clock is nil, the system clock is used
+	Clock kdlib.Clock
 }
 
 type MessagesJwtIssuer struct {
@@ -41,7 +45,7 @@ func (i MessagesJwtIssuer) IsNil() bool {
 }
 
 func (i MessagesJwtIssuer) CreateToken(ttl time.Duration, scopes ...string) MessagesJwtClaims {
-	now := time.Now()
+	now := kdlib.Now(i.options.Clock)
 	return MessagesJwtClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    i.options.Issuer,
@@ -86,6 +90,7 @@ func (a MessagesJwtAuthorizer) Validate(tokenStr string) (MessagesJwtClaims, err
 
 			return a.key, nil
 		},
+		jwt.WithTimeFunc(func() time.Time { return kdlib.Now(a.options.Clock) }),
 	)
 	if err != nil {
 		return MessagesJwtClaims{}, fmt.Errorf("%w: %w", ErrTokenInvalid, err)
@@ -99,7 +104,7 @@ func (a MessagesJwtAuthorizer) Validate(tokenStr string) (MessagesJwtClaims, err
 }
 
 func (a MessagesJwtAuthorizer) Authorize(claims MessagesJwtClaims, scopes ...string) error {
-	if err := checkTimeLimits(claims.NotBefore, claims.ExpiresAt); err != nil {
+	if err := checkTimeLimits(kdlib.Now(a.options.Clock).UTC(), claims.NotBefore, claims.ExpiresAt); err != nil {
 		return err
 	}
 
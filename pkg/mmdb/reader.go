@@ -0,0 +1,429 @@
+// Package mmdb - the MaxMind DB format reader, see https://maxmind.github.io/MaxMind-DB/
+package mmdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net/netip"
+	"os"
+)
+
+var (
+	// ErrInvalidDatabase - the file is not a MaxMind DB.
+	ErrInvalidDatabase = errors.New("invalid database")
+	// ErrIPv6Lookup - the IPv6 address lookup in the IPv4 only database.
+	ErrIPv6Lookup = errors.New("ipv6 lookup in ipv4 database")
+)
+
+const (
+	metadataMaxSize = 128 * 1024
+	dataSeparator   = 16
+	maxDepth        = 512 // the nested maps, arrays and pointers, as in libmaxminddb
+)
+
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// data types, see the spec.
+const (
+	typeExtended = iota
+	typePointer
+	typeString
+	typeDouble
+	typeBytes
+	typeUint16
+	typeUint32
+	typeMap
+	typeInt32
+	typeUint64
+	typeUint128
+	typeArray
+	typeContainer
+	typeEndMarker
+	typeBool
+	typeFloat
+)
+
+// Metadata - the database description.
+type Metadata struct {
+	NodeCount    uint
+	RecordSize   uint
+	IPVersion    uint
+	DatabaseType string
+	BuildEpoch   uint64
+}
+
+// Reader - the database in memory, safe for the concurrent lookups.
+type Reader struct {
+	Metadata Metadata
+
+	tree      []byte
+	data      []byte
+	ipv4Start uint
+}
+
+// Open - read the database file.
+func Open(filename string) (*Reader, error) {
+	buf, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	return FromBytes(buf)
+}
+
+// FromBytes - the database from the file content.
+func FromBytes(buf []byte) (*Reader, error) {
+	from := max(0, len(buf)-metadataMaxSize)
+
+	idx := bytes.LastIndex(buf[from:], metadataMarker)
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: no metadata", ErrInvalidDatabase)
+	}
+
+	meta := buf[from+idx+len(metadataMarker):]
+
+	v, _, err := decoder(meta).decode(0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: metadata: %w", ErrInvalidDatabase, err)
+	}
+
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", ErrInvalidDatabase)
+	}
+
+	r := &Reader{
+		Metadata: Metadata{
+			NodeCount:    uint(toUint(m["node_count"])),
+			RecordSize:   uint(toUint(m["record_size"])),
+			IPVersion:    uint(toUint(m["ip_version"])),
+			BuildEpoch:   toUint(m["build_epoch"]),
+			DatabaseType: fmt.Sprint(m["database_type"]),
+		},
+	}
+
+	switch r.Metadata.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: record size %d", ErrInvalidDatabase, r.Metadata.RecordSize)
+	}
+
+	if r.Metadata.IPVersion != 4 && r.Metadata.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: ip version %d", ErrInvalidDatabase, r.Metadata.IPVersion)
+	}
+
+	// the node is two records, checked before the multiplication can wrap.
+	if r.Metadata.NodeCount > uint(from+idx)/(r.Metadata.RecordSize/4) {
+		return nil, fmt.Errorf("%w: node count %d", ErrInvalidDatabase, r.Metadata.NodeCount)
+	}
+
+	treeSize := r.Metadata.NodeCount * r.Metadata.RecordSize / 4
+	if treeSize+dataSeparator > uint(from+idx) {
+		return nil, fmt.Errorf("%w: search tree size %d", ErrInvalidDatabase, treeSize)
+	}
+
+	r.tree = buf[:treeSize]
+	r.data = buf[treeSize+dataSeparator : from+idx]
+
+	// the IPv4 addresses are ::a.b.c.d in the IPv6 tree.
+	if r.Metadata.IPVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < r.Metadata.NodeCount; i++ {
+			if r.ipv4Start, ok = r.record(r.ipv4Start, 0); !ok {
+				return nil, fmt.Errorf("%w: search tree node %d", ErrInvalidDatabase, i)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// Lookup - the record of the network with the address, nil if there is no one.
+func (r *Reader) Lookup(addr netip.Addr) (any, error) {
+	if !addr.IsValid() {
+		return nil, nil
+	}
+
+	addr = addr.Unmap()
+
+	node, bits := uint(0), addr.BitLen()
+
+	switch {
+	case addr.Is4() && r.Metadata.IPVersion == 6:
+		node = r.ipv4Start
+	case addr.Is6() && r.Metadata.IPVersion == 4:
+		return nil, ErrIPv6Lookup
+	}
+
+	ip := addr.AsSlice()
+	for i := 0; i < bits && node < r.Metadata.NodeCount; i++ {
+		next, ok := r.record(node, uint(ip[i/8]>>(7-i%8))&1)
+		if !ok {
+			return nil, fmt.Errorf("%w: search tree node %d", ErrInvalidDatabase, node)
+		}
+
+		node = next
+	}
+
+	switch {
+	case node == r.Metadata.NodeCount:
+		return nil, nil
+	case node < r.Metadata.NodeCount:
+		return nil, fmt.Errorf("%w: search tree is too deep", ErrInvalidDatabase)
+	}
+
+	offset := node - r.Metadata.NodeCount - dataSeparator
+	if offset >= uint(len(r.data)) {
+		return nil, fmt.Errorf("%w: data pointer %d", ErrInvalidDatabase, offset)
+	}
+
+	v, _, err := decoder(r.data).decode(offset, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: data: %w", ErrInvalidDatabase, err)
+	}
+
+	return v, nil
+}
+
+// record - the left (0) or right (1) node record, false if the node is out of the tree.
+func (r *Reader) record(node, bit uint) (uint, bool) {
+	size := r.Metadata.RecordSize / 4
+	if node >= uint(len(r.tree))/size {
+		return 0, false
+	}
+
+	switch r.Metadata.RecordSize {
+	case 24:
+		b := r.tree[node*6+bit*3:]
+
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]), true
+	case 28:
+		b := r.tree[node*7:]
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]), true
+		}
+
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6]), true
+	default:
+		return uint(binary.BigEndian.Uint32(r.tree[node*8+bit*4:])), true
+	}
+}
+
+type decoder []byte
+
+var (
+	errTruncated = errors.New("truncated")
+	errTooDeep   = errors.New("data is too deep")
+)
+
+// decode - the value at the offset and the offset after it.
+// The depth is the nesting of the value, the pointer loops stop at maxDepth.
+func (d decoder) decode(offset, depth uint) (any, uint, error) {
+	if depth > maxDepth {
+		return nil, 0, errTooDeep
+	}
+
+	typ, size, offset, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typ == typePointer {
+		v, _, err := d.decodeValue(size, depth+1)
+		if err != nil {
+			return nil, 0, fmt.Errorf("pointer %d: %w", size, err)
+		}
+
+		return v, offset, nil
+	}
+
+	return d.decodeType(typ, size, offset, depth)
+}
+
+// decodeValue - the pointed value, the pointer to a pointer is invalid.
+func (d decoder) decodeValue(offset, depth uint) (any, uint, error) {
+	typ, size, next, err := d.control(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typ == typePointer {
+		return nil, 0, errors.New("pointer to pointer")
+	}
+
+	return d.decodeType(typ, size, next, depth)
+}
+
+// control - the type and the size of the field, for the pointers the size is the pointed offset.
+func (d decoder) control(offset uint) (int, uint, uint, error) {
+	if offset >= uint(len(d)) {
+		return 0, 0, 0, errTruncated
+	}
+
+	ctrl := d[offset]
+	offset++
+
+	typ := int(ctrl >> 5)
+	if typ == typePointer {
+		n := uint(ctrl>>3&0x3) + 1
+		if offset+n > uint(len(d)) {
+			return 0, 0, 0, errTruncated
+		}
+
+		p := uint(ctrl & 0x7)
+		if n == 4 {
+			p = 0
+		}
+
+		for _, b := range d[offset : offset+n] {
+			p = p<<8 | uint(b)
+		}
+
+		switch n {
+		case 2:
+			p += 2048
+		case 3:
+			p += 526336
+		}
+
+		return typ, p, offset + n, nil
+	}
+
+	if typ == typeExtended {
+		if offset >= uint(len(d)) {
+			return 0, 0, 0, errTruncated
+		}
+
+		typ = 7 + int(d[offset])
+		offset++
+	}
+
+	size := uint(ctrl & 0x1F)
+	if size >= 29 {
+		n := size - 28
+		if offset+n > uint(len(d)) {
+			return 0, 0, 0, errTruncated
+		}
+
+		v := uint(0)
+		for _, b := range d[offset : offset+n] {
+			v = v<<8 | uint(b)
+		}
+
+		size = []uint{29, 285, 65821}[n-1] + v
+		offset += n
+	}
+
+	return typ, size, offset, nil
+}
+
+// decodeType - the value of the type, the containers are preallocated
+// for the elements the rest of the data can hold, one byte at least each.
+func (d decoder) decodeType(typ int, size, offset, depth uint) (any, uint, error) {
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, min(size, d.remaining(offset)))
+
+		for range size {
+			k, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, fmt.Errorf("map key: %w", err)
+			}
+
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("map key type %T", k)
+			}
+
+			v, next, err := d.decode(next, depth+1)
+			if err != nil {
+				return nil, 0, fmt.Errorf("map %q: %w", key, err)
+			}
+
+			m[key], offset = v, next
+		}
+
+		return m, offset, nil
+	case typeArray:
+		a := make([]any, 0, min(size, d.remaining(offset)))
+
+		for range size {
+			v, next, err := d.decode(offset, depth+1)
+			if err != nil {
+				return nil, 0, fmt.Errorf("array: %w", err)
+			}
+
+			a, offset = append(a, v), next
+		}
+
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	if offset+size > uint(len(d)) {
+		return nil, 0, errTruncated
+	}
+
+	b, next := d[offset:offset+size], offset+size
+
+	switch typ {
+	case typeString:
+		return string(b), next, nil
+	case typeBytes, typeUint128:
+		return bytes.Clone(b), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("double size %d", size)
+		}
+
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("float size %d", size)
+		}
+
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("uint size %d", size)
+		}
+
+		v := uint64(0)
+		for _, c := range b {
+			v = v<<8 | uint64(c)
+		}
+
+		return v, next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("int32 size %d", size)
+		}
+
+		v := uint32(0)
+		for _, c := range b {
+			v = v<<8 | uint32(c)
+		}
+
+		return int32(v), next, nil
+	default:
+		return nil, 0, fmt.Errorf("data type %d", typ)
+	}
+}
+
+// remaining - the data bytes after the offset.
+func (d decoder) remaining(offset uint) uint {
+	if offset >= uint(len(d)) {
+		return 0
+	}
+
+	return uint(len(d)) - offset
+}
+
+func toUint(v any) uint64 {
+	u, _ := v.(uint64)
+
+	return u
+}
@@ -0,0 +1,352 @@
+package mmdb
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"net/netip"
+	"sort"
+	"testing"
+)
+
+// encode - the data section encoding of the test values.
+func encode(buf *bytes.Buffer, v any) {
+	ctrl := func(typ int, size int) {
+		var ext []byte
+		if typ > 7 {
+			ext, typ = []byte{byte(typ - 7)}, typeExtended
+		}
+
+		switch {
+		case size < 29:
+			buf.WriteByte(byte(typ<<5 | size))
+		case size < 285:
+			buf.WriteByte(byte(typ<<5 | 29))
+			defer buf.WriteByte(byte(size - 29))
+		default:
+			buf.WriteByte(byte(typ<<5 | 30))
+			defer buf.Write([]byte{byte((size - 285) >> 8), byte(size - 285)})
+		}
+
+		buf.Write(ext)
+	}
+
+	switch v := v.(type) {
+	case string:
+		ctrl(typeString, len(v))
+		buf.WriteString(v)
+	case uint64:
+		b := binary.BigEndian.AppendUint64(nil, v)
+		b = bytes.TrimLeft(b, "\x00")
+		ctrl(typeUint64, len(b))
+		buf.Write(b)
+	case bool:
+		n := 0
+		if v {
+			n = 1
+		}
+
+		ctrl(typeBool, n)
+	case []any:
+		ctrl(typeArray, len(v))
+
+		for _, e := range v {
+			encode(buf, e)
+		}
+	case map[string]any:
+		ctrl(typeMap, len(v))
+
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			encode(buf, k)
+			encode(buf, v[k])
+		}
+	}
+}
+
+// build - the database with the networks records.
+func build(t testing.TB, ipVersion, recordSize int, networks map[netip.Prefix]any) []byte {
+	t.Helper()
+
+	type node [2]int // >= 0 - the node, -1 - empty, < -1 - the data -(index+2)
+
+	nodes := []node{{-1, -1}}
+	records := []any{}
+
+	for prefix, v := range networks {
+		addr, bits := prefix.Addr(), prefix.Bits()
+		if addr.Is4() && ipVersion == 6 {
+			// ::a.b.c.d, not the mapped one.
+			b := addr.As16()
+			b[10], b[11] = 0, 0
+			addr, bits = netip.AddrFrom16(b), bits+96
+		}
+
+		ip := addr.AsSlice()
+		n := 0
+
+		for i := range bits {
+			bit := int(ip[i/8]>>(7-i%8)) & 1
+			if i == bits-1 {
+				nodes[n][bit] = -(len(records) + 2)
+				records = append(records, v)
+
+				break
+			}
+
+			if nodes[n][bit] < 0 {
+				nodes = append(nodes, node{-1, -1})
+				nodes[n][bit] = len(nodes) - 1
+			}
+
+			n = nodes[n][bit]
+		}
+	}
+
+	data := &bytes.Buffer{}
+	offsets := make([]int, len(records))
+
+	for i, v := range records {
+		offsets[i] = data.Len()
+		encode(data, v)
+	}
+
+	value := func(r int) uint32 {
+		switch {
+		case r == -1:
+			return uint32(len(nodes))
+		case r < -1:
+			return uint32(len(nodes) + dataSeparator + offsets[-r-2])
+		default:
+			return uint32(r)
+		}
+	}
+
+	out := &bytes.Buffer{}
+
+	for _, n := range nodes {
+		l, r := value(n[0]), value(n[1])
+
+		switch recordSize {
+		case 24:
+			out.Write([]byte{byte(l >> 16), byte(l >> 8), byte(l), byte(r >> 16), byte(r >> 8), byte(r)})
+		case 28:
+			out.Write([]byte{byte(l >> 16), byte(l >> 8), byte(l), byte(l>>20)&0xF0 | byte(r>>24)&0x0F, byte(r >> 16), byte(r >> 8), byte(r)})
+		default:
+			out.Write(binary.BigEndian.AppendUint32(binary.BigEndian.AppendUint32(nil, l), r))
+		}
+	}
+
+	out.Write(make([]byte, dataSeparator))
+	out.Write(data.Bytes())
+	out.Write(metadataMarker)
+
+	encode(out, map[string]any{
+		"node_count":    uint64(len(nodes)),
+		"record_size":   uint64(recordSize),
+		"ip_version":    uint64(ipVersion),
+		"database_type": "Test-DB",
+	})
+
+	return out.Bytes()
+}
+
+func TestLookup(t *testing.T) {
+	ru := map[string]any{"country": map[string]any{"iso_code": "RU"}, "autonomous_system_number": uint64(8359)}
+	de := map[string]any{"country": map[string]any{"iso_code": "DE", "names": []any{"Germany", "Deutschland"}}, "is_eu": true}
+	v6 := map[string]any{"country": map[string]any{"iso_code": "NL"}}
+
+	for _, tc := range []struct {
+		ipVersion, recordSize int
+	}{
+		{4, 24}, {4, 28}, {4, 32}, {6, 24}, {6, 28}, {6, 32},
+	} {
+		networks := map[netip.Prefix]any{
+			netip.MustParsePrefix("10.0.0.0/8"):      ru,
+			netip.MustParsePrefix("192.168.1.0/24"):  de,
+			netip.MustParsePrefix("192.168.2.16/28"): ru,
+		}
+
+		if tc.ipVersion == 6 {
+			networks[netip.MustParsePrefix("2001:db8::/32")] = v6
+		}
+
+		r, err := FromBytes(build(t, tc.ipVersion, tc.recordSize, networks))
+		if err != nil {
+			t.Fatalf("v%d/%d: open: %s", tc.ipVersion, tc.recordSize, err)
+		}
+
+		if r.Metadata.DatabaseType != "Test-DB" || r.Metadata.RecordSize != uint(tc.recordSize) {
+			t.Errorf("v%d/%d: metadata %+v", tc.ipVersion, tc.recordSize, r.Metadata)
+		}
+
+		for addr, want := range map[string]string{
+			"10.1.2.3":            "RU",
+			"192.168.1.200":       "DE",
+			"::ffff:192.168.1.1":  "DE",
+			"192.168.2.17":        "RU",
+			"192.168.2.32":        "",
+			"172.16.0.1":          "",
+			"2001:db8::1":         "NL",
+			"2001:db9::1":         "",
+			"::ffff:192.168.2.31": "RU",
+		} {
+			a := netip.MustParseAddr(addr)
+
+			v, err := r.Lookup(a)
+			if a.Unmap().Is6() && tc.ipVersion == 4 {
+				if !errors.Is(err, ErrIPv6Lookup) {
+					t.Errorf("v%d/%d: %s: %v", tc.ipVersion, tc.recordSize, addr, err)
+				}
+
+				continue
+			}
+
+			if err != nil {
+				t.Fatalf("v%d/%d: %s: %s", tc.ipVersion, tc.recordSize, addr, err)
+			}
+
+			got := ""
+			if m, ok := v.(map[string]any); ok {
+				got, _ = m["country"].(map[string]any)["iso_code"].(string)
+			}
+
+			if got != want {
+				t.Errorf("v%d/%d: %s: %q, want %q", tc.ipVersion, tc.recordSize, addr, got, want)
+			}
+		}
+	}
+}
+
+func TestLookupValues(t *testing.T) {
+	r, err := FromBytes(build(t, 6, 24, map[netip.Prefix]any{
+		netip.MustParsePrefix("10.0.0.0/8"): map[string]any{
+			"autonomous_system_number":       uint64(8359),
+			"autonomous_system_organization": string(bytes.Repeat([]byte("x"), 300)),
+			"names":                          []any{"a", "b"},
+			"is_eu":                          true,
+		},
+	}))
+	if err != nil {
+		t.Fatalf("open: %s", err)
+	}
+
+	v, err := r.Lookup(netip.MustParseAddr("10.0.0.1"))
+	if err != nil {
+		t.Fatalf("lookup: %s", err)
+	}
+
+	m := v.(map[string]any)
+	if m["autonomous_system_number"] != uint64(8359) || len(m["autonomous_system_organization"].(string)) != 300 || m["is_eu"] != true || len(m["names"].([]any)) != 2 {
+		t.Errorf("values: %v", m)
+	}
+
+	if _, err := FromBytes([]byte("not a database")); !errors.Is(err, ErrInvalidDatabase) {
+		t.Errorf("garbage: %v", err)
+	}
+}
+
+func TestPointer(t *testing.T) {
+	// the map at 5 with the "test" key by the pointer to 0.
+	d := decoder{
+		0x44, 't', 'e', 's', 't',
+		0xE1, 0x20, 0x00,
+		0x47, 'p', 'o', 'i', 'n', 't', 'e', 'd',
+	}
+
+	v, next, err := d.decode(5, 0)
+	if err != nil {
+		t.Fatalf("decode: %s", err)
+	}
+
+	if m := v.(map[string]any); m["test"] != "pointed" || next != uint(len(d)) {
+		t.Errorf("pointer: %v, next %d", m, next)
+	}
+}
+
+func TestMalformedData(t *testing.T) {
+	// the map with the value by the pointer to the map itself.
+	loop := decoder{0xE1, 0x41, 'a', 0x20, 0x00}
+
+	if _, _, err := loop.decode(0, 0); !errors.Is(err, errTooDeep) {
+		t.Errorf("pointer loop: %v", err)
+	}
+
+	// the map of the max size in a few bytes.
+	huge := decoder{0xFF, 0xFF, 0xFF, 0xFF, 0x41, 'a'}
+
+	if _, _, err := huge.decode(0, 0); !errors.Is(err, errTruncated) {
+		t.Errorf("huge map: %v", err)
+	}
+}
+
+func TestMalformedTree(t *testing.T) {
+	// the node count the tree size wraps with.
+	buf := &bytes.Buffer{}
+	buf.Write(make([]byte, dataSeparator))
+	buf.Write(metadataMarker)
+
+	encode(buf, map[string]any{
+		"node_count":    uint64(1 << 62),
+		"record_size":   uint64(24),
+		"ip_version":    uint64(6),
+		"database_type": "Test-DB",
+	})
+
+	if _, err := FromBytes(buf.Bytes()); !errors.Is(err, ErrInvalidDatabase) {
+		t.Errorf("node count overflow: %v", err)
+	}
+
+	// the node out of the tree.
+	r := &Reader{Metadata: Metadata{NodeCount: 2, RecordSize: 28}, tree: make([]byte, 7)}
+
+	if _, ok := r.record(1, 1); ok {
+		t.Errorf("node out of the tree")
+	}
+
+	if _, ok := r.record(0, 1); !ok {
+		t.Errorf("node in the tree")
+	}
+}
+
+func FuzzFromBytes(f *testing.F) {
+	for _, recordSize := range []int{24, 28, 32} {
+		f.Add(build(f, 6, recordSize, map[netip.Prefix]any{
+			netip.MustParsePrefix("10.0.0.0/8"):     map[string]any{"country": map[string]any{"iso_code": "RU"}, "names": []any{"a", true}},
+			netip.MustParsePrefix("2001:db8::/32"): map[string]any{"autonomous_system_number": uint64(8359)},
+		}))
+	}
+
+	addrs := []netip.Addr{netip.MustParseAddr("10.1.2.3"), netip.MustParseAddr("2001:db8::1"), netip.MustParseAddr("192.0.2.1")}
+
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		r, err := FromBytes(buf)
+		if err != nil {
+			return
+		}
+
+		for _, addr := range addrs {
+			r.Lookup(addr)
+		}
+	})
+}
+
+func FuzzDecode(f *testing.F) {
+	buf := &bytes.Buffer{}
+	encode(buf, map[string]any{"country": map[string]any{"iso_code": "RU", "names": []any{"a", "b"}}, "n": uint64(8359), "eu": true})
+
+	f.Add(buf.Bytes())
+	f.Add([]byte{0x44, 't', 'e', 's', 't', 0xE1, 0x20, 0x00, 0x47, 'p', 'o', 'i', 'n', 't', 'e', 'd'})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for offset := range min(len(data), 8) {
+			decoder(data).decode(uint(offset), 0)
+		}
+	})
+}
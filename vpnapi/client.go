@@ -0,0 +1,196 @@
+package vpnapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// EndpointClient - the endpoint API calls.
+type EndpointClient interface {
+	// WgAdd - create the brigade interface.
+	WgAdd(ctx context.Context, req WgAddRequest) error
+	// WgDel - destroy the brigade interface.
+	WgDel(ctx context.Context, req WgDelRequest) error
+	// PeerAdd - add the user peer.
+	PeerAdd(ctx context.Context, req PeerAddRequest) (*PeerAddResponse, error)
+	// PeerDel - delete the user peer.
+	PeerDel(ctx context.Context, req PeerRequest) error
+	// ThrottleOn - limit the user peer speed.
+	ThrottleOn(ctx context.Context, req PeerRequest) error
+	// ThrottleOff - remove the user peer speed limit.
+	ThrottleOff(ctx context.Context, req PeerRequest) error
+	// PeerList - the brigade interface peers.
+	PeerList(ctx context.Context, req PeerListRequest) (*PeerListResponse, error)
+	// Stat - the brigade interface statistics.
+	Stat(ctx context.Context, req StatRequest) (*WGStatsIn, error)
+}
+
+// Timeouts - the endpoint API calls timeouts, zero means CallTimeout.
+type Timeouts struct {
+	WgAdd    time.Duration
+	WgDel    time.Duration
+	PeerAdd  time.Duration
+	PeerDel  time.Duration
+	Throttle time.Duration
+	PeerList time.Duration
+	Stat     time.Duration
+}
+
+// Client - the endpoint API HTTP client.
+// Without the actual address it is a stand-in: the queries are logged,
+// the responses are empty.
+type Client struct {
+	Timeouts Timeouts
+	Logger   *log.Logger // nil - no debug log
+
+	actualAddrPort     netip.AddrPort
+	calculatedAddrPort netip.AddrPort
+	http               *http.Client
+}
+
+var _ EndpointClient = (*Client)(nil)
+
+// NewClient - the client of the actual endpoint API address,
+// the calculated one is for the stand-in log only.
+func NewClient(actualAddrPort, calculatedAddrPort netip.AddrPort) *Client {
+	return &Client{
+		actualAddrPort:     actualAddrPort,
+		calculatedAddrPort: calculatedAddrPort,
+		http: &http.Client{
+			Transport: &http.Transport{
+				DialContext: (&net.Dialer{Timeout: ConnTimeout}).DialContext,
+			},
+		},
+	}
+}
+
+// IsStandIn - the client has no actual endpoint address.
+func (c *Client) IsStandIn() bool {
+	return !c.actualAddrPort.Addr().IsValid()
+}
+
+func callTimeout(d time.Duration) time.Duration {
+	if d <= 0 {
+		return CallTimeout
+	}
+
+	return d
+}
+
+// query - the endpoint API query, the call name goes first.
+type query []string
+
+func newQuery(call, value string) *query {
+	q := &query{}
+	q.add(call, value)
+
+	return q
+}
+
+func (q *query) add(key, value string) {
+	*q = append(*q, key+"="+url.QueryEscape(value))
+}
+
+func (q *query) String() string {
+	return strings.Join(*q, "&")
+}
+
+// wgKeyLen - the wireguard key length.
+const wgKeyLen = 32
+
+// checkKeys - the wireguard keys are 32 bytes.
+func checkKeys(keys ...[]byte) error {
+	for _, key := range keys {
+		if len(key) != wgKeyLen {
+			return fmt.Errorf("%w: %d bytes", ErrBadKey, len(key))
+		}
+	}
+
+	return nil
+}
+
+func encodeKey(key []byte) string {
+	return base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(key)
+}
+
+func joinPrefixes(prefixes []netip.Prefix) string {
+	list := make([]string, 0, len(prefixes))
+	for _, p := range prefixes {
+		list = append(list, p.String())
+	}
+
+	return strings.Join(list, ",")
+}
+
+// call - make the API call, the body is returned along with the API error code.
+func (c *Client) call(ctx context.Context, timeout time.Duration, q *query) ([]byte, error) {
+	if c.IsStandIn() {
+		fmt.Fprintf(os.Stderr, "Test Request: %s\n", &url.URL{
+			Scheme:   "http",
+			Host:     c.calculatedAddrPort.String(),
+			RawQuery: q.String(),
+		})
+
+		return []byte("{}"), nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, callTimeout(timeout))
+	defer cancel()
+
+	apiURL := &url.URL{
+		Scheme:   "http",
+		Host:     c.actualAddrPort.String(),
+		RawQuery: q.String(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("new req: %w", err)
+	}
+
+	if c.Logger != nil {
+		c.Logger.Println("endpoint request:", apiURL)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do req: %w: %w", ErrUnavailable, err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("resp read: %w: %w", ErrUnavailable, err)
+	}
+
+	if c.Logger != nil {
+		c.Logger.Printf("endpoint response: %d %s", resp.StatusCode, body)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: resp code: %d", ErrUnavailable, resp.StatusCode)
+	}
+
+	data := &APIResponse{}
+	if err := json.Unmarshal(body, data); err != nil {
+		return nil, fmt.Errorf("resp body: %w", err)
+	}
+
+	if data.Code != "0" {
+		return body, fmt.Errorf("invalid resp code: %w", data)
+	}
+
+	return body, nil
+}
@@ -0,0 +1,138 @@
+package vpnapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func testClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	addr, err := netip.ParseAddrPort(srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatalf("addr: %s", err)
+	}
+
+	return NewClient(addr, addr)
+}
+
+// peerKey - the wireguard peer key of the calls.
+var peerKey = bytes.Repeat([]byte{1}, 32)
+
+func TestClientPeerAdd(t *testing.T) {
+	var query string
+
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Write([]byte(`{"code":"0","openvpn-client-certificate":"cert"}`))
+	})
+
+	resp, err := c.PeerAdd(context.Background(), PeerAddRequest{
+		PeerPublicKey: peerKey,
+		WgPublicKey:   []byte{4, 5, 6},
+		WgPSK:         []byte{7, 8, 9},
+		AllowedIPs:    []netip.Prefix{netip.MustParsePrefix("100.64.0.2/32"), netip.MustParsePrefix("fd00::2/128")},
+		CloakUID:      "uid+/=",
+		L2TPUsername:  "user", // no password, not sent
+	})
+	if err != nil {
+		t.Fatalf("peer add: %s", err)
+	}
+
+	if resp.OpenvpnClientCertificate != "cert" {
+		t.Errorf("response: %+v", resp)
+	}
+
+	if want := "peer_add=AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE%3D&wg-public-key=BAUG&wg-psk-key=BwgJ&allowed-ips=100.64.0.2%2F32%2Cfd00%3A%3A2%2F128&cloak-uid=uid%2B%2F%3D"; query != want {
+		t.Errorf("query:\n%s\nwant:\n%s", query, want)
+	}
+}
+
+func TestClientCodes(t *testing.T) {
+	code := "151"
+
+	c := testClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"code":"` + code + `","error":"exists"}`))
+	})
+
+	if _, err := c.PeerAdd(context.Background(), PeerAddRequest{PeerPublicKey: peerKey}); err != nil {
+		t.Errorf("existing peer: %s", err)
+	}
+
+	code = "146"
+
+	if err := c.PeerDel(context.Background(), PeerRequest{PeerPublicKey: peerKey}); !errors.Is(err, ErrDelInProgress) || !IsRetryable(err) {
+		t.Errorf("in progress: %v", err)
+	}
+
+	code = "999"
+
+	err := c.PeerDel(context.Background(), PeerRequest{PeerPublicKey: peerKey})
+
+	apiErr := &APIResponse{}
+	if !errors.As(err, &apiErr) || apiErr.Code != "999" {
+		t.Errorf("api error: %v", err)
+	}
+
+	if !errors.Is(err, ErrUnknownCode) || errors.Is(err, ErrPermanent) || !IsRetryable(err) {
+		t.Errorf("unknown code: %v", err)
+	}
+}
+
+func TestClientBadKey(t *testing.T) {
+	var calls int
+
+	c := testClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.Write([]byte(`{"code":"0"}`))
+	})
+
+	if _, err := c.PeerAdd(context.Background(), PeerAddRequest{PeerPublicKey: []byte{1}}); !errors.Is(err, ErrBadKey) || IsRetryable(err) {
+		t.Errorf("peer add: %v", err)
+	}
+
+	if err := c.ThrottleOn(context.Background(), PeerRequest{}); !errors.Is(err, ErrBadKey) {
+		t.Errorf("throttle on: %v", err)
+	}
+
+	if calls != 0 {
+		t.Errorf("calls with the bad key: %d", calls)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	c := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+
+	c.Timeouts.Stat = 50 * time.Millisecond
+
+	if _, err := c.Stat(context.Background(), StatRequest{}); !errors.Is(err, context.DeadlineExceeded) || !errors.Is(err, ErrUnavailable) || !IsRetryable(err) {
+		t.Errorf("call timeout: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := c.WgAdd(ctx, WgAddRequest{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("canceled: %v", err)
+	}
+}
+
+func TestClientStandIn(t *testing.T) {
+	c := NewClient(netip.AddrPort{}, CalcAPIAddrPort(netip.MustParseAddr("192.0.2.1")))
+
+	resp, err := c.PeerAdd(context.Background(), PeerAddRequest{PeerPublicKey: peerKey})
+	if err != nil || resp.OpenvpnClientCertificate != TestCert {
+		t.Errorf("peer add: %+v, %v", resp, err)
+	}
+}
@@ -0,0 +1,73 @@
+package vpnapi
+
+import "errors"
+
+// The endpoint API failure classes, for errors.Is.
+var (
+	// ErrRetryable - the call may pass later.
+	ErrRetryable = errors.New("retryable")
+	// ErrPermanent - the call fails the same way again.
+	ErrPermanent = errors.New("permanent")
+)
+
+// CodeError - the known endpoint API failure.
+type CodeError struct {
+	Code      string // the API result code, empty - not an API code
+	Text      string
+	Retryable bool
+}
+
+func (e *CodeError) Error() string {
+	return e.Text
+}
+
+// Is - matches the class of the failure, ErrRetryable or ErrPermanent.
+func (e *CodeError) Is(target error) bool {
+	switch target {
+	case ErrRetryable:
+		return e.Retryable
+	case ErrPermanent:
+		return !e.Retryable
+	}
+
+	return false
+}
+
+// The endpoint API result codes catalogue, the other codes are ErrUnknownCode.
+var (
+	// ErrInterfaceMissing - no brigade interface, wg_del takes it as done.
+	ErrInterfaceMissing = &CodeError{Code: "128", Text: "interface missing"}
+	// ErrDelInProgress - the brigade interface deletion is in progress.
+	ErrDelInProgress = &CodeError{Code: "146", Text: "deletion in progress", Retryable: true}
+	// ErrPeerExists - the peer is already added, peer_add takes it as done.
+	ErrPeerExists = &CodeError{Code: "151", Text: "peer already exists"}
+	// ErrUnknownCode - the code is out of the catalogue, it may be a transient one,
+	// the retries are limited by the caller.
+	ErrUnknownCode = &CodeError{Text: "unknown code", Retryable: true}
+)
+
+// ErrUnavailable - the endpoint is unreachable, timed out or responded not with 200.
+var ErrUnavailable = &CodeError{Text: "endpoint unavailable", Retryable: true}
+
+// ErrBadKey - the request key is not a wireguard key, the call is not made.
+var ErrBadKey = &CodeError{Text: "bad key"}
+
+var codeErrors = map[string]*CodeError{
+	ErrInterfaceMissing.Code: ErrInterfaceMissing,
+	ErrDelInProgress.Code:    ErrDelInProgress,
+	ErrPeerExists.Code:       ErrPeerExists,
+}
+
+// Unwrap - the catalogue error of the code.
+func (a *APIResponse) Unwrap() error {
+	if e, ok := codeErrors[a.Code]; ok {
+		return e
+	}
+
+	return ErrUnknownCode
+}
+
+// IsRetryable - the endpoint call failure may pass on a retry.
+func IsRetryable(err error) bool {
+	return errors.Is(err, ErrRetryable)
+}
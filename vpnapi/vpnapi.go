@@ -1,14 +1,8 @@
 package vpnapi
 
 import (
-	"encoding/json"
 	"fmt"
-	"io"
-	"net"
-	"net/http"
 	"net/netip"
-	"net/url"
-	"os"
 	"time"
 )
 
@@ -46,91 +40,20 @@ func (a *APIResponse) String() string {
 	return a.Error()
 }
 
-/*
-var serial uint32 = 0
-
-func nextSerial() uint32 {
-	for {
-		x := atomic.AddUint32(&serial, 1)
-		if x >= uint32(^uint16(0)) {
-			if atomic.CompareAndSwapUint32(&serial, x, 0) {
-				return 0
-			}
-
-			continue
-		}
-
-		return x
-	}
-}
-*/
-
-func getAPIRequest(_ string, actualAddrPort, calculatedAddrPort netip.AddrPort, query string, callTimeout time.Duration) ([]byte, error) {
-	/*
-		if !actualAddrPort.Addr().IsValid() || actualAddrPort.Addr().Compare(calculatedAddrPort.Addr()) != 0 || actualAddrPort.Port() != calculatedAddrPort.Port() {
-			fmt.Fprintf(os.Stderr, "API endpoint calculated: %s\n", calculatedAddrPort)
-		}
-	*/
-
-	if !actualAddrPort.Addr().IsValid() {
-		fmt.Fprintf(os.Stderr, "Test Request: %s\n", &url.URL{
-			Scheme:   "http",
-			Host:     calculatedAddrPort.String(),
-			RawQuery: query,
-		})
-
-		return []byte("{}"), nil
-	}
-
-	// fmt.Fprintf(os.Stderr, "API endpoint actual: %s\n", actualAddrPort)
-
-	apiURL := &url.URL{
-		Scheme:   "http",
-		Host:     actualAddrPort.String(),
-		RawQuery: query,
-	}
-
-	req, err := http.NewRequest(http.MethodGet, apiURL.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("new req: %w", err)
-	}
-
-	// num := nextSerial()
-	// fmt.Fprintf(os.Stderr, "Request (%s | n=%04x): %s\n", ident, num, apiURL)
-
-	c := &http.Client{
-		Transport: &http.Transport{
-			Dial: (&net.Dialer{
-				Timeout: ConnTimeout,
-			}).Dial,
-		},
-		Timeout: callTimeout,
-	}
-
-	resp, err := c.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("do req: %w", err)
-	}
-
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-
-	// fmt.Fprintf(os.Stderr, "Response (%s | n=%04x): %s\n", ident, num, body)
-
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("resp code: %w", err)
-	}
-
-	data := &APIResponse{}
-
-	err = json.Unmarshal(body, data)
-	if err != nil {
-		return nil, fmt.Errorf("resp body: %w", err)
-	}
-
-	if data.Code != "0" {
-		return body, fmt.Errorf("invalid resp code: %w", data)
-	}
-
-	return body, nil
-}
+// TestCert - the openvpn client certificate of the stand-in and the fake endpoints.
+const TestCert = `-----BEGIN CERTIFICATE-----
+MIIChjCCAeigAwIBAgIUHYRJHPNW+eqW3TkSaWhpRxqyk68wCgYIKoZIzj0EAwIw
+VDELMAkGA1UEBhMCUlUxEzARBgNVBAgMClNvbWUtU3RhdGUxITAfBgNVBAoMGElu
+dGVybmV0IFdpZGdpdHMgUHR5IEx0ZDENMAsGA1UEAwwEVGVzdDAgFw0yMzA4MTcx
+NDE0MTRaGA8yMDUxMDEwMjE0MTQxNFowVDELMAkGA1UEBhMCUlUxEzARBgNVBAgM
+ClNvbWUtU3RhdGUxITAfBgNVBAoMGEludGVybmV0IFdpZGdpdHMgUHR5IEx0ZDEN
+MAsGA1UEAwwEVGVzdDCBmzAQBgcqhkjOPQIBBgUrgQQAIwOBhgAEADrZB/oUNXuU
+kAoyC1DCoqWnp0pdJx5GuxqxAJD9uMYOS05G3PjAboesJohnoFGOld2Zh2Kuj6OJ
+ULh9hTj14eB7AZT4YX/vjA/odBS/Bu9PSjMiyrwTCms1hkMl2EvS06Hc3ElrjsuY
+YMma/Chd8G+GAX12ijNO7BMlhLjhoZm383oao1MwUTAdBgNVHQ4EFgQU3x7cM6Kd
+TEJN6KQvc0cHjAODOCwwHwYDVR0jBBgwFoAU3x7cM6KdTEJN6KQvc0cHjAODOCww
+DwYDVR0TAQH/BAUwAwEB/zAKBggqhkjOPQQDAgOBiwAwgYcCQUtlwuBJgT4gSGfH
+yax9nYcFz6DzTaXWe3CZG0oLReUTrP88CeYfevWAvO7etL8IRKr48OWWm+sARDzY
+GH/IDRigAkIBI45wN1CUGzzBjF8/faxNy6XWhcSkFZW7oCRR0MWaL6bn69naej8K
+0msNdKBh0Uyk4SK0q+4NlBMTgoimpXcNdk8=
+-----END CERTIFICATE-----`
@@ -1,13 +1,13 @@
 package vpnapi
 
 import (
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/netip"
-	"net/url"
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -50,208 +50,306 @@ type WGStatsIn struct {
 	Data      WgStatDataIn `json:"data,omitempty"`
 }
 
-// WgPeerAdd - peer_add endpoint-API call.
-func WgPeerAdd(
-	ident string,
-	actualAddrPort,
-	calculatedAddrPort netip.AddrPort,
-	wgPub, wgIfacePub,
-	wgPSK []byte,
-	localIPv4,
-	localIPv6,
-	keydeskIPv6 netip.Addr,
-	ovcCertRequest string,
-	cloakBypasUID string,
-	ipsecUsername string,
-	ipsecPassword string,
-	outlineSecret string,
-	proto0Secret string,
-) ([]byte, error) {
-	query := fmt.Sprintf("peer_add=%s&wg-public-key=%s&wg-psk-key=%s&allowed-ips=%s",
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgPub)),
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgIfacePub)),
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgPSK)),
-		url.QueryEscape(localIPv4.String()+","+localIPv6.String()),
-	)
-
-	if ovcCertRequest != "" {
-		query += fmt.Sprintf("&openvpn-client-csr=%s",
-			url.QueryEscape(ovcCertRequest),
-		)
+// WgAddRequest - wg_add call, the brigade interface.
+type WgAddRequest struct {
+	WgPrivateKey     []byte         // router encrypted
+	ExternalIP       netip.Addr     // the endpoint IPv4
+	WireguardPort    uint16         // the endpoint port
+	InternalNets     []netip.Prefix // the brigade IPv4 and IPv6 nets
+	CloakDomain      string         // empty - no cloak
+	OpenVPNCACert    string         // empty - no openvpn
+	OpenVPNCAKey     string         // router encrypted
+	L2TPPresharedKey string         // empty - no ipsec
+	OutlinePort      uint16         // 0 - no outline
+	Proto0Domain     string         // empty - no proto0
+}
+
+// WgDelRequest - wg_del call.
+type WgDelRequest struct {
+	WgPrivateKey []byte // router encrypted
+}
+
+// PeerAddRequest - peer_add call, the user peer.
+// The empty protocol fields are not sent.
+type PeerAddRequest struct {
+	PeerPublicKey []byte
+	WgPublicKey   []byte // the brigade interface public key
+	WgPSK         []byte // router encrypted
+	AllowedIPs    []netip.Prefix
+	OpenVPNCSR    string // gzipped base64 PEM
+	CloakUID      string // router encrypted base64
+	L2TPUsername  string // router encrypted base64
+	L2TPPassword  string // router encrypted base64
+	OutlineSecret string // router encrypted base64
+	Proto0Secret  string // router encrypted base64
+	ControlHost   netip.Addr
+}
+
+// PeerAddResponse - peer_add call response.
+type PeerAddResponse struct {
+	APIResponse
+	OpenvpnClientCertificate string `json:"openvpn-client-certificate"`
+}
+
+// PeerRequest - peer_del, throttle_on and throttle_off calls.
+type PeerRequest struct {
+	PeerPublicKey []byte
+	WgPublicKey   []byte // the brigade interface public key
+}
+
+// PeerListRequest - peer_list call.
+type PeerListRequest struct {
+	WgPublicKey []byte // the brigade interface public key
+}
+
+// PeerInfo - the peer as the endpoint lists it, the values are
+// the peer_add parameters as they were sent, the empty ones were not.
+type PeerInfo struct {
+	AllowedIPs    string `json:"allowed-ips"`
+	WgPSK         string `json:"wg-psk-key,omitempty"`
+	CloakUID      string `json:"cloak-uid,omitempty"`
+	L2TPUsername  string `json:"l2tp-username,omitempty"`
+	L2TPPassword  string `json:"l2tp-password,omitempty"`
+	OutlineSecret string `json:"outline-ss-password,omitempty"`
+	Proto0Secret  string `json:"p0-id,omitempty"`
+	ControlHost   string `json:"control-host,omitempty"`
+}
+
+// PeerListResponse - peer_list call response.
+type PeerListResponse struct {
+	APIResponse
+	Peers map[string]PeerInfo `json:"peers"` // by the base64 peer public key
+}
+
+// PeerInfo - the peer the endpoint lists after the call.
+func (req PeerAddRequest) PeerInfo() PeerInfo {
+	info := PeerInfo{
+		AllowedIPs:    joinPrefixes(req.AllowedIPs),
+		WgPSK:         encodeKey(req.WgPSK),
+		CloakUID:      req.CloakUID,
+		OutlineSecret: req.OutlineSecret,
+		Proto0Secret:  req.Proto0Secret,
 	}
 
-	if cloakBypasUID != "" {
-		query += fmt.Sprintf("&cloak-uid=%s",
-			url.QueryEscape(cloakBypasUID),
-		)
+	if req.L2TPUsername != "" && req.L2TPPassword != "" {
+		info.L2TPUsername, info.L2TPPassword = req.L2TPUsername, req.L2TPPassword
 	}
 
-	if ipsecUsername != "" && ipsecPassword != "" {
-		query += fmt.Sprintf("&l2tp-username=%s&l2tp-password=%s",
-			url.QueryEscape(ipsecUsername),
-			url.QueryEscape(ipsecPassword),
-		)
+	if req.ControlHost.IsValid() {
+		info.ControlHost = req.ControlHost.String()
 	}
 
-	if outlineSecret != "" {
-		query += fmt.Sprintf("&outline-ss-password=%s",
-			url.QueryEscape(outlineSecret),
-		)
+	return info
+}
+
+// Diff - the names of the differing parameters.
+func (p PeerInfo) Diff(other PeerInfo) []string {
+	var diff []string
+
+	for _, f := range []struct {
+		name string
+		a, b string
+	}{
+		{"allowed-ips", p.AllowedIPs, other.AllowedIPs},
+		{"wg-psk-key", p.WgPSK, other.WgPSK},
+		{"cloak-uid", p.CloakUID, other.CloakUID},
+		{"l2tp-username", p.L2TPUsername, other.L2TPUsername},
+		{"l2tp-password", p.L2TPPassword, other.L2TPPassword},
+		{"outline-ss-password", p.OutlineSecret, other.OutlineSecret},
+		{"p0-id", p.Proto0Secret, other.Proto0Secret},
+		{"control-host", p.ControlHost, other.ControlHost},
+	} {
+		if f.a != f.b {
+			diff = append(diff, f.name)
+		}
 	}
 
-	if proto0Secret != "" {
-		query += fmt.Sprintf("&p0-id=%s",
-			url.QueryEscape(proto0Secret),
-		)
+	return diff
+}
+
+// StatRequest - stat call.
+type StatRequest struct {
+	WgPublicKey []byte // the brigade interface public key
+}
+
+// WgAdd - wg_add endpoint API call.
+func (c *Client) WgAdd(ctx context.Context, req WgAddRequest) error {
+	q := newQuery("wg_add", encodeKey(req.WgPrivateKey))
+	q.add("external-ip", req.ExternalIP.String())
+	q.add("wireguard-port", strconv.Itoa(int(req.WireguardPort)))
+	q.add("internal-nets", joinPrefixes(req.InternalNets))
+
+	if req.CloakDomain != "" {
+		q.add("cloak-domain", req.CloakDomain)
 	}
 
-	if keydeskIPv6.IsValid() {
-		query += fmt.Sprintf("&control-host=%s", url.QueryEscape(keydeskIPv6.String()))
+	if req.OpenVPNCACert != "" && req.OpenVPNCAKey != "" {
+		q.add("openvpn-ca-crt", req.OpenVPNCACert)
+		q.add("openvpn-ca-key", req.OpenVPNCAKey)
 	}
 
-	body, err := getAPIRequest(ident, actualAddrPort, calculatedAddrPort, query, CallTimeout)
-	if err != nil {
-		apiErr := &APIResponse{}
+	if req.L2TPPresharedKey != "" {
+		q.add("l2tp-preshared-key", req.L2TPPresharedKey)
+	}
 
-		if errors.As(err, &apiErr) {
-			switch apiErr.Code {
-			case "151":
-				fmt.Fprintf(os.Stderr, "WARNING: api: %s\n", err)
+	if req.OutlinePort != 0 {
+		q.add("outline-ss-port", strconv.Itoa(int(req.OutlinePort)))
+	}
 
-				return body, nil
-			}
-		}
+	if req.Proto0Domain != "" {
+		q.add("p0-domain", req.Proto0Domain)
+	}
 
-		return nil, fmt.Errorf("api: %w", err)
+	if _, err := c.call(ctx, c.Timeouts.WgAdd, q); err != nil {
+		return fmt.Errorf("api: %w", err)
 	}
 
-	return body, nil
+	return nil
 }
 
-// WgPeerDel - peer_del endpoint-API call.
-func WgPeerDel(ident string, actualAddrPort, calculatedAddrPort netip.AddrPort, wgPub, wgIfacePub []byte) error {
-	query := fmt.Sprintf("peer_del=%s&wg-public-key=%s",
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgPub)),
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgIfacePub)),
-	)
+// WgDel - wg_del endpoint API call.
+// The absent interface is not an error.
+func (c *Client) WgDel(ctx context.Context, req WgDelRequest) error {
+	q := newQuery("wg_del", encodeKey(req.WgPrivateKey))
+
+	if _, err := c.call(ctx, c.Timeouts.WgDel, q); err != nil {
+		switch {
+		case errors.Is(err, ErrInterfaceMissing):
+			fmt.Fprintf(os.Stderr, "WARNING: api: %s\n", err)
+
+			return nil
+		case errors.Is(err, ErrDelInProgress):
+			fmt.Fprintf(os.Stderr, "WARNING: del attempt: %s\n", err)
+
+			// the deletion is in progress, give it the call time.
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("del attempt: %w", ctx.Err())
+			case <-time.After(callTimeout(c.Timeouts.WgDel)):
+			}
+
+			return nil
+		}
 
-	_, err := getAPIRequest(ident, actualAddrPort, calculatedAddrPort, query, CallTimeout)
-	if err != nil {
 		return fmt.Errorf("api: %w", err)
 	}
 
 	return nil
 }
 
-// WgAdd - wg_add endpoint-API call.
-func WgAdd(
-	ident string,
-	actualAddrPort,
-	calculatedAddrPort netip.AddrPort,
-	wgIfacePriv []byte,
-	endpointIPv4 netip.Addr,
-	endpointPort uint16,
-	localNetIPv4, localNetIPv6 netip.Prefix,
-	ovcFakeDomain string,
-	ovcCACert string,
-	ovcRouterCAKey string,
-	ipsecPSK string,
-	outlinePort uint16,
-	proto0FakeDomain string,
-) error {
-	// fmt.Fprintf(os.Stderr, "WgAdd: %d\n", len(wgPriv))
-
-	query := fmt.Sprintf("wg_add=%s&external-ip=%s&wireguard-port=%s&internal-nets=%s",
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgIfacePriv)),
-		url.QueryEscape(endpointIPv4.String()),
-		url.QueryEscape(fmt.Sprintf("%d", endpointPort)),
-		url.QueryEscape(localNetIPv4.String()+","+localNetIPv6.String()),
-	)
-
-	if ovcFakeDomain != "" {
-		query += fmt.Sprintf("&cloak-domain=%s",
-			url.QueryEscape(ovcFakeDomain),
-		)
+// PeerAdd - peer_add endpoint API call.
+// The existing peer is not an error. The stand-in responds with the test openvpn certificate.
+func (c *Client) PeerAdd(ctx context.Context, req PeerAddRequest) (*PeerAddResponse, error) {
+	if err := checkKeys(req.PeerPublicKey); err != nil {
+		return nil, fmt.Errorf("peer key: %w", err)
 	}
 
-	if ovcCACert != "" && len(ovcRouterCAKey) > 0 {
-		query += fmt.Sprintf("&openvpn-ca-crt=%s&openvpn-ca-key=%s",
-			url.QueryEscape(ovcCACert),
-			url.QueryEscape(ovcRouterCAKey),
-		)
+	q := newQuery("peer_add", encodeKey(req.PeerPublicKey))
+	q.add("wg-public-key", encodeKey(req.WgPublicKey))
+	q.add("wg-psk-key", encodeKey(req.WgPSK))
+	q.add("allowed-ips", joinPrefixes(req.AllowedIPs))
+
+	if req.OpenVPNCSR != "" {
+		q.add("openvpn-client-csr", req.OpenVPNCSR)
 	}
 
-	if ipsecPSK != "" {
-		query += fmt.Sprintf("&l2tp-preshared-key=%s",
-			url.QueryEscape(ipsecPSK),
-		)
+	if req.CloakUID != "" {
+		q.add("cloak-uid", req.CloakUID)
 	}
 
-	if outlinePort != 0 {
-		query += fmt.Sprintf("&outline-ss-port=%d",
-			outlinePort,
-		)
+	if req.L2TPUsername != "" && req.L2TPPassword != "" {
+		q.add("l2tp-username", req.L2TPUsername)
+		q.add("l2tp-password", req.L2TPPassword)
 	}
 
-	if proto0FakeDomain != "" {
-		query += fmt.Sprintf("&p0-domain=%s",
-			url.QueryEscape(proto0FakeDomain),
-		)
+	if req.OutlineSecret != "" {
+		q.add("outline-ss-password", req.OutlineSecret)
 	}
 
-	_, err := getAPIRequest(ident, actualAddrPort, calculatedAddrPort, query, CallTimeout)
+	if req.Proto0Secret != "" {
+		q.add("p0-id", req.Proto0Secret)
+	}
+
+	if req.ControlHost.IsValid() {
+		q.add("control-host", req.ControlHost.String())
+	}
+
+	body, err := c.call(ctx, c.Timeouts.PeerAdd, q)
 	if err != nil {
-		return fmt.Errorf("api: %w", err)
+		if !errors.Is(err, ErrPeerExists) {
+			return nil, fmt.Errorf("api: %w", err)
+		}
+
+		fmt.Fprintf(os.Stderr, "WARNING: api: %s\n", err)
 	}
 
-	return nil
-}
+	if c.IsStandIn() {
+		return &PeerAddResponse{APIResponse: APIResponse{Code: "0"}, OpenvpnClientCertificate: TestCert}, nil
+	}
 
-// WgDel - wg_del endpoint API call.
-func WgDel(ident string, actualAddrPort, calculatedAddrPort netip.AddrPort, wgIfacePriv []byte) error {
-	query := fmt.Sprintf("wg_del=%s",
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgIfacePriv)),
-	)
+	resp := &PeerAddResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("api payload: %w", err)
+	}
 
-	var err error
+	return resp, nil
+}
 
-	if _, err = getAPIRequest(ident, actualAddrPort, calculatedAddrPort, query, CallTimeout); err != nil {
-		apiErr := &APIResponse{}
+// PeerDel - peer_del endpoint API call.
+func (c *Client) PeerDel(ctx context.Context, req PeerRequest) error {
+	return c.peerCall(ctx, "peer_del", c.Timeouts.PeerDel, req)
+}
 
-		if errors.As(err, &apiErr) {
-			switch apiErr.Code {
-			case "128":
-				fmt.Fprintf(os.Stderr, "WARNING: api: %s\n", err)
+// ThrottleOn - throttle_on endpoint API call.
+func (c *Client) ThrottleOn(ctx context.Context, req PeerRequest) error {
+	return c.peerCall(ctx, "throttle_on", c.Timeouts.Throttle, req)
+}
 
-				return nil
-			case "146":
-				fmt.Fprintf(os.Stderr, "WARNING: del attempt: %s\n", err)
+// ThrottleOff - throttle_off endpoint API call.
+func (c *Client) ThrottleOff(ctx context.Context, req PeerRequest) error {
+	return c.peerCall(ctx, "throttle_off", c.Timeouts.Throttle, req)
+}
 
-				<-time.After(CallTimeout)
+func (c *Client) peerCall(ctx context.Context, call string, timeout time.Duration, req PeerRequest) error {
+	if err := checkKeys(req.PeerPublicKey); err != nil {
+		return fmt.Errorf("peer key: %w", err)
+	}
 
-				return nil
-			}
-		}
+	q := newQuery(call, encodeKey(req.PeerPublicKey))
+	q.add("wg-public-key", encodeKey(req.WgPublicKey))
 
+	if _, err := c.call(ctx, timeout, q); err != nil {
 		return fmt.Errorf("api: %w", err)
 	}
 
 	return nil
 }
 
-// WgStat - stat endpoint API call.
-func WgStat(ident string, actualAddrPort, calculatedAddrPort netip.AddrPort, wgIfacePub []byte) (*WGStatsIn, error) {
-	query := fmt.Sprintf("stat=%s",
-		url.QueryEscape(base64.StdEncoding.WithPadding(base64.StdPadding).EncodeToString(wgIfacePub)),
-	)
-
-	body, err := getAPIRequest(ident, actualAddrPort, calculatedAddrPort, query, CallTimeout)
+// PeerList - peer_list endpoint API call.
+// The stand-in responds with no peers.
+func (c *Client) PeerList(ctx context.Context, req PeerListRequest) (*PeerListResponse, error) {
+	body, err := c.call(ctx, c.Timeouts.PeerList, newQuery("peer_list", encodeKey(req.WgPublicKey)))
 	if err != nil {
 		return nil, fmt.Errorf("api: %w", err)
 	}
 
-	if body == nil {
-		return nil, nil
+	resp := &PeerListResponse{}
+	if err := json.Unmarshal(body, resp); err != nil {
+		return nil, fmt.Errorf("api payload: %w", err)
+	}
+
+	if resp.Peers == nil {
+		resp.Peers = map[string]PeerInfo{}
+	}
+
+	return resp, nil
+}
+
+// Stat - stat endpoint API call.
+func (c *Client) Stat(ctx context.Context, req StatRequest) (*WGStatsIn, error) {
+	body, err := c.call(ctx, c.Timeouts.Stat, newQuery("stat", encodeKey(req.WgPublicKey)))
+	if err != nil {
+		return nil, fmt.Errorf("api: %w", err)
 	}
 
 	data := &WGStatsIn{}